@@ -7,9 +7,10 @@ const (
 
 	settHideBanner = "hide-banner"
 
-	settServerHost  = "server.host"
-	settServerTrace = "server.trace"
-	settServerCors  = "server.cors"
+	settServerHost           = "server.host"
+	settServerTrace          = "server.trace"
+	settServerCors           = "server.cors"
+	settServerTrustedProxies = "server.trusted-proxies"
 
 	settDBURL = "db.url"
 
@@ -20,6 +21,36 @@ const (
 
 	settEthereumURL = "ethereum.url"
 
+	settJobsExpirationInterval  = "jobs.expiration.interval"
+	settJobsAlertsInterval      = "jobs.alerts.interval"
+	settJobsPublicationInterval = "jobs.publication.interval"
+
+	settAttachmentsStorage     = "attachments.storage"
+	settAttachmentsLocalDir    = "attachments.local.dir"
+	settAttachmentsS3Endpoint  = "attachments.s3.endpoint"
+	settAttachmentsS3Region    = "attachments.s3.region"
+	settAttachmentsS3Bucket    = "attachments.s3.bucket"
+	settAttachmentsS3AccessKey = "attachments.s3.access-key"
+	settAttachmentsS3SecretKey = "attachments.s3.secret-key"
+	settAttachmentsMaxSize     = "attachments.max-size"
+	settAttachmentsTypes       = "attachments.types"
+	settAttachmentsScanCommand = "attachments.scan.command"
+
+	settAdminImpersonationTTL = "admin.impersonation.ttl"
+
+	settConnectsWeekly = "connects.weekly"
+	settConnectsCosts  = "connects.costs"
+
+	settChatEditWindow = "chat.edit-window"
+
+	settEmailSMTPAddr       = "email.smtp.addr"
+	settEmailSMTPUsername   = "email.smtp.username"
+	settEmailSMTPPassword   = "email.smtp.password"
+	settEmailFrom           = "email.from"
+	settEmailAPIURL         = "email.api-url"
+	settEmailWebURL         = "email.web-url"
+	settEmailDigestInterval = "email.digest.interval"
+
 	settCfgRelease = "release"
 	settCfgEnv     = "env"
 	settBuilt      = "built"
@@ -8,7 +8,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,13 +18,17 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"optrispace.com/work/pkg/clog"
 	"optrispace.com/work/pkg/controller"
 	_ "optrispace.com/work/pkg/docs"
+	"optrispace.com/work/pkg/model"
 	"optrispace.com/work/pkg/service"
 	"optrispace.com/work/pkg/service/ethsvc"
+	"optrispace.com/work/pkg/service/filesvc"
+	"optrispace.com/work/pkg/service/mailsvc"
 	"optrispace.com/work/pkg/web"
 )
 
@@ -30,7 +36,12 @@ const (
 	shutdownTimeout = 30 * time.Second
 )
 
-var errSpecifyServerHost = errors.New("specify server host to listen")
+var (
+	errSpecifyServerHost         = errors.New("specify server host to listen")
+	errUnknownAttachmentsStorage = errors.New("unknown attachments storage")
+	errInvalidConnectsCost       = errors.New("invalid connects cost")
+	errInvalidTrustedProxy       = errors.New("invalid trusted proxy")
+)
 
 // startCmd represents the start command
 var startCmd = &cobra.Command{
@@ -55,9 +66,44 @@ func init() {
 		cc.PersistentFlags().Bool(settHideBanner, false, "hide banner")
 
 		cc.PersistentFlags().Bool(settServerCors, false, "enable CORS with allow any (*) origin")
+		cc.PersistentFlags().StringSlice(settServerTrustedProxies, nil,
+			"CIDRs of reverse proxies whose X-Forwarded-For header is trusted, for example 10.0.0.0/8; the address of the connection is the client IP, if unset")
 
 		cc.PersistentFlags().StringP(settNotificationTgToken, "T", "", "telegram bot token for send notifications")
 		cc.PersistentFlags().Int64SliceP(settNotificationTgChats, "C", nil, "telegram chat list for send notifications")
+
+		cc.PersistentFlags().Duration(settJobsExpirationInterval, time.Minute, "interval between checks for expired jobs; checks are disabled, if zero")
+		cc.PersistentFlags().Duration(settJobsPublicationInterval, time.Minute, "interval between checks for scheduled drafts to publish; checks are disabled, if zero")
+		cc.PersistentFlags().Duration(settJobsAlertsInterval, time.Minute, "interval between matching of new jobs against saved searches; alerts are disabled, if zero")
+
+		cc.PersistentFlags().String(settAttachmentsStorage, "local", "storage for attached files: local or s3")
+		cc.PersistentFlags().String(settAttachmentsLocalDir, "./data/attachments", "directory for attached files in the local storage")
+		cc.PersistentFlags().String(settAttachmentsS3Endpoint, "", "S3-compatible storage URL, like http://localhost:9000 for MinIO")
+		cc.PersistentFlags().String(settAttachmentsS3Region, "us-east-1", "S3 region")
+		cc.PersistentFlags().String(settAttachmentsS3Bucket, "", "S3 bucket for attached files")
+		cc.PersistentFlags().String(settAttachmentsS3AccessKey, "", "S3 access key")
+		cc.PersistentFlags().String(settAttachmentsS3SecretKey, "", "S3 secret key. The better way to specify this value, use appropriate environment variable.")
+		cc.PersistentFlags().Int64(settAttachmentsMaxSize, 10<<20, "maximum size of an attached file in bytes")
+		cc.PersistentFlags().StringSlice(settAttachmentsTypes, []string{
+			"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "application/zip", "text/plain",
+		}, "allowed MIME types of attached files")
+		cc.PersistentFlags().String(settAttachmentsScanCommand, "", "command to scan attached files for viruses, the file is passed to stdin and exit code 1 means infected (like `clamdscan -`); no scan, if unset")
+
+		cc.PersistentFlags().Duration(settAdminImpersonationTTL, time.Hour, "lifetime of impersonation tokens issued by admins")
+
+		cc.PersistentFlags().Int32(settConnectsWeekly, 0, "weekly allowance of connects spent on applications, for example 60; connects are not limited, if zero")
+		cc.PersistentFlags().StringSlice(settConnectsCosts, []string{"0=1", "500=2", "2000=4"},
+			"costs of applications in connects per job budget tier as budget_from=cost; jobs without budget cost as the lowest tier")
+
+		cc.PersistentFlags().Duration(settChatEditWindow, 15*time.Minute, "time after posting when the author is able to edit or delete a chat message")
+
+		cc.PersistentFlags().String(settEmailSMTPAddr, "", "host:port of SMTP server for sending emails; emails are not sent, if unset")
+		cc.PersistentFlags().String(settEmailSMTPUsername, "", "SMTP username; no authentication, if unset")
+		cc.PersistentFlags().String(settEmailSMTPPassword, "", "SMTP password. The better way to specify this value, use appropriate environment variable.")
+		cc.PersistentFlags().String(settEmailFrom, "OptriSpace <noreply@optrispace.com>", "sender address of emails")
+		cc.PersistentFlags().String(settEmailAPIURL, "http://localhost:8080", "public URL of this API for unsubscribe links in emails")
+		cc.PersistentFlags().String(settEmailWebURL, "", "URL of the web application for chat links in emails; no links, if unset")
+		cc.PersistentFlags().Duration(settEmailDigestInterval, 10*time.Minute, "interval between checks for persons due for email digest of unread messages; digests are disabled, if zero")
 	})
 }
 
@@ -86,6 +132,14 @@ func doStart(ctx context.Context) error {
 		e.Pre(middleware.CORS())
 	}
 
+	ipExtractor, err := newIPExtractor(viper.GetStringSlice(settServerTrustedProxies))
+	if err != nil {
+		return err
+	}
+
+	// the client IP restricts API keys and is recorded in sessions, so headers of untrusted clients are ignored
+	e.IPExtractor = ipExtractor
+
 	e.HTTPErrorHandler = web.GetErrorHandler(e.HTTPErrorHandler)
 	e.Use(middleware.Recover())
 
@@ -141,15 +195,75 @@ func addControllers(ctx context.Context, e *echo.Echo) error {
 
 	eth := ethsvc.NewEthereum(viper.GetString(settEthereumURL))
 
+	connectsPolicy, err := newConnectsPolicy()
+	if err != nil {
+		return err
+	}
+
+	connectsSvc := service.NewConnects(db, connectsPolicy)
+
+	jobSvc := service.NewJob(db)
+
+	storage, err := newAttachmentsStorage()
+	if err != nil {
+		return err
+	}
+
+	if interval := viper.GetDuration(settJobsExpirationInterval); interval > 0 {
+		go runPeriodically(ctx, interval, "Expired jobs suspended", jobSvc.SuspendExpired)
+	}
+
+	if interval := viper.GetDuration(settJobsPublicationInterval); interval > 0 {
+		go runPeriodically(ctx, interval, "Scheduled jobs published", jobSvc.PublishScheduled)
+	}
+
+	savedSearchSvc := service.NewSavedSearch(db)
+
+	if interval := viper.GetDuration(settJobsAlertsInterval); interval > 0 {
+		go runPeriodically(ctx, interval, "Saved search alerts sent", savedSearchSvc.MatchNewJobs)
+	}
+
+	mailer, err := newMailer()
+	if err != nil {
+		return err
+	}
+
+	emailDigestSvc := service.NewEmailDigest(db, mailer, viper.GetString(settEmailAPIURL), viper.GetString(settEmailWebURL))
+
+	if interval := viper.GetDuration(settEmailDigestInterval); interval > 0 && mailer != nil {
+		go runPeriodically(ctx, interval, "Email digests sent", emailDigestSvc.SendDigests)
+	}
+
+	chatSvc := service.NewChat(db, viper.GetDuration(settChatEditWindow))
+	chatEvents := service.NewChatEvents(db, viper.GetString(settDBURL))
+
+	go func() {
+		if e := chatEvents.Listen(ctx); e != nil {
+			log.Error().Err(e).Msg("Chat events listener stopped")
+		}
+	}()
+
 	rr = append(rr,
-		controller.NewAuth(sm, service.NewPerson(db)),
-		controller.NewJob(sm, service.NewJob(db)),
-		controller.NewApplication(sm, service.NewApplication(db)),
+		controller.NewAuth(sm, service.NewPerson(db), connectsSvc, chatSvc),
+		controller.NewJob(sm, jobSvc),
+		controller.NewApplication(sm, service.NewApplication(db, connectsPolicy)),
 		controller.NewPerson(sm, service.NewPerson(db)),
 		controller.NewContract(sm, service.NewContract(db, eth)),
 		controller.NewNotification(service.NewNotification(token, chats...)),
 		controller.NewStats(sm, service.NewStats(db)),
-		controller.NewChat(sm, service.NewChat(db)),
+		controller.NewChat(sm, chatSvc, chatEvents),
+		controller.NewInvitation(sm, service.NewInvitation(db, connectsPolicy)),
+		controller.NewPersonNotification(sm, service.NewPersonNotification(db)),
+		controller.NewModeration(sm, service.NewModeration(db)),
+		controller.NewSavedJob(sm, service.NewSavedJob(db)),
+		controller.NewPersonBlock(sm, service.NewPersonBlock(db)),
+		controller.NewSession(sm, service.NewSession(db)),
+		controller.NewAPIKey(sm, service.NewAPIKey(db)),
+		controller.NewSavedSearch(sm, savedSearchSvc),
+		controller.NewEmailDigest(sm, emailDigestSvc),
+		controller.NewAdmin(sm, service.NewAdmin(db, viper.GetDuration(settAdminImpersonationTTL)), connectsSvc),
+		controller.NewAttachment(sm, service.NewAttachment(db, storage, newAttachmentsScanner(),
+			viper.GetInt64(settAttachmentsMaxSize), viper.GetStringSlice(settAttachmentsTypes))),
 	)
 
 	controller.SwaggerRegister(e)
@@ -159,3 +273,125 @@ func addControllers(ctx context.Context, e *echo.Echo) error {
 
 	return nil
 }
+
+// newAttachmentsStorage creates storage for attached files according to settings
+func newAttachmentsStorage() (filesvc.Storage, error) {
+	switch kind := viper.GetString(settAttachmentsStorage); kind {
+	case "local":
+		return filesvc.NewLocal(viper.GetString(settAttachmentsLocalDir))
+	case "s3":
+		return filesvc.NewS3(
+			viper.GetString(settAttachmentsS3Endpoint),
+			viper.GetString(settAttachmentsS3Region),
+			viper.GetString(settAttachmentsS3Bucket),
+			viper.GetString(settAttachmentsS3AccessKey),
+			viper.GetString(settAttachmentsS3SecretKey),
+		)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownAttachmentsStorage, kind)
+	}
+}
+
+// newAttachmentsScanner creates virus scanner for attached files according to settings
+func newAttachmentsScanner() filesvc.Scanner {
+	command := strings.Fields(viper.GetString(settAttachmentsScanCommand))
+	if len(command) == 0 {
+		return filesvc.NewNoopScanner()
+	}
+
+	return filesvc.NewCommandScanner(command[0], command[1:]...)
+}
+
+// newMailer creates mailer according to settings, it is nil if SMTP server is not specified
+func newMailer() (mailsvc.Mailer, error) {
+	addr := strings.TrimSpace(viper.GetString(settEmailSMTPAddr))
+	if addr == "" {
+		return nil, nil //nolint: nilnil
+	}
+
+	return mailsvc.NewSMTP(addr,
+		viper.GetString(settEmailSMTPUsername),
+		viper.GetString(settEmailSMTPPassword),
+		viper.GetString(settEmailFrom),
+	)
+}
+
+// newIPExtractor returns how the client IP is taken from requests
+// The X-Forwarded-For header is used only if it is set by the trusted proxies.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, p := range trustedProxies {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidTrustedProxy, p)
+		}
+
+		options = append(options, echo.TrustIPRange(network))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// newConnectsPolicy creates policy of connects spent on applications according to settings
+func newConnectsPolicy() (*model.ConnectsPolicy, error) {
+	policy := &model.ConnectsPolicy{
+		WeeklyAllowance: viper.GetInt32(settConnectsWeekly),
+	}
+
+	for _, c := range viper.GetStringSlice(settConnectsCosts) {
+		budget, cost, ok := strings.Cut(c, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errInvalidConnectsCost, c)
+		}
+
+		budgetFrom, err := decimal.NewFromString(strings.TrimSpace(budget))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidConnectsCost, c)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(cost), 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %s", errInvalidConnectsCost, c)
+		}
+
+		policy.Tiers = append(policy.Tiers, model.ConnectsTier{
+			BudgetFrom: budgetFrom,
+			Cost:       int32(n),
+		})
+	}
+
+	return policy, nil
+}
+
+// runPeriodically calls the task with the interval until the context is done
+// The task returns count of processed items, they are logged with the message
+func runPeriodically(ctx context.Context, interval time.Duration, msg string, task func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := task(ctx)
+			if err != nil {
+				log.Error().Err(err).Str("task", msg).Msg("Periodic task failed")
+				continue
+			}
+
+			if n > 0 {
+				log.Info().Int("count", n).Msg(msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+func TestAdmin(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		meURL = appURL + "/me"
+
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		admin     = addPerson(t, "admin")
+
+		job         = addJob(t, "Some job", "Description", customer.ID, "10", "3")
+		application = addApplication(t, job.ID, "I can", "10", performer.ID)
+		_           = addContract(t, customer.ID, performer.ID, application.ID, "Contract", "Description", "10", "3", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	)
+
+	require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{
+		IsAdmin: true,
+		ID:      admin.ID,
+	}))
+
+	t.Run("admin API is available for admins only", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, adminPersonsURL, "", customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, adminPersonsURL+"/"+performer.ID, "", customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/impersonate", `{"reason":"Support"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, adminPersonsURL+"/"+performer.ID+"/jobs", "", customer.AccessToken.String, http.StatusForbidden)
+	})
+
+	t.Run("search persons", func(t *testing.T) {
+		pp := doRequest[[]*model.Person](t, http.MethodGet, adminPersonsURL+"?q=perf", "", admin.AccessToken.String)
+		if assert.Len(t, pp, 1) {
+			assert.Equal(t, performer.ID, pp[0].ID)
+		}
+
+		pp = doRequest[[]*model.Person](t, http.MethodGet, adminPersonsURL+"?q=0x8ca2702c5bcc50d79d9a059d58607028aa36aa6c", "", admin.AccessToken.String)
+		if assert.Len(t, pp, 1) {
+			assert.Equal(t, customer.ID, pp[0].ID)
+		}
+
+		pp = doRequest[[]*model.Person](t, http.MethodGet, adminPersonsURL+"?limit=2", "", admin.AccessToken.String)
+		assert.Len(t, pp, 2)
+
+		e := doFailedRequest(t, http.MethodGet, adminPersonsURL+"?limit=x", "", admin.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "limit has an invalid format", e.Message)
+
+		p := doRequest[model.Person](t, http.MethodGet, adminPersonsURL+"/"+performer.ID, "", admin.AccessToken.String)
+		assert.Equal(t, "performer", p.Login)
+
+		doFailedRequest(t, http.MethodGet, adminPersonsURL+"/nothing", "", admin.AccessToken.String, http.StatusNotFound)
+	})
+
+	t.Run("grant and revoke admin privileges", func(t *testing.T) {
+		p := doRequest[model.Person](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/grant-admin", `{"reason":"New moderator"}`, admin.AccessToken.String)
+		assert.True(t, p.IsAdmin)
+
+		e := doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/grant-admin", "", admin.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "person is already admin", e.Message)
+
+		p = doRequest[model.Person](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/revoke-admin", `{"reason":"Left the team"}`, admin.AccessToken.String)
+		assert.False(t, p.IsAdmin)
+
+		e = doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+admin.ID+"/revoke-admin", "", admin.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "admin can not change own privileges", e.Message)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+performer.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 2) {
+			assert.Equal(t, model.AuditRevokeAdmin, aa[0].Action)
+			assert.Equal(t, "Left the team", aa[0].Reason)
+			assert.Equal(t, model.AuditGrantAdmin, aa[1].Action)
+			assert.Equal(t, admin.ID, aa[1].ActorID)
+		}
+	})
+
+	t.Run("impersonate person", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+customer.ID+"/impersonate", "", admin.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "reason is required", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+admin.ID+"/impersonate", `{"reason":"Support"}`, admin.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "admin can not be impersonated", e.Message)
+
+		uc := doRequest[model.UserContext](t, http.MethodPost, adminPersonsURL+"/"+customer.ID+"/impersonate", `{"reason":"Ticket 42"}`, admin.AccessToken.String)
+		assert.NotEmpty(t, uc.Token)
+		assert.NotEqual(t, customer.AccessToken.String, uc.Token)
+		assert.Equal(t, customer.ID, uc.Subject.ID)
+		assert.Equal(t, admin.ID, uc.ImpersonatedBy)
+
+		me := doRequest[model.UserContext](t, http.MethodGet, meURL, "", uc.Token)
+		assert.Equal(t, customer.ID, me.Subject.ID)
+		assert.Equal(t, admin.ID, me.ImpersonatedBy)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+customer.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 2) {
+			assert.Equal(t, model.AuditImpersonatedRequest, aa[0].Action)
+			assert.Equal(t, "GET /me", aa[0].Reason)
+			assert.Equal(t, model.AuditImpersonate, aa[1].Action)
+			assert.Equal(t, "Ticket 42", aa[1].Reason)
+		}
+
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from impersonations where token_hash = $1`, tokenHash(uc.Token)).Scan(&n))
+		assert.Equal(t, 1, n, "only the hash of the token is stored")
+
+		require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{IsAdmin: false, ID: admin.ID}))
+		doFailedRequest(t, http.MethodGet, meURL, "", uc.Token, http.StatusUnauthorized)
+		require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{IsAdmin: true, ID: admin.ID}))
+	})
+
+	t.Run("view person's data", func(t *testing.T) {
+		jj := doRequest[[]*model.JobCardDTO](t, http.MethodGet, adminPersonsURL+"/"+customer.ID+"/jobs", "", admin.AccessToken.String)
+		if assert.Len(t, jj, 1) {
+			assert.Equal(t, job.ID, jj[0].ID)
+		}
+
+		aa := doRequest[[]*model.ApplicationDTO](t, http.MethodGet, adminPersonsURL+"/"+performer.ID+"/applications", "", admin.AccessToken.String)
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, application.ID, aa[0].ID)
+		}
+
+		cc := doRequest[[]*model.ContractDTO](t, http.MethodGet, adminPersonsURL+"/"+performer.ID+"/contracts", "", admin.AccessToken.String)
+		assert.Len(t, cc, 1)
+
+		rr := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+performer.ID, "", admin.AccessToken.String)
+		if assert.Len(t, rr, 4) {
+			assert.Equal(t, model.AuditViewContracts, rr[0].Action)
+			assert.Equal(t, model.AuditViewApplications, rr[1].Action)
+		}
+	})
+
+	t.Run("reset access token", func(t *testing.T) {
+		doRequest[model.UserContext](t, http.MethodGet, meURL, "", performer.AccessToken.String)
+
+		key := doRequest[model.APIKeyDTO](t, http.MethodPost, apiKeysURL, `{"name":"Bot","scopes":["jobs:read"]}`, performer.AccessToken.String)
+		doRequest[model.UserContext](t, http.MethodGet, meURL, "", key.Key)
+
+		impersonation := doRequest[model.UserContext](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/impersonate", `{"reason":"Support"}`, admin.AccessToken.String)
+
+		doRequest[map[string]any](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/reset-token", `{"reason":"Token leaked"}`, admin.AccessToken.String)
+
+		doFailedRequest(t, http.MethodGet, meURL, "", performer.AccessToken.String, http.StatusUnauthorized)
+		doFailedRequest(t, http.MethodGet, meURL, "", key.Key, http.StatusUnauthorized)
+		doFailedRequest(t, http.MethodGet, meURL, "", impersonation.Token, http.StatusUnauthorized)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+performer.ID, "", admin.AccessToken.String)
+		if assert.NotEmpty(t, aa) {
+			assert.Equal(t, model.AuditResetToken, aa[0].Action)
+			assert.Equal(t, "Token leaked", aa[0].Reason)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package intest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var apiKeysURL = appURL + "/me/api-keys"
+
+func TestAPIKeys(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer = addPerson(t, "customer")
+		stranger = addPerson(t, "stranger")
+	)
+
+	key := doRequest[model.APIKeyDTO](t, http.MethodPost, apiKeysURL,
+		`{"name":"Job poster","scopes":["jobs:write","jobs:read","jobs:write"]}`, customer.AccessToken.String)
+
+	t.Run("add", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(key.Key, model.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+		assert.Equal(t, "Job poster", key.Name)
+		assert.Equal(t, []string{model.ScopeJobsRead, model.ScopeJobsWrite}, key.Scopes)
+		assert.Nil(t, key.ExpiresAt)
+
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"scopes":["jobs:write"]}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"name":"Key"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"name":"Key","scopes":["admin"]}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"name":"Key","scopes":["jobs:write"],"ip_allowlist":["localhost"]}`,
+			customer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"name":"Key","scopes":["jobs:write"],"expires_at":"2020-01-01T00:00:00Z"}`,
+			customer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		me := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", key.Key)
+		assert.Equal(t, customer.ID, me.Subject.ID)
+		assert.Equal(t, key.ID, me.APIKeyID)
+		assert.Equal(t, key.Scopes, me.Scopes)
+
+		job := doRequest[model.JobDTO](t, http.MethodPost, jobsURL, `{"title":"Title","description":"Description"}`, key.Key)
+		assert.Equal(t, customer.ID, job.CreatedBy)
+
+		doFailedRequest(t, http.MethodGet, contractsURL, "", key.Key, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, appURL+"/applications", "", key.Key, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, apiKeysURL, "", key.Key, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, apiKeysURL, `{"name":"Key","scopes":["chats:write"]}`, key.Key, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, sessionsURL, "", key.Key, http.StatusForbidden)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		kk := doRequest[[]*model.APIKeyDTO](t, http.MethodGet, apiKeysURL, "", customer.AccessToken.String)
+		if assert.Len(t, kk, 1) {
+			assert.Equal(t, key.ID, kk[0].ID)
+			assert.Empty(t, kk[0].Key)
+			assert.NotNil(t, kk[0].LastUsedAt)
+		}
+
+		kk = doRequest[[]*model.APIKeyDTO](t, http.MethodGet, apiKeysURL, "", stranger.AccessToken.String)
+		assert.Empty(t, kk)
+	})
+
+	t.Run("ip allowlist", func(t *testing.T) {
+		k := doRequest[model.APIKeyDTO](t, http.MethodPost, apiKeysURL,
+			`{"name":"Office","scopes":["jobs:read"],"ip_allowlist":["192.0.2.0/24"]}`, customer.AccessToken.String)
+		assert.Equal(t, []string{"192.0.2.0/24"}, k.IPAllowlist)
+
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", k.Key, http.StatusUnauthorized)
+
+		// the client is not a trusted proxy, so its headers do not change the IP
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, appURL+"/me", nil)
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+k.Key)
+		req.Header.Set(echo.HeaderXForwardedFor, "192.0.2.5")
+		req.Header.Set(echo.HeaderXRealIP, "192.0.2.5")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		k := doRequest[model.APIKeyDTO](t, http.MethodPost, apiKeysURL,
+			`{"name":"Temporary","scopes":["jobs:read"],"expires_at":"`+expiresAt.Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+		if assert.NotNil(t, k.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*k.ExpiresAt))
+		}
+
+		doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", k.Key)
+
+		_, err := db.ExecContext(ctx, `update api_keys set expires_at = now() - interval '1 minute' where id = $1`, k.ID)
+		require.NoError(t, err)
+
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", k.Key, http.StatusUnauthorized)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		doFailedRequest(t, http.MethodDelete, apiKeysURL+"/unknown", "", customer.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodDelete, apiKeysURL+"/"+key.ID, "", stranger.AccessToken.String, http.StatusNotFound)
+
+		doRequest[map[string]any](t, http.MethodDelete, apiKeysURL+"/"+key.ID, "", customer.AccessToken.String)
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", key.Key, http.StatusUnauthorized)
+	})
+}
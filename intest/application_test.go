@@ -46,7 +46,7 @@ func TestCreateApplication(t *testing.T) {
 	t.Run("with validation errors", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -68,7 +68,7 @@ func TestCreateApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -277,7 +277,7 @@ func TestCreateApplication(t *testing.T) {
 	t.Run("returns error if job is suspended", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -302,7 +302,7 @@ func TestCreateApplication(t *testing.T) {
 		err = pgdao.New(db).JobSuspend(ctx, suspendedJob.ID)
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -338,7 +338,7 @@ func TestCreateApplication(t *testing.T) {
 	t.Run("returns error if customer tries to apply on his own job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -386,7 +386,7 @@ func TestCreateApplication(t *testing.T) {
 	t.Run("returns error if application already exists", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -408,7 +408,7 @@ func TestCreateApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -456,7 +456,7 @@ func TestCreateApplication(t *testing.T) {
 	t.Run("creates application", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -478,7 +478,7 @@ func TestCreateApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -551,7 +551,7 @@ func TestGetMyApplications(t *testing.T) {
 	t.Run("returns an empty array if there are no applications", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -580,7 +580,7 @@ func TestGetMyApplications(t *testing.T) {
 	t.Run("returns applications belong to a person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -610,7 +610,7 @@ func TestGetMyApplications(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant1, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -622,7 +622,7 @@ func TestGetMyApplications(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -742,7 +742,7 @@ func TestGetApplication(t *testing.T) {
 	t.Run("returns error if application does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -773,7 +773,7 @@ func TestGetApplication(t *testing.T) {
 	t.Run("returns error if requested application belongs to another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -791,7 +791,7 @@ func TestGetApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant1, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -806,7 +806,7 @@ func TestGetApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -838,7 +838,7 @@ func TestGetApplication(t *testing.T) {
 	t.Run("returns application if requested by customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -860,7 +860,7 @@ func TestGetApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			DisplayName:     "applicant1",
@@ -910,7 +910,7 @@ func TestGetApplication(t *testing.T) {
 	t.Run("returns application", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -928,7 +928,7 @@ func TestGetApplication(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1004,7 +1004,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 	t.Run("returns error if requested job belongs to another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1014,7 +1014,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		anotherCustomer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		anotherCustomer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1056,7 +1056,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1087,7 +1087,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 	t.Run("returns an empty array if there are no applications", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1130,7 +1130,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 	t.Run("returns applications for specific job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1152,7 +1152,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant1, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			DisplayName:     "applicant1",
@@ -1160,7 +1160,7 @@ func TestGetApplicationsForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1289,7 +1289,7 @@ func TestGetApplicationForJob(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1320,7 +1320,7 @@ func TestGetApplicationForJob(t *testing.T) {
 	t.Run("returns error if application requested by not an applicant", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1363,7 +1363,7 @@ func TestGetApplicationForJob(t *testing.T) {
 	t.Run("returns empty response if application does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1385,7 +1385,7 @@ func TestGetApplicationForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1416,7 +1416,7 @@ func TestGetApplicationForJob(t *testing.T) {
 	t.Run("returns application", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1438,7 +1438,7 @@ func TestGetApplicationForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1486,7 +1486,7 @@ func TestGetApplicationForJob(t *testing.T) {
 	t.Run("returns application with contract", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1508,7 +1508,7 @@ func TestGetApplicationForJob(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:          pgdao.NewID(),
 			Login:       pgdao.NewID(),
 			DisplayName: "applicant1",
@@ -1571,3 +1571,302 @@ func TestGetApplicationForJob(t *testing.T) {
 		}
 	})
 }
+
+func TestEditAndWithdrawApplication(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	applicationsURL := appURL + "/" + applicationsResourceName
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+	job := addJob(t, "Job", "Description", customer.ID, "10", "3")
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+
+	chat, err := queries.ChatGetByTopic(ctx, "urn:application:"+application.ID)
+	require.NoError(t, err)
+
+	t.Run("only applicant is able to edit application", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPut, applicationsURL+"/"+application.ID,
+			`{"comment":"Changed","price":"12"}`, customer.AccessToken.String, http.StatusForbidden)
+
+		e := doFailedRequest(t, http.MethodPut, applicationsURL+"/"+application.ID,
+			`{"comment":"","price":"12"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "comment is required", e.Message)
+	})
+
+	t.Run("edit is saved as revision and posted to chat", func(t *testing.T) {
+		a := doRequest[model.ApplicationDTO](t, http.MethodPut, applicationsURL+"/"+application.ID,
+			`{"comment":"I am ready and experienced","price":"12"}`, performer.AccessToken.String)
+		assert.Equal(t, "I am ready and experienced", a.Comment)
+		assert.Equal(t, "12", a.Price.String())
+
+		rr := doRequest[[]*model.ApplicationRevisionDTO](t, http.MethodGet, applicationsURL+"/"+application.ID+"/revisions", "", customer.AccessToken.String)
+		if assert.Len(t, rr, 2) {
+			assert.EqualValues(t, 2, rr[0].Version)
+			assert.Equal(t, "12", rr[0].Price.String())
+			assert.Equal(t, "I am ready", rr[1].Comment)
+		}
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		if assert.Len(t, mm, 2) {
+			assert.Equal(t, performer.ID, mm[1].CreatedBy)
+			assert.Equal(t, "The application terms have been changed: price has been changed from 10 to 12, comment has been changed\n\nI am ready and experienced", mm[1].Text)
+			assert.Equal(t, model.MessageSystem, mm[1].Kind)
+
+			doFailedRequest(t, http.MethodPut, chatsURL+"/"+chat.ID+"/messages/"+mm[1].ID, `{"text":"Nothing has changed"}`,
+				performer.AccessToken.String, http.StatusBadRequest)
+		}
+
+		doRequest[model.ApplicationDTO](t, http.MethodPut, applicationsURL+"/"+application.ID,
+			`{"comment":"I am ready and experienced","price":"12"}`, performer.AccessToken.String)
+
+		rr = doRequest[[]*model.ApplicationRevisionDTO](t, http.MethodGet, applicationsURL+"/"+application.ID+"/revisions", "", performer.AccessToken.String)
+		assert.Len(t, rr, 2, "unchanged terms do not make revision")
+	})
+
+	t.Run("withdrawal frees applicant to apply again", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application.ID+"/withdraw", "", customer.AccessToken.String, http.StatusForbidden)
+
+		a := doRequest[model.ApplicationDTO](t, http.MethodPost, applicationsURL+"/"+application.ID+"/withdraw", "", performer.AccessToken.String)
+		assert.NotNil(t, a.WithdrawnAt)
+
+		e := doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application.ID+"/withdraw", "", performer.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "application is withdrawn", e.Message)
+
+		doFailedRequest(t, http.MethodPut, applicationsURL+"/"+application.ID,
+			`{"comment":"Back","price":"12"}`, performer.AccessToken.String, http.StatusBadRequest)
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		if assert.Len(t, mm, 3) {
+			assert.Equal(t, "The application has been withdrawn", mm[2].Text)
+			assert.Equal(t, model.MessageSystem, mm[2].Kind)
+
+			doFailedRequest(t, http.MethodDelete, chatsURL+"/"+chat.ID+"/messages/"+mm[2].ID, "", performer.AccessToken.String, http.StatusBadRequest)
+		}
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", customer.AccessToken.String)
+		if assert.Len(t, c.Messages, 3) {
+			assert.Equal(t, &model.SystemMessagePayload{
+				Event:         model.SystemEventApplicationWithdrawn,
+				JobID:         job.ID,
+				ApplicationID: application.ID,
+			}, c.Messages[2].Payload)
+		}
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", "")
+		assert.Zero(t, card.ApplicationsCount)
+
+		doFailedRequest(t, http.MethodPost, contractsURL,
+			`{"application_id":"`+application.ID+`","title":"Contract","description":"Do it","price":"12"}`, customer.AccessToken.String, http.StatusBadRequest)
+
+		again := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"I am back","price":"11"}`, performer.AccessToken.String)
+		assert.NotEqual(t, application.ID, again.ID)
+
+		aa := doRequest[[]*model.ApplicationDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/applications", "", customer.AccessToken.String)
+		if assert.Len(t, aa, 2) {
+			assert.Equal(t, again.ID, aa[0].ID)
+			assert.Nil(t, aa[0].WithdrawnAt)
+			assert.NotNil(t, aa[1].WithdrawnAt)
+		}
+	})
+
+	t.Run("application with contract can not be changed", func(t *testing.T) {
+		applicant := addPersonWithEthereumAddress(t, "applicant", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		a := addApplication(t, job.ID, "Hire me", "10", applicant.ID)
+		addContract(t, customer.ID, applicant.ID, a.ID, "Contract", "Do it", "10", "3", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+
+		e := doFailedRequest(t, http.MethodPut, applicationsURL+"/"+a.ID,
+			`{"comment":"Changed","price":"20"}`, applicant.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "application already has a contract", e.Message)
+
+		doFailedRequest(t, http.MethodPost, applicationsURL+"/"+a.ID+"/withdraw", "", applicant.AccessToken.String, http.StatusBadRequest)
+	})
+}
+
+func TestApplicationStatuses(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	applicationsURL := appURL + "/" + applicationsResourceName
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer1 := addPersonWithEthereumAddress(t, "performer1", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+	performer2 := addPersonWithEthereumAddress(t, "performer2", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+	job := addJob(t, "Job", "Description", customer.ID, "10", "3")
+
+	application1 := addApplication(t, job.ID, "I am ready", "10", performer1.ID)
+	application2 := addApplication(t, job.ID, "Me too", "9", performer2.ID)
+
+	t.Run("new application has new status", func(t *testing.T) {
+		a := doRequest[model.ApplicationDTO](t, http.MethodGet, applicationsURL+"/"+application1.ID, "", performer1.AccessToken.String)
+		assert.Equal(t, model.ApplicationNew, a.Status)
+	})
+
+	t.Run("only job owner changes status", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application1.ID+"/status",
+			`{"status":"hired"}`, performer1.AccessToken.String, http.StatusForbidden)
+
+		e := doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application1.ID+"/status",
+			`{"status":"unknown"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "status has an invalid format", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application1.ID+"/status",
+			`{"status":"shortlisted","rejection_reason":"No"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "rejection_reason is allowed for rejected status only", e.Message)
+	})
+
+	t.Run("status changes are notified", func(t *testing.T) {
+		a := doRequest[model.ApplicationDTO](t, http.MethodPost, applicationsURL+"/"+application1.ID+"/status",
+			`{"status":"shortlisted"}`, customer.AccessToken.String)
+		assert.Equal(t, model.ApplicationShortlisted, a.Status)
+
+		e := doFailedRequest(t, http.MethodPost, applicationsURL+"/"+application1.ID+"/status",
+			`{"status":"shortlisted"}`, customer.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "application is already shortlisted", e.Message)
+
+		a = doRequest[model.ApplicationDTO](t, http.MethodPost, applicationsURL+"/"+application2.ID+"/status",
+			`{"status":"rejected","rejection_reason":"Not enough experience"}`, customer.AccessToken.String)
+		assert.Equal(t, model.ApplicationRejected, a.Status)
+		assert.Equal(t, "Not enough experience", a.RejectionReason)
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", performer2.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, model.NotificationApplicationStatus, nn[0].Kind)
+			assert.Equal(t, `Your application for the job "Job" is rejected: Not enough experience`, nn[0].Text)
+			assert.Equal(t, "/applications/"+application2.ID, nn[0].Link)
+		}
+
+		nn = doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", performer1.AccessToken.String)
+		assert.Len(t, nn, 1)
+	})
+
+	t.Run("list by status", func(t *testing.T) {
+		aa := doRequest[[]*model.ApplicationDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/applications?status=shortlisted", "", customer.AccessToken.String)
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, application1.ID, aa[0].ID)
+		}
+
+		aa = doRequest[[]*model.ApplicationDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/applications", "", customer.AccessToken.String)
+		assert.Len(t, aa, 2)
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+job.ID+"/applications?status=unknown", "", customer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("job card contains counts per status for owner", func(t *testing.T) {
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", customer.AccessToken.String)
+		assert.Equal(t, map[string]int64{model.ApplicationShortlisted: 1, model.ApplicationRejected: 1}, card.ApplicationStatusCounts)
+
+		card = doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", performer1.AccessToken.String)
+		assert.Empty(t, card.ApplicationStatusCounts)
+
+		cards := doRequest[[]*model.JobCardDTO](t, http.MethodGet, jobsURL+"/mine", "", customer.AccessToken.String)
+		if assert.Len(t, cards, 1) {
+			assert.EqualValues(t, 1, cards[0].ApplicationStatusCounts[model.ApplicationShortlisted])
+		}
+	})
+}
+
+func TestScreeningQuestions(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer1 := addPersonWithEthereumAddress(t, "performer1", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+	performer2 := addPersonWithEthereumAddress(t, "performer2", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+	job := addJob(t, "Job", "Description", customer.ID, "10", "3")
+
+	var qq []*model.JobQuestionDTO
+
+	t.Run("job owner sets questions", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPut, jobsURL+"/"+job.ID+"/questions",
+			`{"questions":[{"kind":"single_choice","text":"Language?","options":["Go"]}]}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "questions[0].options must contain at least 2 options", e.Message)
+
+		doFailedRequest(t, http.MethodPut, jobsURL+"/"+job.ID+"/questions",
+			`{"questions":[]}`, performer1.AccessToken.String, http.StatusForbidden)
+
+		qq = doRequest[[]*model.JobQuestionDTO](t, http.MethodPut, jobsURL+"/"+job.ID+"/questions",
+			`{"questions":[
+				{"kind":"text","text":"Experience?","required":true},
+				{"kind":"single_choice","text":"Language?","options":["Go","Rust"],"required":true},
+				{"kind":"multi_choice","text":"Databases?","options":["PostgreSQL","MySQL","Redis"]}
+			]}`, customer.AccessToken.String)
+		if assert.Len(t, qq, 3) {
+			assert.EqualValues(t, 1, qq[0].Position)
+			assert.Equal(t, model.QuestionSingleChoice, qq[1].Kind)
+			assert.Equal(t, []string{"Go", "Rust"}, qq[1].Options)
+		}
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", performer1.AccessToken.String)
+		assert.Equal(t, qq, card.Questions)
+	})
+
+	t.Run("answers are validated", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Ready","price":"10","answers":[{"question_id":"`+qq[0].ID+`","text":"5 years"}]}`,
+			performer1.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "answer to question 2 is required", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Ready","price":"10","answers":[
+				{"question_id":"`+qq[0].ID+`","text":"5 years"},
+				{"question_id":"`+qq[1].ID+`","options":["Go","Rust"]}
+			]}`,
+			performer1.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "answer to question 2 has an invalid format", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Ready","price":"10","answers":[
+				{"question_id":"`+qq[0].ID+`","text":"5 years"},
+				{"question_id":"`+qq[1].ID+`","options":["Java"]}
+			]}`,
+			performer1.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, `answer to question 2 contains unknown option "Java"`, e.Message)
+	})
+
+	var application1 model.ApplicationDTO
+
+	t.Run("answers are saved", func(t *testing.T) {
+		application1 = doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Ready","price":"10","answers":[
+				{"question_id":"`+qq[0].ID+`","text":"5 years"},
+				{"question_id":"`+qq[1].ID+`","options":["Go"]},
+				{"question_id":"`+qq[2].ID+`","options":["PostgreSQL","Redis"]}
+			]}`, performer1.AccessToken.String)
+		if assert.Len(t, application1.Answers, 3) {
+			assert.Equal(t, "5 years", application1.Answers[0].Text)
+			assert.Equal(t, []string{"PostgreSQL", "Redis"}, application1.Answers[2].Options)
+		}
+
+		doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Me too","price":"9","answers":[
+				{"question_id":"`+qq[0].ID+`","text":"1 year"},
+				{"question_id":"`+qq[1].ID+`","options":["Rust"]}
+			]}`, performer2.AccessToken.String)
+	})
+
+	t.Run("answers are compared side by side", func(t *testing.T) {
+		aa := doRequest[[]*model.ApplicationDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/applications", "", customer.AccessToken.String)
+		if assert.Len(t, aa, 2) {
+			for _, a := range aa {
+				if assert.Len(t, a.Answers, 3) {
+					assert.Equal(t, qq[0].ID, a.Answers[0].QuestionID)
+					assert.Equal(t, qq[1].ID, a.Answers[1].QuestionID)
+					assert.Equal(t, qq[2].ID, a.Answers[2].QuestionID)
+				}
+			}
+			// the last application is the first one
+			assert.Equal(t, []string{"Rust"}, aa[0].Answers[1].Options)
+			assert.Empty(t, aa[0].Answers[2].Options)
+		}
+	})
+
+	t.Run("questions can not be changed after applications", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPut, jobsURL+"/"+job.ID+"/questions",
+			`{"questions":[]}`, customer.AccessToken.String, http.StatusBadRequest)
+		assert.Equal(t, "questions can not be changed after the job has got applications", e.Message)
+	})
+}
@@ -0,0 +1,295 @@
+package intest
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var (
+	attachmentsResourceName = "attachments"
+	attachmentsURL          = appURL + "/" + attachmentsResourceName
+)
+
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+// uploadFile sends multipart request with the file
+func uploadFile(t *testing.T, name string, content []byte, token string) *http.Response {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("file", name)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, attachmentsURL, body)
+	require.NoError(t, err)
+	req.Header.Set(clog.HeaderXHint, t.Name())
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return res
+}
+
+func uploadAttachment(t *testing.T, name string, content []byte, token string) model.AttachmentDTO {
+	res := uploadFile(t, name, content, token)
+	require.Equal(t, http.StatusCreated, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+	a := model.AttachmentDTO{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&a))
+
+	return a
+}
+
+func downloadAttachment(t *testing.T, id, token string) *http.Response {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachmentsURL+"/"+id, nil)
+	require.NoError(t, err)
+	req.Header.Set(clog.HeaderXHint, t.Name())
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestUploadAttachment(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	person := addPerson(t, "uploader")
+
+	t.Run("returns error if file is not specified", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, attachmentsURL, `{}`, person.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("returns error if file type is not allowed", func(t *testing.T) {
+		res := uploadFile(t, "virus.exe", append([]byte("MZ\x90\x00\x03"), bytes.Repeat([]byte{0xff}, 64)...), person.AccessToken.String)
+
+		if assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, "Invalid result status code '%s'", res.Status) {
+			e := model.BackendError{}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&e))
+			assert.Equal(t, "file type is not allowed", e.Message)
+		}
+	})
+
+	t.Run("returns error if file is empty", func(t *testing.T) {
+		res := uploadFile(t, "empty.txt", nil, person.AccessToken.String)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, "Invalid result status code '%s'", res.Status)
+	})
+
+	t.Run("uploads file", func(t *testing.T) {
+		a := uploadAttachment(t, "../../mockup.png", pngContent, person.AccessToken.String)
+
+		assert.NotEmpty(t, a.ID)
+		assert.Equal(t, "mockup.png", a.Name)
+		assert.Equal(t, "image/png", a.ContentType)
+		assert.EqualValues(t, len(pngContent), a.Size)
+		assert.Equal(t, person.ID, a.CreatedBy)
+		assert.Empty(t, a.JobID)
+		assert.Empty(t, a.ApplicationID)
+
+		res := downloadAttachment(t, a.ID, person.AccessToken.String)
+		if assert.Equal(t, http.StatusOK, res.StatusCode, "Invalid result status code '%s'", res.Status) {
+			assert.Equal(t, "image/png", res.Header.Get(echo.HeaderContentType))
+			assert.Contains(t, res.Header.Get(echo.HeaderContentDisposition), `filename=mockup.png`)
+
+			bb, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, pngContent, bb)
+		}
+
+		res = downloadAttachment(t, a.ID, "")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "unbound attachment must be available only for the uploader")
+	})
+}
+
+func TestJobAndApplicationAttachments(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+	stranger := addPersonWithEthereumAddress(t, "stranger", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa79")
+
+	spec := uploadAttachment(t, "spec.txt", []byte("The job specification"), customer.AccessToken.String)
+
+	var job model.JobDTO
+
+	t.Run("unable to attach foreign file", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL,
+			`{"title":"Title","description":"Description","attachment_ids":["`+spec.ID+`"]}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "attachment_ids contain unknown or already used attachments", e.Message)
+	})
+
+	t.Run("job attachments are public", func(t *testing.T) {
+		job = doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Title","description":"Description","attachment_ids":["`+spec.ID+`"]}`, customer.AccessToken.String)
+
+		aa := doRequest[[]*model.AttachmentDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/attachments", "", "")
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, spec.ID, aa[0].ID)
+			assert.Equal(t, job.ID, aa[0].JobID)
+		}
+
+		res := downloadAttachment(t, spec.ID, "")
+		if assert.Equal(t, http.StatusOK, res.StatusCode, "Invalid result status code '%s'", res.Status) {
+			bb, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "The job specification", string(bb))
+		}
+	})
+
+	t.Run("file can not be attached twice", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, jobsURL,
+			`{"title":"Another","description":"Description","attachment_ids":["`+spec.ID+`"]}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("application attachments are available for applicant and job owner only", func(t *testing.T) {
+		proposal := uploadAttachment(t, "proposal.png", pngContent, performer.AccessToken.String)
+
+		application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"My proposal","price":"1.5","attachment_ids":["`+proposal.ID+`"]}`, performer.AccessToken.String)
+
+		for _, token := range []string{performer.AccessToken.String, customer.AccessToken.String} {
+			aa := doRequest[[]*model.AttachmentDTO](t, http.MethodGet, appURL+"/applications/"+application.ID+"/attachments", "", token)
+			if assert.Len(t, aa, 1) {
+				assert.Equal(t, proposal.ID, aa[0].ID)
+				assert.Equal(t, application.ID, aa[0].ApplicationID)
+			}
+
+			res := downloadAttachment(t, proposal.ID, token)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "Invalid result status code '%s'", res.Status)
+		}
+
+		doFailedRequest(t, http.MethodGet, appURL+"/applications/"+application.ID+"/attachments", "", stranger.AccessToken.String, http.StatusNotFound)
+
+		res := downloadAttachment(t, proposal.ID, stranger.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+		res = downloadAttachment(t, proposal.ID, "")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+	})
+
+	t.Run("only uploader is able to delete file", func(t *testing.T) {
+		doFailedRequest(t, http.MethodDelete, attachmentsURL+"/"+spec.ID, "", performer.AccessToken.String, http.StatusForbidden)
+
+		doRequest[[]byte](t, http.MethodDelete, attachmentsURL+"/"+spec.ID, "", customer.AccessToken.String)
+
+		aa := doRequest[[]*model.AttachmentDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/attachments", "", "")
+		assert.Empty(t, aa)
+
+		res := downloadAttachment(t, spec.ID, customer.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+	})
+}
+
+func TestMessageAttachments(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+
+		job = addJob(t, "Job", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+	messagesURL := chatsURL + "/" + chat.ID + "/messages"
+
+	img := image.NewNRGBA(image.Rect(0, 0, 600, 300))
+	for x := 0; x < 600; x++ {
+		for y := 0; y < 300; y++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, png.Encode(buf, img))
+
+	screenshot := uploadAttachment(t, "screenshot.png", buf.Bytes(), performer.AccessToken.String)
+	assert.True(t, screenshot.HasThumbnail)
+
+	notes := uploadAttachment(t, "notes.txt", []byte("Delivered as agreed"), performer.AccessToken.String)
+	assert.False(t, notes.HasThumbnail)
+
+	var m model.Message
+
+	t.Run("message with files only", func(t *testing.T) {
+		m = doRequest[model.Message](t, http.MethodPost, messagesURL,
+			`{"attachment_ids":["`+screenshot.ID+`","`+notes.ID+`"]}`, performer.AccessToken.String)
+		assert.Empty(t, m.Text)
+		if assert.Len(t, m.Attachments, 2) {
+			assert.Equal(t, m.ID, m.Attachments[0].MessageID)
+		}
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", customer.AccessToken.String)
+		if assert.Len(t, c.Messages, 2) {
+			assert.Len(t, c.Messages[1].Attachments, 2)
+		}
+
+		doFailedRequest(t, http.MethodPost, messagesURL, `{"attachment_ids":["`+notes.ID+`"]}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, messagesURL, `{}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("files are available for participants only", func(t *testing.T) {
+		res := downloadAttachment(t, notes.ID, customer.AccessToken.String)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+		res = downloadAttachment(t, notes.ID, stranger.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+		res = downloadAttachment(t, notes.ID, "")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+	})
+
+	t.Run("thumbnail", func(t *testing.T) {
+		res := downloadAttachment(t, screenshot.ID+"/thumbnail", customer.AccessToken.String)
+		if assert.Equal(t, http.StatusOK, res.StatusCode, "Invalid result status code '%s'", res.Status) {
+			assert.Equal(t, "image/png", res.Header.Get(echo.HeaderContentType))
+
+			cfg, _, err := image.DecodeConfig(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, 256, cfg.Width)
+			assert.Equal(t, 128, cfg.Height)
+		}
+
+		res = downloadAttachment(t, screenshot.ID+"/thumbnail", stranger.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+		res = downloadAttachment(t, notes.ID+"/thumbnail", customer.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+	})
+
+	t.Run("files of deleted message are hidden", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodDelete, messagesURL+"/"+m.ID, "", performer.AccessToken.String)
+
+		res := downloadAttachment(t, notes.ID, customer.AccessToken.String)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "Invalid result status code '%s'", res.Status)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", customer.AccessToken.String)
+		if assert.Len(t, c.Messages, 2) {
+			assert.Empty(t, c.Messages[1].Attachments)
+		}
+	})
+}
@@ -69,7 +69,11 @@ func TestAuth(t *testing.T) {
 				assert.Regexp(t, regexp.MustCompile("^Person[0-9]+$"), d.DisplayName)
 				assert.Equal(t, e.Subject.CreatedAt, d.CreatedAt.UTC())
 				assert.Equal(t, "", d.Email)
-				assert.Equal(t, e.Token, d.AccessToken.String)
+
+				s, err := pgdao.New(db).SessionGetByTokenHash(ctx, tokenHash(e.Token))
+				if assert.NoError(t, err, "session of the token must be started") {
+					assert.Equal(t, d.ID, s.PersonID)
+				}
 			}
 		}
 	})
@@ -116,7 +120,11 @@ func TestAuth(t *testing.T) {
 				assert.Equal(t, "John Smith", d.DisplayName)
 				assert.Equal(t, e.Subject.CreatedAt, d.CreatedAt.UTC())
 				assert.Equal(t, "me@domain.tld", d.Email)
-				assert.Equal(t, e.Token, d.AccessToken.String)
+
+				s, err := pgdao.New(db).SessionGetByTokenHash(ctx, tokenHash(e.Token))
+				if assert.NoError(t, err, "session of the token must be started") {
+					assert.Equal(t, d.ID, s.PersonID)
+				}
 			}
 		}
 	})
@@ -235,7 +243,11 @@ func TestAuth(t *testing.T) {
 				assert.Equal(t, "John Smith", d.DisplayName)
 				assert.Equal(t, e.Subject.CreatedAt, d.CreatedAt.UTC())
 				assert.Equal(t, "me@domain.tld", d.Email)
-				assert.Equal(t, e.Token, d.AccessToken.String)
+
+				s, err := pgdao.New(db).SessionGetByTokenHash(ctx, tokenHash(e.Token))
+				if assert.NoError(t, err, "session of the token must be started") {
+					assert.Equal(t, d.ID, s.PersonID)
+				}
 			}
 		}
 	})
@@ -369,7 +381,7 @@ func TestChangePassword(t *testing.T) {
 	queries := pgdao.New(db)
 
 	t.Run("ok", func(t *testing.T) {
-		smith, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		smith, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -410,7 +422,7 @@ func TestChangePassword(t *testing.T) {
 	})
 
 	t.Run("invalid old password", func(t *testing.T) {
-		smith, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		smith, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -446,7 +458,7 @@ func TestChangePassword(t *testing.T) {
 	})
 
 	t.Run("invalid authorization token", func(t *testing.T) {
-		smith, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		smith, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -478,7 +490,7 @@ func TestChangePassword(t *testing.T) {
 	})
 
 	t.Run("empty new password", func(t *testing.T) {
-		smith, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		smith, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -559,7 +571,11 @@ func TestLoginTransmutation(t *testing.T) {
 				assert.Equal(t, "John Smith", d.DisplayName)
 				assert.Equal(t, e.Subject.CreatedAt, d.CreatedAt.UTC())
 				assert.Equal(t, "", d.Email)
-				assert.Equal(t, e.Token, d.AccessToken.String)
+
+				s, err := pgdao.New(db).SessionGetByTokenHash(ctx, tokenHash(e.Token))
+				if assert.NoError(t, err, "session of the token must be started") {
+					assert.Equal(t, d.ID, s.PersonID)
+				}
 			}
 		}
 	})
@@ -621,7 +637,11 @@ func TestLoginTransmutation(t *testing.T) {
 				assert.Equal(t, "John Smith", d.DisplayName)
 				assert.Equal(t, e.Subject.CreatedAt, d.CreatedAt.UTC())
 				assert.Equal(t, "", d.Email)
-				assert.Equal(t, e.Token, d.AccessToken.String)
+
+				s, err := pgdao.New(db).SessionGetByTokenHash(ctx, tokenHash(e.Token))
+				if assert.NoError(t, err, "session of the token must be started") {
+					assert.Equal(t, d.ID, s.PersonID)
+				}
 			}
 		}
 	})
@@ -0,0 +1,96 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var blockedURL = appURL + "/me/blocked"
+
+func TestPersonBlocks(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+
+		job        = addJob(t, "Job", "Description", customer.ID, "10", "3")
+		anotherJob = addJob(t, "Another job", "Description", customer.ID, "10", "3")
+		thirdJob   = addJob(t, "Third job", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+	messagesURL := chatsURL + "/" + chat.ID + "/messages"
+
+	t.Run("block", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPut, blockedURL+"/"+customer.ID, "", customer.AccessToken.String, http.StatusBadRequest)
+		doFailedRequest(t, http.MethodPut, blockedURL+"/unknown", "", customer.AccessToken.String, http.StatusNotFound)
+
+		doRequest[map[string]any](t, http.MethodPut, blockedURL+"/"+performer.ID, "", customer.AccessToken.String)
+		doRequest[map[string]any](t, http.MethodPut, blockedURL+"/"+performer.ID, "", customer.AccessToken.String)
+
+		bb := doRequest[[]*model.BlockedPersonDTO](t, http.MethodGet, blockedURL, "", customer.AccessToken.String)
+		if assert.Len(t, bb, 1) {
+			assert.Equal(t, performer.ID, bb[0].ID)
+			assert.Equal(t, "performer", bb[0].DisplayName)
+		}
+
+		assert.Empty(t, doRequest[[]*model.BlockedPersonDTO](t, http.MethodGet, blockedURL, "", performer.AccessToken.String))
+	})
+
+	t.Run("blocked state of participants", func(t *testing.T) {
+		cc := doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", customer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			for _, p := range cc[0].Participants {
+				assert.Equal(t, p.ID == performer.ID, p.Blocked)
+			}
+		}
+
+		cc = doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", performer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			for _, p := range cc[0].Participants {
+				assert.False(t, p.Blocked)
+			}
+		}
+	})
+
+	t.Run("blocked person is unable to contact", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, messagesURL, `{"text":"Hey"}`, performer.AccessToken.String, http.StatusForbidden)
+		doRequest[model.Message](t, http.MethodPost, messagesURL, `{"text":"Bye"}`, customer.AccessToken.String)
+
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+anotherJob.ID+"/applications",
+			`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String, http.StatusForbidden)
+
+		doFailedRequest(t, http.MethodPost, contractsURL,
+			`{"application_id":"`+application.ID+`","title":"Contract","description":"Description","price":"10","duration":3}`,
+			customer.AccessToken.String, http.StatusBadRequest)
+	})
+
+	t.Run("unblock", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodDelete, blockedURL+"/"+performer.ID, "", customer.AccessToken.String)
+		doFailedRequest(t, http.MethodDelete, blockedURL+"/"+performer.ID, "", customer.AccessToken.String, http.StatusNotFound)
+
+		assert.Empty(t, doRequest[[]*model.BlockedPersonDTO](t, http.MethodGet, blockedURL, "", customer.AccessToken.String))
+
+		doRequest[model.Message](t, http.MethodPost, messagesURL, `{"text":"Sorry"}`, performer.AccessToken.String)
+		doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+anotherJob.ID+"/applications",
+			`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	})
+
+	t.Run("blocked customer is unable to invite", func(t *testing.T) {
+		inviteURL := jobsURL + "/" + thirdJob.ID + "/invitations"
+
+		doRequest[map[string]any](t, http.MethodPut, blockedURL+"/"+customer.ID, "", performer.AccessToken.String)
+		doFailedRequest(t, http.MethodPost, inviteURL, `{"login":"performer"}`, customer.AccessToken.String, http.StatusForbidden)
+
+		doRequest[map[string]any](t, http.MethodDelete, blockedURL+"/"+customer.ID, "", performer.AccessToken.String)
+		doRequest[model.InvitationDTO](t, http.MethodPost, inviteURL, `{"login":"performer"}`, customer.AccessToken.String)
+	})
+}
@@ -0,0 +1,204 @@
+package intest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+func TestChatEvents(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+
+		job = addJob(t, "Job", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+
+	t.Run("websocket requires token", func(t *testing.T) {
+		_, res, err := websocket.DefaultDialer.DialContext(ctx, "ws"+strings.TrimPrefix(chatsURL, "http")+"/ws", nil)
+		require.Error(t, err)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	t.Run("websocket pushes messages and typing", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(echo.HeaderAuthorization, "Bearer "+customer.AccessToken.String)
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, "ws"+strings.TrimPrefix(chatsURL, "http")+"/ws", header)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Hello"}`, performer.AccessToken.String)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		ev := new(model.ChatEvent)
+		require.NoError(t, conn.ReadJSON(ev))
+		assert.Equal(t, model.ChatEventMessage, ev.Kind)
+		assert.Equal(t, chat.ID, ev.ChatID)
+		if assert.NotNil(t, ev.Message) {
+			assert.Equal(t, "Hello", ev.Message.Text)
+			assert.Equal(t, performer.ID, ev.Message.CreatedBy)
+		}
+
+		doFailedRequest(t, http.MethodPost, chatsURL+"/"+chat.ID+"/typing", "", stranger.AccessToken.String, http.StatusNotFound)
+		doRequest[map[string]any](t, http.MethodPost, chatsURL+"/"+chat.ID+"/typing", "", performer.AccessToken.String)
+
+		ev = new(model.ChatEvent)
+		require.NoError(t, conn.ReadJSON(ev))
+		assert.Equal(t, model.ChatEventTyping, ev.Kind)
+		assert.Equal(t, performer.ID, ev.PersonID)
+		assert.Nil(t, ev.Message)
+	})
+
+	t.Run("websocket accepts single-use ticket", func(t *testing.T) {
+		wsURL := "ws" + strings.TrimPrefix(chatsURL, "http") + "/ws"
+
+		_, res, err := websocket.DefaultDialer.DialContext(ctx, wsURL+"?access_token="+customer.AccessToken.String, nil)
+		require.Error(t, err)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		}
+
+		ticket := doRequest[model.ChatTicketDTO](t, http.MethodPost, chatsURL+"/ws/ticket", "", customer.AccessToken.String)
+		assert.NotEqual(t, customer.AccessToken.String, ticket.Ticket)
+		assert.True(t, ticket.ExpiresAt.After(time.Now()))
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL+"?ticket="+ticket.Ticket, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"With ticket"}`, performer.AccessToken.String)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		ev := new(model.ChatEvent)
+		require.NoError(t, conn.ReadJSON(ev))
+		if assert.NotNil(t, ev.Message) {
+			assert.Equal(t, "With ticket", ev.Message.Text)
+		}
+
+		_, res, err = websocket.DefaultDialer.DialContext(ctx, wsURL+"?ticket="+ticket.Ticket, nil)
+		require.Error(t, err)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	t.Run("server-sent events push messages", func(t *testing.T) {
+		sseCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(sseCtx, http.MethodGet, chatsURL+"/events", nil)
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+performer.AccessToken.String)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Hi there"}`, customer.AccessToken.String)
+
+		scanner := bufio.NewScanner(res.Body)
+		var event string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "event: ") {
+				event = strings.TrimPrefix(line, "event: ")
+			}
+
+			if strings.HasPrefix(line, "data: ") {
+				ev := new(model.ChatEvent)
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), ev))
+				assert.Equal(t, model.ChatEventMessage, event)
+				if assert.NotNil(t, ev.Message) {
+					assert.Equal(t, "Hi there", ev.Message.Text)
+				}
+				return
+			}
+		}
+
+		t.Fatal("no event received")
+	})
+}
+
+func TestChatReadMarkers(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+
+		job = addJob(t, "Job", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+
+	m2 := doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Second"}`, performer.AccessToken.String)
+	doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Third"}`, performer.AccessToken.String)
+
+	t.Run("unread counts", func(t *testing.T) {
+		cc := doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", customer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			assert.EqualValues(t, 3, cc[0].UnreadCount)
+		}
+
+		cc = doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", performer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			assert.EqualValues(t, 0, cc[0].UnreadCount)
+		}
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", customer.AccessToken.String)
+		assert.EqualValues(t, 3, uc.UnreadMessages)
+	})
+
+	t.Run("read up to the message", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, chatsURL+"/"+chat.ID+"/read", "", stranger.AccessToken.String, http.StatusNotFound)
+
+		doRequest[map[string]any](t, http.MethodPost, chatsURL+"/"+chat.ID+"/read", `{"message_id":"`+m2.ID+`"}`, customer.AccessToken.String)
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", customer.AccessToken.String)
+		assert.EqualValues(t, 1, uc.UnreadMessages)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", performer.AccessToken.String)
+		if assert.Len(t, c.Messages, 3) {
+			assert.Equal(t, []string{customer.ID}, c.Messages[1].ReadBy)
+			assert.Empty(t, c.Messages[2].ReadBy)
+		}
+	})
+
+	t.Run("read all", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodPost, chatsURL+"/"+chat.ID+"/read", "", customer.AccessToken.String)
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", customer.AccessToken.String)
+		assert.EqualValues(t, 0, uc.UnreadMessages)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", performer.AccessToken.String)
+		if assert.Len(t, c.Messages, 3) {
+			assert.Equal(t, []string{customer.ID}, c.Messages[2].ReadBy)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -254,7 +255,7 @@ func TestApplicationChat(t *testing.T) {
 
 func TestCreateChat(t *testing.T) {
 	t.Run("creates a new chat while applying for a job", func(t *testing.T) {
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -266,7 +267,7 @@ func TestCreateChat(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -335,7 +336,7 @@ func TestSendMessageToChat(t *testing.T) {
 	t.Run("with validation errors", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -408,7 +409,7 @@ func TestSendMessageToChat(t *testing.T) {
 	t.Run("returns error if chat does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -439,7 +440,7 @@ func TestSendMessageToChat(t *testing.T) {
 	t.Run("returns error if person is not a participant of this chat", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -453,7 +454,7 @@ func TestSendMessageToChat(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -494,7 +495,7 @@ func TestSendMessageToChat(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -525,7 +526,7 @@ func TestSendMessageToChat(t *testing.T) {
 	t.Run("adds message to chat as an applicant", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -539,7 +540,7 @@ func TestSendMessageToChat(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -612,7 +613,7 @@ func TestSendMessageToChat(t *testing.T) {
 	t.Run("adds message to chat as a customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -630,7 +631,7 @@ func TestSendMessageToChat(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -719,7 +720,7 @@ func TestGetChats(t *testing.T) {
 	t.Run("returns an empty array if there are no chats for the person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -748,7 +749,7 @@ func TestGetChats(t *testing.T) {
 	t.Run("returns only related chats for the customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -766,7 +767,7 @@ func TestGetChats(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -815,7 +816,7 @@ func TestGetChats(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -912,7 +913,7 @@ func TestGetChats(t *testing.T) {
 	t.Run("returns only related chats for the applicant", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -926,7 +927,7 @@ func TestGetChats(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -979,7 +980,7 @@ func TestGetChats(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1028,7 +1029,7 @@ func TestGetChats(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		applicant2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		applicant2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1136,3 +1137,233 @@ func TestGetChats(t *testing.T) {
 		}
 	})
 }
+
+func TestChatMessagesPages(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+
+		job = addJob(t, "Job", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"Message 0","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+
+	for i := 1; i < 60; i++ {
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", fmt.Sprintf(`{"text":"Message %d"}`, i), customer.AccessToken.String)
+	}
+
+	messagesURL := chatsURL + "/" + chat.ID + "/messages"
+
+	t.Run("chat contains the most recent page", func(t *testing.T) {
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", performer.AccessToken.String)
+		if assert.Len(t, c.Messages, 50) {
+			assert.Equal(t, "Message 10", c.Messages[0].Text)
+			assert.Equal(t, "Message 59", c.Messages[49].Text)
+		}
+		assert.True(t, c.HasOlder)
+	})
+
+	t.Run("scroll back and catch up", func(t *testing.T) {
+		latest := doRequest[model.MessagePageDTO](t, http.MethodGet, messagesURL+"?limit=20", "", performer.AccessToken.String)
+		if !assert.Len(t, latest.Messages, 20) {
+			return
+		}
+		assert.Equal(t, "Message 40", latest.Messages[0].Text)
+		assert.True(t, latest.HasOlder)
+		assert.False(t, latest.HasNewer)
+
+		older := doRequest[model.MessagePageDTO](t, http.MethodGet, messagesURL+"?limit=30&before="+latest.Messages[0].ID, "", performer.AccessToken.String)
+		if assert.Len(t, older.Messages, 30) {
+			assert.Equal(t, "Message 10", older.Messages[0].Text)
+			assert.Equal(t, "Message 39", older.Messages[29].Text)
+		}
+		assert.True(t, older.HasOlder)
+		assert.True(t, older.HasNewer)
+
+		oldest := doRequest[model.MessagePageDTO](t, http.MethodGet, messagesURL+"?before="+older.Messages[0].ID, "", performer.AccessToken.String)
+		if assert.Len(t, oldest.Messages, 10) {
+			assert.Equal(t, "Message 0", oldest.Messages[0].Text)
+		}
+		assert.False(t, oldest.HasOlder)
+
+		newer := doRequest[model.MessagePageDTO](t, http.MethodGet, messagesURL+"?limit=5&after="+latest.Messages[14].ID, "", performer.AccessToken.String)
+		if assert.Len(t, newer.Messages, 5) {
+			assert.Equal(t, "Message 55", newer.Messages[0].Text)
+		}
+		assert.True(t, newer.HasOlder)
+		assert.False(t, newer.HasNewer)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, messagesURL, "", stranger.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, messagesURL+"?limit=-1", "", performer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodGet, messagesURL+"?limit=many", "", performer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodGet, messagesURL+"?before=unknown", "", performer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestChatMessageEdits(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+		admin     = addPerson(t, "admin")
+
+		job = addJob(t, "Job", "Description", customer.ID, "10", "3")
+	)
+
+	queries := pgdao.New(db)
+	require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{IsAdmin: true, ID: admin.ID}))
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+
+	m := doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Helo"}`, performer.AccessToken.String)
+	messageURL := chatsURL + "/" + chat.ID + "/messages/" + m.ID
+
+	t.Run("edit", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPut, messageURL, `{"text":"Hi"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPut, messageURL, `{"text":"Hi"}`, stranger.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodPut, messageURL, `{"text":" "}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+
+		e := doRequest[model.Message](t, http.MethodPut, messageURL, `{"text":"Hello"}`, performer.AccessToken.String)
+		assert.Equal(t, "Hello", e.Text)
+		assert.NotNil(t, e.EditedAt)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", customer.AccessToken.String)
+		if assert.Len(t, c.Messages, 2) {
+			assert.Equal(t, "Hello", c.Messages[1].Text)
+			assert.NotNil(t, c.Messages[1].EditedAt)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		doFailedRequest(t, http.MethodDelete, messageURL, "", customer.AccessToken.String, http.StatusForbidden)
+
+		doRequest[map[string]any](t, http.MethodDelete, messageURL, "", performer.AccessToken.String)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", customer.AccessToken.String)
+		if assert.Len(t, c.Messages, 2) {
+			assert.Empty(t, c.Messages[1].Text)
+			assert.NotNil(t, c.Messages[1].DeletedAt)
+		}
+
+		cc := doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", customer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			assert.EqualValues(t, 1, cc[0].UnreadCount) // deleted message is not counted
+		}
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", customer.AccessToken.String)
+		assert.EqualValues(t, 1, uc.UnreadMessages)
+
+		doFailedRequest(t, http.MethodPut, messageURL, `{"text":"Hello again"}`, performer.AccessToken.String, http.StatusBadRequest)
+		doFailedRequest(t, http.MethodDelete, messageURL, "", performer.AccessToken.String, http.StatusBadRequest)
+	})
+
+	t.Run("revisions are available for admins", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, appURL+"/admin/messages/"+m.ID+"/revisions", "", performer.AccessToken.String, http.StatusForbidden)
+
+		rr := doRequest[[]*model.MessageRevisionDTO](t, http.MethodGet, appURL+"/admin/messages/"+m.ID+"/revisions", "", admin.AccessToken.String)
+		if assert.Len(t, rr, 2) {
+			assert.EqualValues(t, 2, rr[0].Version)
+			assert.Equal(t, "Hello", rr[0].Text)
+			assert.Equal(t, "Helo", rr[1].Text)
+		}
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=message&target_id="+m.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, model.AuditViewMessageHistory, aa[0].Action)
+		}
+	})
+
+	t.Run("edit window", func(t *testing.T) {
+		old := doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Old"}`, performer.AccessToken.String)
+		_, err := db.ExecContext(ctx, `update messages set created_at = now() - interval '1 day' where id = $1`, old.ID)
+		require.NoError(t, err)
+
+		doFailedRequest(t, http.MethodPut, chatsURL+"/"+chat.ID+"/messages/"+old.ID, `{"text":"New"}`, performer.AccessToken.String, http.StatusBadRequest)
+	})
+
+	t.Run("system messages are immutable", func(t *testing.T) {
+		s, err := queries.MessageAddSystem(ctx, pgdao.MessageAddSystemParams{
+			ID:        pgdao.NewID(),
+			ChatID:    chat.ID,
+			CreatedBy: customer.ID,
+			Text:      "Contract has been created",
+			Payload:   json.RawMessage(`{"event":"contract_status_changed","contract_id":"1","new_status":"created"}`),
+		})
+		require.NoError(t, err)
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", performer.AccessToken.String)
+		if assert.NotEmpty(t, c.Messages) {
+			m := c.Messages[len(c.Messages)-1]
+			assert.Equal(t, model.MessageSystem, m.Kind)
+			assert.Equal(t, &model.SystemMessagePayload{
+				Event:      model.SystemEventContractStatusChanged,
+				ContractID: "1",
+				NewStatus:  model.ContractCreated,
+			}, m.Payload)
+			assert.Equal(t, model.MessageUser, c.Messages[0].Kind)
+			assert.Nil(t, c.Messages[0].Payload)
+		}
+
+		doFailedRequest(t, http.MethodPut, chatsURL+"/"+chat.ID+"/messages/"+s.ID, `{"text":"Contract has been approved"}`, customer.AccessToken.String, http.StatusBadRequest)
+		doFailedRequest(t, http.MethodDelete, chatsURL+"/"+chat.ID+"/messages/"+s.ID, "", customer.AccessToken.String, http.StatusBadRequest)
+	})
+}
+
+func TestChatMessagesSearch(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPerson(t, "customer")
+		performer = addPerson(t, "performer")
+		stranger  = addPerson(t, "stranger")
+
+		job = addJob(t, "Landing page", "Description", customer.ID, "10", "3")
+	)
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+	chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", performer.AccessToken.String)
+
+	agreed := doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages",
+		`{"text":"We agreed on <b>three</b> deliverables by Friday"}`, customer.AccessToken.String)
+	deleted := doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages",
+		`{"text":"The deliverables are changed"}`, performer.AccessToken.String)
+	doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Hello"}`, performer.AccessToken.String)
+
+	doRequest[map[string]any](t, http.MethodDelete, chatsURL+"/"+chat.ID+"/messages/"+deleted.ID, "", performer.AccessToken.String)
+
+	t.Run("found in chats of the participant", func(t *testing.T) {
+		hh := doRequest[[]*model.MessageSearchHitDTO](t, http.MethodGet, chatsURL+"/search?q=deliverable", "", performer.AccessToken.String)
+		if assert.Len(t, hh, 1) {
+			assert.Equal(t, agreed.ID, hh[0].Message.ID)
+			assert.Equal(t, "urn:application:"+application.ID, hh[0].Topic)
+			assert.Equal(t, job.ID, hh[0].JobID)
+			assert.Equal(t, "Landing page", hh[0].JobTitle)
+			assert.Equal(t, "We agreed on &lt;b&gt;three&lt;/b&gt; <mark>deliverables</mark> by Friday", hh[0].Snippet)
+		}
+
+		hh = doRequest[[]*model.MessageSearchHitDTO](t, http.MethodGet, chatsURL+"/search?q=deliverables+-friday", "", customer.AccessToken.String)
+		assert.Empty(t, hh)
+	})
+
+	t.Run("not found in other chats", func(t *testing.T) {
+		hh := doRequest[[]*model.MessageSearchHitDTO](t, http.MethodGet, chatsURL+"/search?q=deliverables", "", stranger.AccessToken.String)
+		assert.Empty(t, hh)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, chatsURL+"/search", "", performer.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodGet, chatsURL+"/search?q=hello&limit=-1", "", performer.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+}
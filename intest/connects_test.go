@@ -0,0 +1,102 @@
+package intest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+// The test expects connects settings from testdata/test.yaml: 60 connects weekly, the job with budget from 2000 costs 4 connects by default
+func TestConnects(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		meURL = appURL + "/me"
+
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		admin     = addPerson(t, "admin")
+
+		cheapJob     = addJob(t, "Cheap job", "Description", customer.ID, "10", "3")
+		expensiveJob = addJob(t, "Expensive job", "Description", customer.ID, "3000", "3")
+		anotherJob   = addJob(t, "Another job", "Description", customer.ID, "10", "3")
+		invitedJob   = addJob(t, "Invited job", "Description", customer.ID, "10", "3")
+	)
+
+	require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{
+		IsAdmin: true,
+		ID:      admin.ID,
+	}))
+
+	t.Run("new person gets weekly allowance", func(t *testing.T) {
+		uc := doRequest[model.UserContext](t, http.MethodGet, meURL, "", performer.AccessToken.String)
+		if assert.NotNil(t, uc.Connects) {
+			assert.EqualValues(t, 60, uc.Connects.Balance)
+			assert.EqualValues(t, 60, uc.Connects.WeeklyAllowance)
+			assert.NotNil(t, uc.Connects.RefillsAt)
+		}
+	})
+
+	t.Run("applications cost connects per budget tier", func(t *testing.T) {
+		doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+cheapJob.ID+"/applications",
+			`{"comment":"Ready","price":"10"}`, performer.AccessToken.String)
+		doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+expensiveJob.ID+"/applications",
+			`{"comment":"Ready","price":"3000"}`, performer.AccessToken.String)
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, meURL, "", performer.AccessToken.String)
+		if assert.NotNil(t, uc.Connects) {
+			assert.EqualValues(t, 55, uc.Connects.Balance)
+		}
+	})
+
+	t.Run("admin adjusts balance", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/connects",
+			`{"delta":10}`, customer.AccessToken.String, http.StatusForbidden)
+
+		o := doRequest[model.ConnectsDTO](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/connects",
+			`{"delta":-100,"reason":"Spam"}`, admin.AccessToken.String)
+		assert.EqualValues(t, 0, o.Balance)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+performer.ID, "", admin.AccessToken.String)
+		if assert.NotEmpty(t, aa) {
+			assert.Equal(t, model.AuditAdjustConnects, aa[0].Action)
+			assert.Equal(t, "-100 connects: Spam", aa[0].Reason)
+		}
+	})
+
+	t.Run("application is rejected when connects are exhausted", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+anotherJob.ID+"/applications",
+			`{"comment":"Ready","price":"10"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.True(t, strings.HasPrefix(e.Message, "not enough connects: the application costs 1, the balance is 0"), e.Message)
+
+		o := doRequest[model.ConnectsDTO](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/connects",
+			`{"delta":1}`, admin.AccessToken.String)
+		assert.EqualValues(t, 1, o.Balance)
+
+		doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+anotherJob.ID+"/applications",
+			`{"comment":"Ready","price":"10"}`, performer.AccessToken.String)
+	})
+
+	t.Run("accepted invitation costs connects", func(t *testing.T) {
+		invitation := doRequest[model.InvitationDTO](t, http.MethodPost, jobsURL+"/"+invitedJob.ID+"/invitations",
+			`{"login":"performer"}`, customer.AccessToken.String)
+		acceptURL := invitationsURL + "/" + invitation.ID + "/accept"
+
+		e := doFailedRequest(t, http.MethodPost, acceptURL, `{"comment":"Ready","price":"10"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.True(t, strings.HasPrefix(e.Message, "not enough connects"), e.Message)
+
+		doRequest[model.ConnectsDTO](t, http.MethodPost, adminPersonsURL+"/"+performer.ID+"/connects",
+			`{"delta":1}`, admin.AccessToken.String)
+		doRequest[model.InvitationDTO](t, http.MethodPost, acceptURL, `{"comment":"Ready","price":"10"}`, performer.AccessToken.String)
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, meURL, "", performer.AccessToken.String)
+		if assert.NotNil(t, uc.Connects) {
+			assert.EqualValues(t, 0, uc.Connects.Balance)
+		}
+	})
+}
@@ -52,7 +52,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("with validation errors", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -338,13 +338,13 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if application_id does not belong to job owned by customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -367,7 +367,7 @@ func TestCreateContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		anotherCustomer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		anotherCustomer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -403,7 +403,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if customer does not have ethereum_address", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -413,7 +413,7 @@ func TestCreateContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -462,7 +462,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if performer does not have ethereum_address", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -473,7 +473,7 @@ func TestCreateContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -522,7 +522,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if customer and performer are the same person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -576,7 +576,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if customer and performer have the same ethereum_address", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -587,7 +587,7 @@ func TestCreateContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			EthereumAddress: customer.EthereumAddress,
@@ -637,7 +637,7 @@ func TestCreateContract(t *testing.T) {
 	t.Run("returns error if contract between customer and performer already exists", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -648,7 +648,7 @@ func TestCreateContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			EthereumAddress: pgdao.NewID(),
@@ -713,7 +713,7 @@ func TestCreateContract(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -724,7 +724,7 @@ func TestCreateContract(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -807,7 +807,7 @@ func TestCreateContract(t *testing.T) {
 					assert.Equal(t, e.PerformerAddress, d.PerformerAddress)
 					assert.Equal(t, e.CustomerAddress, d.CustomerAddress)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+d.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+d.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -816,6 +816,8 @@ func TestCreateContract(t *testing.T) {
 							assert.Len(t, messages, 1)
 							assert.Equal(t, messages[0].Text, "Contract has been created")
 							assert.Equal(t, messages[0].CreatedBy, customer.ID)
+							assert.Equal(t, model.MessageSystem, messages[0].Kind)
+							assert.JSONEq(t, `{"event":"contract_status_changed","contract_id":"`+d.ID+`","new_status":"created"}`, string(messages[0].Payload))
 						}
 					}
 				}
@@ -825,7 +827,7 @@ func TestCreateContract(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -836,7 +838,7 @@ func TestCreateContract(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -945,19 +947,15 @@ func TestCreateContract(t *testing.T) {
 					assert.Equal(t, e.PerformerAddress, d.PerformerAddress)
 					assert.Equal(t, e.CustomerAddress, d.CustomerAddress)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+d.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+d.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
-							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been created")
-							assert.Equal(t, messages[1].CreatedBy, customer.ID)
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been created")
+							assert.Equal(t, messages[0].CreatedBy, customer.ID)
 						}
 					}
 				}
@@ -988,7 +986,7 @@ func TestGetContracts(t *testing.T) {
 	t.Run("returns an empty array if there are no contracts for the person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		person, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		person, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1017,7 +1015,7 @@ func TestGetContracts(t *testing.T) {
 	t.Run("returns only owned contracts for the person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer1, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1027,7 +1025,7 @@ func TestGetContracts(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer1, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1067,7 +1065,7 @@ func TestGetContracts(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1085,7 +1083,7 @@ func TestGetContracts(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: "performer2",
 			AccessToken: sql.NullString{
@@ -1172,7 +1170,7 @@ func TestGetContract(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1182,7 +1180,7 @@ func TestGetContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1222,7 +1220,7 @@ func TestGetContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1247,7 +1245,7 @@ func TestGetContract(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1272,7 +1270,7 @@ func TestGetContract(t *testing.T) {
 	t.Run("returns success for authorized customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1282,7 +1280,7 @@ func TestGetContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1354,7 +1352,7 @@ func TestGetContract(t *testing.T) {
 	t.Run("returns success for authorized performer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1364,7 +1362,7 @@ func TestGetContract(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1458,7 +1456,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1485,7 +1483,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 	t.Run("returns error for customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1495,7 +1493,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1556,7 +1554,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1566,7 +1564,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1606,7 +1604,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1634,7 +1632,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -1644,7 +1642,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -1705,7 +1703,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractAccepted, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -1723,7 +1721,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -1733,7 +1731,7 @@ func TestSetContractAsAccepted(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -1820,19 +1818,15 @@ func TestSetContractAsAccepted(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractAccepted, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been accepted")
 							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been accepted")
-							assert.Equal(t, messages[1].CreatedBy, performer.ID)
 						}
 					}
 				}
@@ -1865,7 +1859,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1894,7 +1888,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if body is not a valid JSON", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1904,7 +1898,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1965,7 +1959,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error for performer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1975,7 +1969,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2038,7 +2032,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if contract_address is missing", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2048,7 +2042,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2109,7 +2103,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if contract_address is an empty string", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2119,7 +2113,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2182,7 +2176,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if contract_address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2192,7 +2186,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2255,7 +2249,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2265,7 +2259,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2305,7 +2299,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2334,7 +2328,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 	t.Run("returns error if contract has an invalid status", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2344,7 +2338,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2414,7 +2408,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -2424,7 +2418,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -2486,7 +2480,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractDeployed, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -2504,7 +2498,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -2514,7 +2508,7 @@ func TestSetContractAsDeployed(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -2602,19 +2596,15 @@ func TestSetContractAsDeployed(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractDeployed, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
-							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been deployed")
-							assert.Equal(t, messages[1].CreatedBy, customer.ID)
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been deployed")
+							assert.Equal(t, messages[0].CreatedBy, customer.ID)
 						}
 					}
 				}
@@ -2647,7 +2637,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2674,7 +2664,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error for customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2684,7 +2674,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2745,7 +2735,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2755,7 +2745,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2795,7 +2785,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2822,7 +2812,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error if contract address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2832,7 +2822,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2895,7 +2885,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error if contract address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2905,7 +2895,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2968,7 +2958,7 @@ func TestSetContractAsSigned(t *testing.T) {
 	t.Run("returns error if contract has an invalid status", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -2978,7 +2968,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3047,7 +3037,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3057,7 +3047,7 @@ func TestSetContractAsSigned(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3118,7 +3108,7 @@ func TestSetContractAsSigned(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractSigned, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -3136,7 +3126,7 @@ func TestSetContractAsSigned(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3146,7 +3136,7 @@ func TestSetContractAsSigned(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3233,19 +3223,15 @@ func TestSetContractAsSigned(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractSigned, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been signed")
 							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been signed")
-							assert.Equal(t, messages[1].CreatedBy, performer.ID)
 						}
 					}
 				}
@@ -3278,7 +3264,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3305,7 +3291,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error for performer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3315,7 +3301,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3376,7 +3362,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3386,7 +3372,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3426,7 +3412,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3453,7 +3439,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error if contract address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3463,7 +3449,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3526,7 +3512,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error if contract has an invalid status", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3536,7 +3522,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3600,7 +3586,7 @@ func TestSetContractAsFunded(t *testing.T) {
 	t.Run("returns error if contract is not funded", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3610,7 +3596,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3678,7 +3664,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3688,7 +3674,7 @@ func TestSetContractAsFunded(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3749,7 +3735,7 @@ func TestSetContractAsFunded(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractFunded, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -3760,6 +3746,11 @@ func TestSetContractAsFunded(t *testing.T) {
 							assert.Equal(t, messages[0].CreatedBy, customer.ID)
 						}
 					}
+
+					j, err := queries.JobFind(ctx, job.ID)
+					if assert.NoError(t, err) {
+						assert.True(t, j.ClosedAt.Valid, "job must be closed when contract is funded")
+					}
 				}
 			}
 		})
@@ -3767,7 +3758,7 @@ func TestSetContractAsFunded(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3777,7 +3768,7 @@ func TestSetContractAsFunded(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -3864,19 +3855,15 @@ func TestSetContractAsFunded(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractFunded, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
-							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been funded")
-							assert.Equal(t, messages[1].CreatedBy, customer.ID)
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been funded")
+							assert.Equal(t, messages[0].CreatedBy, customer.ID)
 						}
 					}
 				}
@@ -3909,7 +3896,7 @@ func TestSetContractAsApproved(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3936,7 +3923,7 @@ func TestSetContractAsApproved(t *testing.T) {
 	t.Run("returns error for performer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -3946,7 +3933,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4007,7 +3994,7 @@ func TestSetContractAsApproved(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4017,7 +4004,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4057,7 +4044,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4084,7 +4071,7 @@ func TestSetContractAsApproved(t *testing.T) {
 	t.Run("returns error if contract address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4094,7 +4081,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4157,7 +4144,7 @@ func TestSetContractAsApproved(t *testing.T) {
 	t.Run("returns error if contract has an invalid status", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4167,7 +4154,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4236,7 +4223,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4246,7 +4233,7 @@ func TestSetContractAsApproved(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4307,7 +4294,7 @@ func TestSetContractAsApproved(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractApproved, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -4325,7 +4312,7 @@ func TestSetContractAsApproved(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4335,7 +4322,7 @@ func TestSetContractAsApproved(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4422,19 +4409,15 @@ func TestSetContractAsApproved(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractApproved, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
-							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been approved")
-							assert.Equal(t, messages[1].CreatedBy, customer.ID)
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been approved")
+							assert.Equal(t, messages[0].CreatedBy, customer.ID)
 						}
 					}
 				}
@@ -4467,7 +4450,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 	t.Run("returns error if contract does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4494,7 +4477,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 	t.Run("returns error for customer", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4504,7 +4487,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4565,7 +4548,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 	t.Run("returns error for another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4575,7 +4558,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4615,7 +4598,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4642,7 +4625,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 	t.Run("returns error if contract address has an invalid format", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4652,7 +4635,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4715,7 +4698,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 	t.Run("returns error if contract has an invalid status", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4725,7 +4708,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		performer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -4794,7 +4777,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		t.Run("when there is no chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4804,7 +4787,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4865,7 +4848,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractCompleted, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
@@ -4883,7 +4866,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 		t.Run("when there is chat between participants", func(t *testing.T) {
 			require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-			customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			customer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4893,7 +4876,7 @@ func TestSetContractAsCompleted(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			performer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+			performer, err := personAdd(ctx, personAddParams{
 				ID:    pgdao.NewID(),
 				Login: pgdao.NewID(),
 				AccessToken: sql.NullString{
@@ -4980,19 +4963,15 @@ func TestSetContractAsCompleted(t *testing.T) {
 				if assert.NoError(t, err) {
 					assert.Equal(t, model.ContractCompleted, c.Status)
 
-					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:application:"+e.ApplicationID)
+					chat, err := pgdao.New(db).ChatGetByTopic(ctx, "urn:contract:"+e.ID)
 
 					if assert.NoError(t, err) {
 						messages, err := pgdao.New(db).MessagesListByChat(ctx, chat.ID)
 
 						if assert.NoError(t, err) {
-							assert.Len(t, messages, 2)
-
-							assert.Equal(t, messages[0].Text, "I want it")
+							assert.Len(t, messages, 1)
+							assert.Equal(t, messages[0].Text, "Contract has been completed")
 							assert.Equal(t, messages[0].CreatedBy, performer.ID)
-
-							assert.Equal(t, messages[1].Text, "Contract has been completed")
-							assert.Equal(t, messages[1].CreatedBy, performer.ID)
 						}
 					}
 				}
@@ -5000,3 +4979,78 @@ func TestSetContractAsCompleted(t *testing.T) {
 		})
 	})
 }
+
+func TestContractChat(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer    = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer   = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger    = addPerson(t, "stranger")
+		admin       = addPerson(t, "admin")
+		job         = addJob(t, "Job", "Description", customer.ID, "10", "3")
+		application = addApplication(t, job.ID, "I want it", "10", performer.ID)
+	)
+
+	require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{IsAdmin: true, ID: admin.ID}))
+
+	contract := doRequest[model.ContractDTO](t, http.MethodPost, contractsURL,
+		`{"application_id":"`+application.ID+`","title":"Contract","description":"Description","price":"10","duration":3}`, customer.AccessToken.String)
+	chatURL := contractsURL + "/" + contract.ID + "/chat"
+	joinURL := appURL + "/admin/contracts/" + contract.ID + "/chat/join"
+
+	t.Run("parties get the chat with status messages", func(t *testing.T) {
+		chat := doRequest[model.Chat](t, http.MethodGet, chatURL, "", performer.AccessToken.String)
+		assert.Equal(t, "urn:contract:"+contract.ID, chat.Topic)
+		if assert.Len(t, chat.Messages, 1) {
+			assert.Equal(t, model.MessageSystem, chat.Messages[0].Kind)
+			assert.Equal(t, &model.SystemMessagePayload{
+				Event:      model.SystemEventContractStatusChanged,
+				ContractID: contract.ID,
+				NewStatus:  model.ContractCreated,
+			}, chat.Messages[0].Payload)
+		}
+
+		cc := doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", customer.AccessToken.String)
+		if assert.Len(t, cc, 1) {
+			assert.Equal(t, chat.ID, cc[0].ID)
+			assert.Equal(t, "contract", cc[0].Kind)
+			assert.Equal(t, contract.ID, cc[0].ContractID)
+			assert.Equal(t, application.ID, cc[0].ApplicationID)
+			assert.Equal(t, "Contract", cc[0].Title)
+		}
+
+		doFailedRequest(t, http.MethodGet, chatURL, "", stranger.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, chatURL, "", admin.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, contractsURL+"/unknown/chat", "", customer.AccessToken.String, http.StatusNotFound)
+	})
+
+	t.Run("admin joins the chat", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, joinURL, `{"reason":"Dispute"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, joinURL, `{"reason":" "}`, admin.AccessToken.String, http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, appURL+"/admin/contracts/unknown/chat/join", `{"reason":"Dispute"}`, admin.AccessToken.String, http.StatusNotFound)
+
+		_, err := db.ExecContext(ctx, `update persons set display_name = '' where id = $1`, admin.ID)
+		require.NoError(t, err)
+
+		chat := doRequest[model.Chat](t, http.MethodPost, joinURL, `{"reason":"Dispute"}`, admin.AccessToken.String)
+		if assert.Len(t, chat.Messages, 2) {
+			assert.Equal(t, admin.Login+" has joined the chat", chat.Messages[1].Text)
+			assert.Equal(t, &model.SystemMessagePayload{
+				Event:      model.SystemEventParticipantJoined,
+				ContractID: contract.ID,
+				PersonID:   admin.ID,
+			}, chat.Messages[1].Payload)
+		}
+
+		doRequest[model.Chat](t, http.MethodGet, chatURL, "", admin.AccessToken.String)
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Let me see"}`, admin.AccessToken.String)
+		doFailedRequest(t, http.MethodPost, joinURL, `{"reason":"Dispute"}`, admin.AccessToken.String, http.StatusBadRequest)
+
+		rr := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=contract&target_id="+contract.ID, "", admin.AccessToken.String)
+		if assert.Len(t, rr, 1) {
+			assert.Equal(t, model.AuditJoinChat, rr[0].Action)
+			assert.Equal(t, "Dispute", rr[0].Reason)
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package intest
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service/mailsvc"
+	"optrispace.com/work/pkg/service/pgsvc"
+)
+
+var emailDigestURL = appURL + "/me/email-digest"
+
+// receivedMail is an email accepted by the SMTP stand-in
+type receivedMail struct {
+	to      string
+	header  mail.Header
+	subject string
+	body    string
+}
+
+// startSMTPStandIn starts a minimal SMTP server on a random local port
+// It accepts every message and passes it to the channel.
+func startSMTPStandIn(t *testing.T) (string, <-chan *receivedMail) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan *receivedMail, 16)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveSMTP(conn, received)
+		}
+	}()
+
+	return l.Addr().String(), received
+}
+
+func serveSMTP(conn net.Conn, received chan<- *receivedMail) {
+	defer conn.Close()
+
+	var (
+		r  = bufio.NewReader(conn)
+		to string
+	)
+
+	reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+
+	reply("220 localhost ESMTP stand-in")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			to = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			data := new(strings.Builder)
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if l == ".\r\n" {
+					break
+				}
+
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+
+			m, err := mail.ReadMessage(strings.NewReader(data.String()))
+			if err != nil {
+				reply("554 invalid message")
+				continue
+			}
+
+			body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+			if err != nil {
+				reply("554 invalid body")
+				continue
+			}
+
+			subject, _ := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+
+			received <- &receivedMail{
+				to:      to,
+				header:  m.Header,
+				subject: subject,
+				body:    strings.ReplaceAll(string(body), "\r\n", "\n"),
+			}
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestEmailDigest(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPerson(t, "customer")
+		performer = addPerson(t, "performer")
+
+		job = addJob(t, "Landing page", "Description", customer.ID, "10", "3")
+	)
+
+	addr, received := startSMTPStandIn(t)
+	mailer, err := mailsvc.NewSMTP(addr, "", "", "OptriSpace <noreply@optrispace.com>")
+	require.NoError(t, err)
+
+	svc := pgsvc.NewEmailDigest(db, mailer, appURL, "https://web.sample.com")
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+
+	var unsubscribeURL string
+
+	t.Run("digests are off by default", func(t *testing.T) {
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		s := doRequest[model.EmailDigestSettingsDTO](t, http.MethodGet, emailDigestURL, "", customer.AccessToken.String)
+		assert.Equal(t, model.EmailDigestNever, s.Frequency)
+
+		for _, p := range []testPerson{customer, performer} {
+			doRequest[model.EmailDigestSettingsDTO](t, http.MethodPut, emailDigestURL, `{"frequency":"daily"}`, p.AccessToken.String)
+		}
+	})
+
+	t.Run("unread messages are sent", func(t *testing.T) {
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		m := <-received
+		assert.Equal(t, "customer@sample.com", m.to)
+		assert.Equal(t, "You have 1 unread messages", m.subject)
+		assert.Contains(t, m.body, "Application for Landing page https://web.sample.com/chats/")
+		assert.Contains(t, m.body, "performer, ")
+		assert.Contains(t, m.body, ": I am ready\n")
+
+		unsubscribeURL = strings.Trim(m.header.Get("List-Unsubscribe"), "<>")
+		assert.True(t, strings.HasPrefix(unsubscribeURL, appURL+"/email-digest/unsubscribe?token="))
+		assert.Contains(t, m.body, "https://web.sample.com/email-digest/unsubscribe?token="+strings.TrimPrefix(unsubscribeURL, appURL+"/email-digest/unsubscribe?token="))
+		assert.NotContains(t, m.body, appURL, "people confirm unsubscribing on the web page")
+
+		var stored int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from unsubscribe_tokens where token_hash = $1`,
+			tokenHash(strings.TrimPrefix(unsubscribeURL, appURL+"/email-digest/unsubscribe?token="))).Scan(&stored))
+		assert.Equal(t, 1, stored, "only the hash of the token is stored")
+
+		n, err = svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "digest period has not passed yet")
+	})
+
+	t.Run("contract status changes are sent", func(t *testing.T) {
+		doRequest[model.ContractDTO](t, http.MethodPost, contractsURL,
+			`{"application_id":"`+application.ID+`","title":"Contract","description":"Description","price":"10","duration":3}`, customer.AccessToken.String)
+
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		m := <-received
+		assert.Equal(t, "performer@sample.com", m.to)
+		assert.Contains(t, m.body, "Your contracts have been updated:\n  Contract Contract: Contract has been created\n")
+		assert.NotContains(t, m.body, "You have unread messages")
+	})
+
+	t.Run("read messages are not sent", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `update persons set email_digest_sent_at = email_digest_sent_at - interval '2 days' where id = $1`, customer.ID)
+		require.NoError(t, err)
+
+		chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", customer.AccessToken.String)
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Hello"}`, performer.AccessToken.String)
+		doRequest[map[string]any](t, http.MethodPost, chatsURL+"/"+chat.ID+"/read", "", customer.AccessToken.String)
+
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("concurrent senders send the digest once", func(t *testing.T) {
+		chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", customer.AccessToken.String)
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Any news?"}`, performer.AccessToken.String)
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			total int
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n, err := svc.SendDigests(ctx)
+				assert.NoError(t, err)
+
+				mu.Lock()
+				total += n
+				mu.Unlock()
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, 1, total)
+
+		m := <-received
+		assert.Equal(t, "customer@sample.com", m.to)
+		assert.Contains(t, m.body, ": Any news?\n")
+		assert.Empty(t, received)
+	})
+
+	t.Run("frequency", func(t *testing.T) {
+		s := doRequest[model.EmailDigestSettingsDTO](t, http.MethodGet, emailDigestURL, "", customer.AccessToken.String)
+		assert.Equal(t, model.EmailDigestDaily, s.Frequency)
+		assert.Equal(t, "customer@sample.com", s.Email)
+		assert.NotNil(t, s.SentAt)
+
+		doFailedRequest(t, http.MethodPut, emailDigestURL, `{"frequency":"monthly"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+
+		s = doRequest[model.EmailDigestSettingsDTO](t, http.MethodPut, emailDigestURL, `{"frequency":"weekly"}`, customer.AccessToken.String)
+		assert.Equal(t, model.EmailDigestWeekly, s.Frequency)
+
+		chat := doRequest[model.Chat](t, http.MethodGet, appURL+"/applications/"+application.ID+"/chat", "", customer.AccessToken.String)
+		doRequest[model.Message](t, http.MethodPost, chatsURL+"/"+chat.ID+"/messages", `{"text":"Are you there?"}`, performer.AccessToken.String)
+
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "week has not passed yet")
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, appURL+"/email-digest/unsubscribe?token=unknown", "", "", http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, appURL+"/email-digest/unsubscribe", "", "", http.StatusUnprocessableEntity)
+		doFailedRequest(t, http.MethodPost, appURL+"/email-digest/unsubscribe?token=unknown", "", "", http.StatusNotFound)
+
+		doRequest[map[string]any](t, http.MethodGet, unsubscribeURL, "", "")
+
+		s := doRequest[model.EmailDigestSettingsDTO](t, http.MethodGet, emailDigestURL, "", customer.AccessToken.String)
+		assert.Equal(t, model.EmailDigestWeekly, s.Frequency, "checking the token does not unsubscribe")
+
+		doRequest[map[string]any](t, http.MethodPost, unsubscribeURL, "", "")
+
+		s = doRequest[model.EmailDigestSettingsDTO](t, http.MethodGet, emailDigestURL, "", customer.AccessToken.String)
+		assert.Equal(t, model.EmailDigestNever, s.Frequency)
+
+		_, err := db.ExecContext(ctx, `update persons set email_digest_sent_at = email_digest_sent_at - interval '30 days' where id = $1`, customer.ID)
+		require.NoError(t, err)
+
+		n, err := svc.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
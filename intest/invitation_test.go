@@ -0,0 +1,187 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var (
+	invitationsResourceName = "invitations"
+	invitationsURL          = appURL + "/" + invitationsResourceName
+)
+
+func TestJobInvitations(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer   = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer  = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+		performer2 = addPersonWithEthereumAddress(t, "performer2", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger   = addPersonWithEthereumAddress(t, "stranger", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa79")
+
+		privateJob = doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Private job","description":"For invited only","visibility":"private"}`, customer.AccessToken.String)
+	)
+
+	t.Run("private job is not listed", func(t *testing.T) {
+		jobs := doRequest[[]*model.JobDTO](t, http.MethodGet, jobsURL, "", "")
+		assert.Empty(t, jobs)
+	})
+
+	t.Run("private job is not available for anonymous and stranger", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodGet, jobsURL+"/"+privateJob.ID, "", "", http.StatusNotFound)
+		assert.Equal(t, "entity not found", e.Message)
+
+		e = doFailedRequest(t, http.MethodGet, jobsURL+"/"+privateJob.ID, "", stranger.AccessToken.String, http.StatusNotFound)
+		assert.Equal(t, "entity not found", e.Message)
+	})
+
+	t.Run("private job is available for the owner", func(t *testing.T) {
+		job := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+privateJob.ID, "", customer.AccessToken.String)
+		assert.Equal(t, privateJob.ID, job.ID)
+		assert.Equal(t, "private", job.Visibility)
+	})
+
+	t.Run("stranger is unable to apply for private job", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/applications",
+			`{"comment":"Let me in","price":"1.0"}`, stranger.AccessToken.String, http.StatusNotFound)
+		assert.Equal(t, "entity not found", e.Message)
+	})
+
+	t.Run("returns error if person is not specified", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations", `{}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "person_id or login is required", e.Message)
+	})
+
+	t.Run("returns error if person does not exist", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations", `{"login":"nobody"}`, customer.AccessToken.String, http.StatusNotFound)
+		assert.Equal(t, "person does not exist", e.Message)
+	})
+
+	t.Run("only the owner is able to invite", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations", `{"login":"performer"}`, stranger.AccessToken.String, http.StatusForbidden)
+	})
+
+	var invitation model.InvitationDTO
+
+	t.Run("customer invites performer by login", func(t *testing.T) {
+		invitation = doRequest[model.InvitationDTO](t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations",
+			`{"login":"performer","message":"Would you like to work with me?"}`, customer.AccessToken.String)
+
+		assert.NotEmpty(t, invitation.ID)
+		assert.Equal(t, privateJob.ID, invitation.JobID)
+		assert.Equal(t, "Private job", invitation.JobTitle)
+		assert.Equal(t, performer.ID, invitation.PersonID)
+		assert.Equal(t, customer.ID, invitation.CreatedBy)
+		assert.Equal(t, "pending", invitation.Status)
+		assert.Nil(t, invitation.RespondedAt)
+
+		chat, err := queries.ChatGetByTopic(ctx, "urn:invitation:"+invitation.ID)
+		require.NoError(t, err)
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		if assert.Len(t, mm, 1) {
+			assert.Equal(t, "Would you like to work with me?", mm[0].Text)
+			assert.Equal(t, customer.ID, mm[0].CreatedBy)
+		}
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", performer.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, "job_invitation", nn[0].Kind)
+			assert.Equal(t, "urn:invitation:"+invitation.ID, nn[0].Link)
+			assert.Nil(t, nn[0].ReadAt)
+
+			n := doRequest[model.PersonNotification](t, http.MethodPost, appURL+"/me/notifications/"+nn[0].ID+"/read", "", performer.AccessToken.String)
+			assert.NotNil(t, n.ReadAt)
+		}
+
+		chats := doRequest[[]*model.ChatDTO](t, http.MethodGet, chatsURL, "", performer.AccessToken.String)
+		if assert.Len(t, chats, 1) {
+			assert.Equal(t, "invitation", chats[0].Kind)
+			assert.Equal(t, invitation.ID, chats[0].InvitationID)
+			assert.Equal(t, privateJob.ID, chats[0].JobID)
+			assert.Equal(t, "Private job", chats[0].Title)
+		}
+	})
+
+	t.Run("returns error if person is already invited", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations", `{"person_id":"`+performer.ID+`"}`, customer.AccessToken.String, http.StatusConflict)
+		assert.Equal(t, "person is already invited", e.Message)
+	})
+
+	t.Run("invited person is able to see private job", func(t *testing.T) {
+		job := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+privateJob.ID, "", performer.AccessToken.String)
+		assert.Equal(t, privateJob.ID, job.ID)
+	})
+
+	t.Run("invitation is listed for the owner and the invited person", func(t *testing.T) {
+		ii := doRequest[[]*model.InvitationDTO](t, http.MethodGet, jobsURL+"/"+privateJob.ID+"/invitations", "", customer.AccessToken.String)
+		if assert.Len(t, ii, 1) {
+			assert.Equal(t, invitation.ID, ii[0].ID)
+		}
+
+		ii = doRequest[[]*model.InvitationDTO](t, http.MethodGet, invitationsURL, "", performer.AccessToken.String)
+		if assert.Len(t, ii, 1) {
+			assert.Equal(t, invitation.ID, ii[0].ID)
+		}
+
+		ii = doRequest[[]*model.InvitationDTO](t, http.MethodGet, invitationsURL, "", stranger.AccessToken.String)
+		assert.Empty(t, ii)
+
+		doFailedRequest(t, http.MethodGet, invitationsURL+"/"+invitation.ID, "", stranger.AccessToken.String, http.StatusNotFound)
+	})
+
+	t.Run("customer is unable to accept invitation instead of invited person", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, invitationsURL+"/"+invitation.ID+"/accept", `{"comment":"Yes","price":"2.0"}`, customer.AccessToken.String, http.StatusForbidden)
+	})
+
+	t.Run("invited person accepts invitation", func(t *testing.T) {
+		accepted := doRequest[model.InvitationDTO](t, http.MethodPost, invitationsURL+"/"+invitation.ID+"/accept",
+			`{"comment":"I am glad to work with you","price":"2.5"}`, performer.AccessToken.String)
+
+		assert.Equal(t, "accepted", accepted.Status)
+		assert.NotEmpty(t, accepted.ApplicationID)
+		assert.NotNil(t, accepted.RespondedAt)
+
+		application, err := queries.ApplicationGet(ctx, accepted.ApplicationID)
+		require.NoError(t, err)
+		assert.Equal(t, privateJob.ID, application.JobID)
+		assert.Equal(t, performer.ID, application.ApplicantID)
+		assert.Equal(t, "I am glad to work with you", application.Comment)
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", customer.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, "job_invitation_accepted", nn[0].Kind)
+		}
+	})
+
+	t.Run("accepted invitation can not be accepted or declined again", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, invitationsURL+"/"+invitation.ID+"/accept", `{"comment":"Yes","price":"2.0"}`, performer.AccessToken.String, http.StatusBadRequest)
+		doFailedRequest(t, http.MethodPost, invitationsURL+"/"+invitation.ID+"/decline", ``, performer.AccessToken.String, http.StatusBadRequest)
+	})
+
+	t.Run("invited person declines invitation", func(t *testing.T) {
+		i := doRequest[model.InvitationDTO](t, http.MethodPost, jobsURL+"/"+privateJob.ID+"/invitations",
+			`{"person_id":"`+performer2.ID+`"}`, customer.AccessToken.String)
+
+		chat, err := queries.ChatGetByTopic(ctx, "urn:invitation:"+i.ID)
+		require.NoError(t, err)
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		if assert.Len(t, mm, 1) {
+			assert.Equal(t, "You are invited to apply for the job: Private job", mm[0].Text)
+		}
+
+		declined := doRequest[model.InvitationDTO](t, http.MethodPost, invitationsURL+"/"+i.ID+"/decline", ``, performer2.AccessToken.String)
+		assert.Equal(t, "declined", declined.Status)
+		assert.Empty(t, declined.ApplicationID)
+		assert.NotNil(t, declined.RespondedAt)
+	})
+}
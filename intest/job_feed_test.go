@@ -0,0 +1,117 @@
+package intest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+)
+
+// getFeed requests the feed anonymously with additional headers
+func getFeed(t *testing.T, url string, headers map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res, body
+}
+
+func TestJobFeeds(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+
+	golangJob := addJob(t, "Golang backend", "REST API", customer.ID, "150", "3")
+	addJob(t, "Frontend", "React application", customer.ID, "50", "3")
+
+	t.Run("atom", func(t *testing.T) {
+		res, body := getFeed(t, jobsURL+"/feed.atom", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, string(body))
+		assert.Contains(t, res.Header.Get("Content-Type"), "application/atom+xml")
+
+		feed := struct {
+			Entries []struct {
+				Title string `xml:"title"`
+			} `xml:"entry"`
+		}{}
+		require.NoError(t, xml.Unmarshal(body, &feed))
+		assert.Len(t, feed.Entries, 2)
+	})
+
+	t.Run("rss with filter", func(t *testing.T) {
+		res, body := getFeed(t, jobsURL+"/feed.rss?keyword=golang&budget_min=100", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, string(body))
+		assert.Contains(t, res.Header.Get("Content-Type"), "application/rss+xml")
+
+		feed := struct {
+			Items []struct {
+				Title string `xml:"title"`
+				Link  string `xml:"link"`
+			} `xml:"channel>item"`
+		}{}
+		require.NoError(t, xml.Unmarshal(body, &feed))
+		if assert.Len(t, feed.Items, 1) {
+			assert.Equal(t, "Golang backend", feed.Items[0].Title)
+			assert.Contains(t, feed.Items[0].Link, "/jobs/"+golangJob.ID)
+		}
+
+		res, _ = getFeed(t, jobsURL+"/feed.rss?budget_min=x", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		res, body := getFeed(t, jobsURL+"/feed.json?budget_max=100", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, string(body))
+		assert.Contains(t, res.Header.Get("Content-Type"), "application/feed+json")
+
+		feed := struct {
+			Version string `json:"version"`
+			Items   []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"items"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, &feed))
+		assert.Equal(t, "https://jsonfeed.org/version/1.1", feed.Version)
+		if assert.Len(t, feed.Items, 1) {
+			assert.Equal(t, "Frontend", feed.Items[0].Title)
+		}
+	})
+
+	t.Run("conditional requests", func(t *testing.T) {
+		res, _ := getFeed(t, jobsURL+"/feed.json", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		etag := res.Header.Get("ETag")
+		lastModified := res.Header.Get("Last-Modified")
+		require.NotEmpty(t, etag)
+		require.NotEmpty(t, lastModified)
+
+		res, _ = getFeed(t, jobsURL+"/feed.json", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+		res, _ = getFeed(t, jobsURL+"/feed.json", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+		addJob(t, "Another job", "Description", customer.ID, "10", "1")
+
+		res, _ = getFeed(t, jobsURL+"/feed.json", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotEqual(t, etag, res.Header.Get("ETag"))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/shopspring/decimal"
@@ -16,6 +17,7 @@ import (
 	"optrispace.com/work/pkg/clog"
 	"optrispace.com/work/pkg/db/pgdao"
 	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service/pgsvc"
 )
 
 var (
@@ -47,7 +49,7 @@ func TestCreateJob(t *testing.T) {
 	t.Run("with validation errors", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -235,7 +237,7 @@ func TestCreateJob(t *testing.T) {
 	t.Run("returns error if customer does not have ethereum_address", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -270,7 +272,7 @@ func TestCreateJob(t *testing.T) {
 	t.Run("creates job when budget is missing", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -324,7 +326,7 @@ func TestCreateJob(t *testing.T) {
 	t.Run("creates job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -398,7 +400,7 @@ func TestGetJobs(t *testing.T) {
 	t.Run("returns only public jobs", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer1, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -414,7 +416,7 @@ func TestGetJobs(t *testing.T) {
 		err = pgdao.New(db).JobHide(ctx, job1.ID)
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -448,7 +450,7 @@ func TestGetJobs(t *testing.T) {
 	t.Run("returns only available jobs ordered by updated_at descending", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer1, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer1, err := personAdd(ctx, personAddParams{
 			ID:          pgdao.NewID(),
 			Login:       pgdao.NewID(),
 			DisplayName: "Person1",
@@ -472,7 +474,7 @@ func TestGetJobs(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		customer2, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer2, err := personAdd(ctx, personAddParams{
 			ID:          pgdao.NewID(),
 			Login:       pgdao.NewID(),
 			DisplayName: "Person2",
@@ -587,7 +589,7 @@ func TestGetJob(t *testing.T) {
 	t.Run("returns error if job is blocked", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -621,7 +623,7 @@ func TestGetJob(t *testing.T) {
 	t.Run("returns job if job is available", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			DisplayName:     "Person1",
@@ -673,7 +675,7 @@ func TestGetJob(t *testing.T) {
 	t.Run("returns job if job is suspended", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:              pgdao.NewID(),
 			Login:           pgdao.NewID(),
 			DisplayName:     "Person1",
@@ -739,7 +741,7 @@ func TestUpdateJob(t *testing.T) {
 	t.Run("with validation errors", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -939,7 +941,7 @@ func TestUpdateJob(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -976,7 +978,7 @@ func TestUpdateJob(t *testing.T) {
 	t.Run("returns error if customer does not have ethereum_address", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1028,7 +1030,7 @@ func TestUpdateJob(t *testing.T) {
 	t.Run("updates job when budget and duration are missing", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1097,7 +1099,7 @@ func TestUpdateJob(t *testing.T) {
 	t.Run("updates job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1188,7 +1190,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1218,7 +1220,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 	t.Run("returns error if person is not an admin", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1259,7 +1261,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 	t.Run("returns error if job already blocked", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1309,7 +1311,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 	t.Run("blocks job when customer is an admin", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1337,7 +1339,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, jobsURL+"/"+job.ID+"/block", nil)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, jobsURL+"/"+job.ID+"/block", bytes.NewReader([]byte(`{"reason":"Spam"}`)))
 		require.NoError(t, err)
 		req.Header.Set(clog.HeaderXHint, t.Name())
 		req.Header.Set(echo.HeaderContentType, "application/json")
@@ -1357,7 +1359,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 	t.Run("blocks job when current person is an admin and job belongs to another person", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1375,7 +1377,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		admin, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		admin, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1391,7 +1393,7 @@ func TestSetJobAsBlocked(t *testing.T) {
 			ID:      admin.ID,
 		}))
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, jobsURL+"/"+job.ID+"/block", nil)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, jobsURL+"/"+job.ID+"/block", bytes.NewReader([]byte(`{"reason":"Spam"}`)))
 		require.NoError(t, err)
 		req.Header.Set(clog.HeaderXHint, t.Name())
 		req.Header.Set(echo.HeaderContentType, "application/json")
@@ -1435,7 +1437,7 @@ func TestSetJobAsSuspended(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1465,7 +1467,7 @@ func TestSetJobAsSuspended(t *testing.T) {
 	t.Run("returns error if a person is not an owner of a job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1483,7 +1485,7 @@ func TestSetJobAsSuspended(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		stranger, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		stranger, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1512,7 +1514,7 @@ func TestSetJobAsSuspended(t *testing.T) {
 	t.Run("suspends job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1578,7 +1580,7 @@ func TestSetJobAsResumed(t *testing.T) {
 	t.Run("returns error if job does not exist", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1608,7 +1610,7 @@ func TestSetJobAsResumed(t *testing.T) {
 	t.Run("returns error if a person is not an owner of a job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 		})
@@ -1626,7 +1628,7 @@ func TestSetJobAsResumed(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		stranger, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		stranger, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1655,7 +1657,7 @@ func TestSetJobAsResumed(t *testing.T) {
 	t.Run("returns error if job is not suspended", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1696,7 +1698,7 @@ func TestSetJobAsResumed(t *testing.T) {
 	t.Run("resumes job", func(t *testing.T) {
 		require.NoError(t, pgdao.PurgeDB(ctx, db))
 
-		customer, err := pgdao.New(db).PersonAdd(ctx, pgdao.PersonAddParams{
+		customer, err := personAdd(ctx, personAddParams{
 			ID:    pgdao.NewID(),
 			Login: pgdao.NewID(),
 			AccessToken: sql.NullString{
@@ -1742,3 +1744,389 @@ func TestSetJobAsResumed(t *testing.T) {
 		}
 	})
 }
+
+func TestJobExpiration(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+
+	t.Run("returns error if expires_at is in the past", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL,
+			`{"title":"Title","description":"Description","expires_at":"2020-01-01T00:00:00Z"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "expires_at must be in the future", e.Message)
+	})
+
+	t.Run("creates job with expiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+		job := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Title","description":"Description","expires_at":"`+expiresAt.Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+
+		if assert.NotNil(t, job.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*job.ExpiresAt))
+		}
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", "")
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+	})
+
+	t.Run("expired job is not listed and does not accept applications", func(t *testing.T) {
+		require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+		customer = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+
+		job, err := queries.JobAdd(ctx, pgdao.JobAddParams{
+			ID:          pgdao.NewID(),
+			Title:       "Expired",
+			Description: "Expired job",
+			CreatedBy:   customer.ID,
+			ExpiresAt:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		})
+		require.NoError(t, err)
+
+		jobs := doRequest[[]*model.JobDTO](t, http.MethodGet, jobsURL, "", "")
+		assert.Empty(t, jobs)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", "")
+		assert.Equal(t, model.JobStatusExpired, card.Status)
+
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Let me in","price":"1.0"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "job does not accept new applications", e.Message)
+
+		stats := doRequest[model.Stats](t, http.MethodGet, appURL+"/stats", "", "")
+		assert.EqualValues(t, 0, stats.OpenedJobs)
+	})
+
+	t.Run("suspends expired jobs and notifies owners", func(t *testing.T) {
+		require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+		customer = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+
+		expired, err := queries.JobAdd(ctx, pgdao.JobAddParams{
+			ID:          pgdao.NewID(),
+			Title:       "Expired",
+			Description: "Expired job",
+			CreatedBy:   customer.ID,
+			ExpiresAt:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		})
+		require.NoError(t, err)
+
+		actual, err := queries.JobAdd(ctx, pgdao.JobAddParams{
+			ID:          pgdao.NewID(),
+			Title:       "Actual",
+			Description: "Actual job",
+			CreatedBy:   customer.ID,
+			ExpiresAt:   sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		})
+		require.NoError(t, err)
+
+		n, err := pgsvc.NewJob(db).SuspendExpired(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		j, err := queries.JobFind(ctx, expired.ID)
+		require.NoError(t, err)
+		assert.True(t, j.SuspendedAt.Valid)
+
+		j, err = queries.JobFind(ctx, actual.ID)
+		require.NoError(t, err)
+		assert.False(t, j.SuspendedAt.Valid)
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", customer.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, model.NotificationJobExpired, nn[0].Kind)
+			assert.Equal(t, "/jobs/"+expired.ID, nn[0].Link)
+		}
+
+		n, err = pgsvc.NewJob(db).SuspendExpired(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "already suspended jobs must be skipped")
+
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+expired.ID+"/resume", "", customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "job is expired, reopen it instead", e.Message)
+	})
+
+	t.Run("update keeps expiration unless it is cleared", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+		job := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Title","description":"Description","expires_at":"`+expiresAt.Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+
+		updated := doRequest[model.JobDTO](t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"New title","description":"Description"}`, customer.AccessToken.String)
+		if assert.NotNil(t, updated.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*updated.ExpiresAt))
+		}
+
+		doFailedRequest(t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"New title","description":"Description","expires_at":"`+expiresAt.Format(time.RFC3339)+`","clear_expires_at":true}`,
+			customer.AccessToken.String, http.StatusUnprocessableEntity)
+
+		updated = doRequest[model.JobDTO](t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"New title","description":"Description","clear_expires_at":true}`, customer.AccessToken.String)
+		assert.Nil(t, updated.ExpiresAt)
+	})
+
+	t.Run("blocked job is shown to its owner", func(t *testing.T) {
+		job := addJob(t, "Blocked", "Blocked job", customer.ID, "", "")
+		require.NoError(t, queries.JobBlock(ctx, job.ID))
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+job.ID, "", customer.AccessToken.String)
+		assert.Equal(t, model.JobStatusBlocked, card.Status)
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+job.ID, "", performer.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+job.ID, "", "", http.StatusNotFound)
+	})
+}
+
+func TestReopenJob(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	stranger := addPersonWithEthereumAddress(t, "stranger", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa79")
+
+	expired, err := queries.JobAdd(ctx, pgdao.JobAddParams{
+		ID:          pgdao.NewID(),
+		Title:       "Expired",
+		Description: "Expired job",
+		CreatedBy:   customer.ID,
+		ExpiresAt:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, queries.JobSuspend(ctx, expired.ID))
+
+	open := addJob(t, "Open", "Open job", customer.ID, "", "")
+
+	t.Run("only owner is able to reopen job", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+expired.ID+"/reopen", `{}`, stranger.AccessToken.String, http.StatusForbidden)
+	})
+
+	t.Run("returns error if job is open", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+open.ID+"/reopen", `{}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "job is not closed or expired", e.Message)
+	})
+
+	t.Run("reopens expired job with new expiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodPost, jobsURL+"/"+expired.ID+"/reopen",
+			`{"expires_at":"`+expiresAt.Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+		assert.False(t, card.IsSuspended)
+		if assert.NotNil(t, card.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*card.ExpiresAt))
+		}
+	})
+
+	t.Run("reopens closed job", func(t *testing.T) {
+		performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+		application := addApplication(t, open.ID, "Do it!", "1.0", performer.ID)
+
+		require.NoError(t, queries.JobCloseByApplication(ctx, application.ID))
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+open.ID, "", "")
+		assert.Equal(t, model.JobStatusClosed, card.Status)
+		assert.NotNil(t, card.ClosedAt)
+
+		card = doRequest[model.JobCardDTO](t, http.MethodPost, jobsURL+"/"+open.ID+"/reopen", ``, customer.AccessToken.String)
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+		assert.Nil(t, card.ClosedAt)
+		assert.Nil(t, card.ExpiresAt)
+	})
+}
+
+func TestJobDrafts(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+
+	draft := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+		`{"title":"Draft","description":"Not ready yet","draft":true}`, customer.AccessToken.String)
+
+	t.Run("draft is available for the owner only", func(t *testing.T) {
+		jobs := doRequest[[]*model.JobDTO](t, http.MethodGet, jobsURL, "", "")
+		assert.Empty(t, jobs)
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+draft.ID, "", "", http.StatusNotFound)
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+draft.ID, "", performer.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+draft.ID+"/applications",
+			`{"comment":"Let me in","price":"1.0"}`, performer.AccessToken.String, http.StatusNotFound)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+draft.ID, "", customer.AccessToken.String)
+		assert.Equal(t, model.JobStatusDraft, card.Status)
+		assert.Nil(t, card.PublishedAt)
+	})
+
+	t.Run("owner lists jobs in every state", func(t *testing.T) {
+		suspended := addJob(t, "Suspended", "Suspended job", customer.ID, "", "")
+		require.NoError(t, queries.JobSuspend(ctx, suspended.ID))
+
+		addJob(t, "Foreign", "Foreign job", performer.ID, "", "")
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/mine", "", "", http.StatusUnauthorized)
+
+		jobs := doRequest[[]*model.JobCardDTO](t, http.MethodGet, jobsURL+"/mine", "", customer.AccessToken.String)
+		if assert.Len(t, jobs, 2) {
+			statuses := map[string]string{}
+			for _, j := range jobs {
+				statuses[j.ID] = j.Status
+			}
+
+			assert.Equal(t, model.JobStatusDraft, statuses[draft.ID])
+			assert.Equal(t, model.JobStatusSuspended, statuses[suspended.ID])
+		}
+	})
+
+	t.Run("only owner is able to publish draft", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+draft.ID+"/publish", `{}`, performer.AccessToken.String, http.StatusNotFound)
+	})
+
+	t.Run("schedules publication", func(t *testing.T) {
+		publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodPost, jobsURL+"/"+draft.ID+"/publish",
+			`{"publish_at":"`+publishAt.Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+
+		assert.Equal(t, model.JobStatusDraft, card.Status)
+		if assert.NotNil(t, card.PublishAt) {
+			assert.True(t, publishAt.Equal(*card.PublishAt))
+		}
+	})
+
+	t.Run("publishes draft immediately", func(t *testing.T) {
+		card := doRequest[model.JobCardDTO](t, http.MethodPost, jobsURL+"/"+draft.ID+"/publish", ``, customer.AccessToken.String)
+
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+		assert.NotNil(t, card.PublishedAt)
+		assert.Nil(t, card.PublishAt)
+
+		jobs := doRequest[[]*model.JobDTO](t, http.MethodGet, jobsURL, "", "")
+		if assert.Len(t, jobs, 2) {
+			assert.Contains(t, []string{jobs[0].ID, jobs[1].ID}, draft.ID)
+		}
+
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+draft.ID+"/publish", ``, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "job is already published", e.Message)
+	})
+
+	t.Run("scheduler publishes drafts and notifies owners", func(t *testing.T) {
+		scheduled := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Scheduled","description":"Will be published","publish_at":"`+time.Now().Add(time.Hour).UTC().Format(time.RFC3339)+`"}`, customer.AccessToken.String)
+
+		n, err := pgsvc.NewJob(db).PublishScheduled(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "it is too early to publish")
+
+		_, err = db.ExecContext(ctx, "update jobs set publish_at = now() - interval '1 minute' where id = $1", scheduled.ID)
+		require.NoError(t, err)
+
+		n, err = pgsvc.NewJob(db).PublishScheduled(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+scheduled.ID, "", "")
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", customer.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, model.NotificationJobPublished, nn[0].Kind)
+			assert.Equal(t, "/jobs/"+scheduled.ID, nn[0].Link)
+		}
+	})
+}
+
+func TestJobRevisions(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	customer := addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+	performer := addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+
+	job := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+		`{"title":"Initial","description":"Initial description","budget":"10"}`, customer.AccessToken.String)
+
+	t.Run("new job has the first revision", func(t *testing.T) {
+		rr := doRequest[[]*model.JobRevisionDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/revisions", "", "")
+		if assert.Len(t, rr, 1) {
+			assert.EqualValues(t, 1, rr[0].Version)
+			assert.Equal(t, "Initial", rr[0].Title)
+			assert.True(t, decimal.RequireFromString("10").Equal(rr[0].Budget))
+			assert.Equal(t, customer.ID, rr[0].CreatedBy)
+		}
+	})
+
+	application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+		`{"comment":"I am ready","price":"10"}`, performer.AccessToken.String)
+
+	chat, err := queries.ChatGetByTopic(ctx, "urn:application:"+application.ID)
+	require.NoError(t, err)
+
+	t.Run("title change makes revision without message to applicants", func(t *testing.T) {
+		doRequest[model.JobDTO](t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"Renamed","description":"Initial description","budget":"10"}`, customer.AccessToken.String)
+
+		rr := doRequest[[]*model.JobRevisionDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/revisions", "", "")
+		if assert.Len(t, rr, 2) {
+			assert.EqualValues(t, 2, rr[0].Version)
+			assert.Equal(t, "Renamed", rr[0].Title)
+			assert.Equal(t, "Initial", rr[1].Title)
+		}
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		assert.Len(t, mm, 1)
+	})
+
+	t.Run("unchanged terms do not make revision", func(t *testing.T) {
+		doRequest[model.JobDTO](t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"Renamed","description":"Initial description","budget":"10"}`, customer.AccessToken.String)
+
+		rr := doRequest[[]*model.JobRevisionDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/revisions", "", "")
+		assert.Len(t, rr, 2)
+	})
+
+	t.Run("budget and description change are posted to application chats", func(t *testing.T) {
+		doRequest[model.JobDTO](t, http.MethodPut, jobsURL+"/"+job.ID,
+			`{"title":"Renamed","description":"New description","budget":"20"}`, customer.AccessToken.String)
+
+		rr := doRequest[[]*model.JobRevisionDTO](t, http.MethodGet, jobsURL+"/"+job.ID+"/revisions", "", performer.AccessToken.String)
+		if assert.Len(t, rr, 3) {
+			assert.EqualValues(t, 3, rr[0].Version)
+			assert.Equal(t, "New description", rr[0].Description)
+			assert.True(t, decimal.RequireFromString("20").Equal(rr[0].Budget))
+		}
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		if assert.Len(t, mm, 2) {
+			assert.Equal(t, customer.ID, mm[1].CreatedBy)
+			assert.Equal(t, "The job terms have been changed: budget has been changed from 10 to 20, description has been changed", mm[1].Text)
+			assert.Equal(t, model.MessageSystem, mm[1].Kind)
+		}
+
+		c := doRequest[model.Chat](t, http.MethodGet, chatsURL+"/"+chat.ID, "", performer.AccessToken.String)
+		if assert.Len(t, c.Messages, 2) {
+			assert.Equal(t, &model.SystemMessagePayload{
+				Event:   model.SystemEventJobChanged,
+				JobID:   job.ID,
+				Changes: []string{"budget", "description"},
+			}, c.Messages[1].Payload)
+
+			doFailedRequest(t, http.MethodDelete, chatsURL+"/"+chat.ID+"/messages/"+c.Messages[1].ID, "", customer.AccessToken.String, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("revisions of draft are not available for others", func(t *testing.T) {
+		draft := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Draft","description":"Draft description","draft":true}`, customer.AccessToken.String)
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+draft.ID+"/revisions", "", performer.AccessToken.String, http.StatusNotFound)
+
+		rr := doRequest[[]*model.JobRevisionDTO](t, http.MethodGet, jobsURL+"/"+draft.ID+"/revisions", "", customer.AccessToken.String)
+		assert.Len(t, rr, 1)
+	})
+}
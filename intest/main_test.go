@@ -3,7 +3,9 @@ package intest
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -12,12 +14,14 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
 	"optrispace.com/work/pkg/clog"
 	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
 	"optrispace.com/work/pkg/service/pgsvc"
 
 	_ "github.com/lib/pq"
@@ -65,8 +69,59 @@ func TestMain(m *testing.M) {
 
 // Initialization functions
 
-func addPerson(t *testing.T, login string) pgdao.Person {
+type (
+	// testPerson is a person with the access token of its session
+	testPerson struct {
+		pgdao.Person
+		AccessToken sql.NullString
+	}
+
+	// personAddParams are pgdao.PersonAddParams with the access token of the session to start
+	personAddParams struct {
+		ID              string
+		Realm           string
+		Login           string
+		PasswordHash    string
+		DisplayName     string
+		Email           string
+		EthereumAddress string
+		AccessToken     sql.NullString
+	}
+)
+
+// tokenHash returns the hash of the access token as it is stored in sessions
+func tokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// personAdd creates the person and starts the session with the access token if it is valid
+func personAdd(ctx context.Context, arg personAddParams) (testPerson, error) {
 	person, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		ID:              arg.ID,
+		Realm:           arg.Realm,
+		Login:           arg.Login,
+		PasswordHash:    arg.PasswordHash,
+		DisplayName:     arg.DisplayName,
+		Email:           arg.Email,
+		EthereumAddress: arg.EthereumAddress,
+	})
+	if err != nil || !arg.AccessToken.Valid {
+		return testPerson{Person: person}, err
+	}
+
+	_, err = queries.SessionAdd(ctx, pgdao.SessionAddParams{
+		ID:        pgdao.NewID(),
+		PersonID:  person.ID,
+		TokenHash: tokenHash(arg.AccessToken.String),
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	})
+
+	return testPerson{Person: person, AccessToken: arg.AccessToken}, err
+}
+
+func addPerson(t *testing.T, login string) testPerson {
+	person, err := personAdd(ctx, personAddParams{
 		ID:           pgdao.NewID(),
 		Realm:        "inhouse",
 		Login:        login,
@@ -83,8 +138,8 @@ func addPerson(t *testing.T, login string) pgdao.Person {
 	return person
 }
 
-func addPersonWithEthereumAddress(t *testing.T, login, ethereum_address string) pgdao.Person {
-	person, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+func addPersonWithEthereumAddress(t *testing.T, login, ethereum_address string) testPerson {
+	person, err := personAdd(ctx, personAddParams{
 		ID:           pgdao.NewID(),
 		Realm:        "inhouse",
 		Login:        login,
@@ -191,3 +246,24 @@ func doRequest[T any](t *testing.T, httpMethod, url, body, token string) T {
 
 	return *resultObj
 }
+
+// doFailedRequest performs request which is expected to fail with specified HTTP status and returns error description
+func doFailedRequest(t *testing.T, httpMethod, url, body, token string, expectedStatus int) model.BackendError {
+	req, err := http.NewRequestWithContext(ctx, httpMethod, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	req.Header.Set(clog.HeaderXHint, t.Name())
+	req.Header.Set(echo.HeaderContentType, "application/json")
+	if len(token) > 0 {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equalf(t, expectedStatus, res.StatusCode, httpMethod+" "+url+": invalid result status code '%s'", res.Status)
+
+	e := model.BackendError{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&e))
+
+	return e
+}
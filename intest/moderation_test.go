@@ -0,0 +1,187 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var (
+	reportsURL      = appURL + "/reports"
+	adminReportsURL = appURL + "/admin/reports"
+	adminPersonsURL = appURL + "/admin/persons"
+	adminAuditURL   = appURL + "/admin/audit"
+)
+
+func TestModeration(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		spammer   = addPersonWithEthereumAddress(t, "spammer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa77")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		admin     = addPerson(t, "admin")
+
+		spamJob = doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Cheap pills","description":"Buy now"}`, spammer.AccessToken.String)
+	)
+
+	require.NoError(t, queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{
+		IsAdmin: true,
+		ID:      admin.ID,
+	}))
+
+	t.Run("validates report", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "reason is required", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`","reason":"other"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "comment is required", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"contract","target_id":"`+spamJob.ID+`","reason":"spam"}`, customer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "target_kind has an invalid format", e.Message)
+
+		doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"nothing","reason":"spam"}`, customer.AccessToken.String, http.StatusNotFound)
+
+		doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`","reason":"spam"}`, spammer.AccessToken.String, http.StatusBadRequest)
+	})
+
+	var jobReport model.ReportDTO
+
+	t.Run("users report job", func(t *testing.T) {
+		jobReport = doRequest[model.ReportDTO](t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`","reason":"spam","comment":"It is spam"}`, customer.AccessToken.String)
+
+		assert.NotEmpty(t, jobReport.ID)
+		assert.Equal(t, model.TargetJob, jobReport.TargetKind)
+		assert.Equal(t, spamJob.ID, jobReport.TargetID)
+		assert.Equal(t, model.ReportOpen, jobReport.Status)
+		assert.Equal(t, customer.ID, jobReport.CreatedBy)
+
+		e := doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`","reason":"spam"}`, customer.AccessToken.String, http.StatusConflict)
+		assert.Equal(t, "it is already reported", e.Message)
+
+		doRequest[model.ReportDTO](t, http.MethodPost, reportsURL,
+			`{"target_kind":"job","target_id":"`+spamJob.ID+`","reason":"fraud"}`, performer.AccessToken.String)
+	})
+
+	t.Run("moderation queue is available for admins only", func(t *testing.T) {
+		doFailedRequest(t, http.MethodGet, adminReportsURL, "", customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, adminReportsURL+"/"+jobReport.ID, "", customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, adminReportsURL+"/"+jobReport.ID+"/resolve", `{"resolution":"Spam","block":true}`, customer.AccessToken.String, http.StatusForbidden)
+
+		rr := doRequest[[]*model.ReportDTO](t, http.MethodGet, adminReportsURL+"?status=open", "", admin.AccessToken.String)
+		assert.Len(t, rr, 2)
+
+		doFailedRequest(t, http.MethodGet, adminReportsURL+"?status=unknown", "", admin.AccessToken.String, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("admin resolves report and blocks job", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, adminReportsURL+"/"+jobReport.ID+"/resolve", `{"block":true}`, admin.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "resolution is required", e.Message)
+
+		r := doRequest[model.ReportDTO](t, http.MethodPost, adminReportsURL+"/"+jobReport.ID+"/resolve",
+			`{"resolution":"Obvious spam","block":true}`, admin.AccessToken.String)
+		assert.Equal(t, model.ReportResolved, r.Status)
+		assert.Equal(t, admin.ID, r.ResolvedBy)
+		assert.Equal(t, "Obvious spam", r.Resolution)
+		assert.NotNil(t, r.ResolvedAt)
+
+		doFailedRequest(t, http.MethodGet, jobsURL+"/"+spamJob.ID, "", "", http.StatusNotFound)
+
+		rr := doRequest[[]*model.ReportDTO](t, http.MethodGet, adminReportsURL+"?status=open", "", admin.AccessToken.String)
+		assert.Empty(t, rr, "all reports about the job are resolved")
+
+		doFailedRequest(t, http.MethodPost, adminReportsURL+"/"+jobReport.ID+"/dismiss", `{}`, admin.AccessToken.String, http.StatusBadRequest)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=job&target_id="+spamJob.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, model.AuditBlock, aa[0].Action)
+			assert.Equal(t, admin.ID, aa[0].ActorID)
+			assert.Equal(t, "Obvious spam", aa[0].Reason)
+		}
+	})
+
+	t.Run("admin unblocks job with reason", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, jobsURL+"/"+spamJob.ID+"/unblock", `{}`, admin.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "reason is required", e.Message)
+
+		doRequest[map[string]any](t, http.MethodPost, jobsURL+"/"+spamJob.ID+"/unblock", `{"reason":"Appeal accepted"}`, admin.AccessToken.String)
+
+		card := doRequest[model.JobCardDTO](t, http.MethodGet, jobsURL+"/"+spamJob.ID, "", "")
+		assert.Equal(t, model.JobStatusOpen, card.Status)
+
+		doFailedRequest(t, http.MethodPost, jobsURL+"/"+spamJob.ID+"/unblock", `{"reason":"Again"}`, admin.AccessToken.String, http.StatusBadRequest)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_id="+spamJob.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 2) {
+			assert.Equal(t, model.AuditUnblock, aa[0].Action)
+			assert.Equal(t, "Appeal accepted", aa[0].Reason)
+		}
+	})
+
+	t.Run("report on message blocks its author", func(t *testing.T) {
+		job := addJob(t, "Honest job", "Honest description", customer.ID, "", "")
+		application := doRequest[model.ApplicationDTO](t, http.MethodPost, jobsURL+"/"+job.ID+"/applications",
+			`{"comment":"Send me money first","price":"1.0"}`, spammer.AccessToken.String)
+
+		chat, err := queries.ChatGetByTopic(ctx, "urn:application:"+application.ID)
+		require.NoError(t, err)
+
+		mm, err := queries.MessagesListByChat(ctx, chat.ID)
+		require.NoError(t, err)
+		require.Len(t, mm, 1)
+
+		doFailedRequest(t, http.MethodPost, reportsURL,
+			`{"target_kind":"message","target_id":"`+mm[0].ID+`","reason":"fraud"}`, performer.AccessToken.String, http.StatusNotFound)
+
+		r := doRequest[model.ReportDTO](t, http.MethodPost, reportsURL,
+			`{"target_kind":"message","target_id":"`+mm[0].ID+`","reason":"fraud"}`, customer.AccessToken.String)
+
+		doRequest[model.ReportDTO](t, http.MethodPost, adminReportsURL+"/"+r.ID+"/resolve",
+			`{"resolution":"Scam","block":true}`, admin.AccessToken.String)
+
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", spammer.AccessToken.String, http.StatusUnauthorized)
+
+		e := doFailedRequest(t, http.MethodPost, appURL+"/login", `{"login":"spammer","password":"spammer-password"}`, "", http.StatusUnprocessableEntity)
+		assert.Equal(t, "person is blocked", e.Message)
+
+		aa := doRequest[[]*model.AuditRecordDTO](t, http.MethodGet, adminAuditURL+"?target_kind=person&target_id="+spammer.ID, "", admin.AccessToken.String)
+		if assert.Len(t, aa, 1) {
+			assert.Equal(t, model.AuditBlock, aa[0].Action)
+		}
+	})
+
+	t.Run("admin unblocks person", func(t *testing.T) {
+		doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+spammer.ID+"/unblock", `{"reason":"Mistake"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, adminPersonsURL+"/unknown/unblock", `{"reason":"Mistake"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, jobsURL+"/unknown/block", `{"reason":"Spam"}`, customer.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPost, adminPersonsURL+"/"+admin.ID+"/block", `{"reason":"Myself"}`, admin.AccessToken.String, http.StatusBadRequest)
+
+		doRequest[map[string]any](t, http.MethodPost, adminPersonsURL+"/"+spammer.ID+"/unblock", `{"reason":"Mistake"}`, admin.AccessToken.String)
+
+		uc := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", spammer.AccessToken.String)
+		assert.Equal(t, spammer.ID, uc.Subject.ID)
+		assert.Nil(t, uc.Subject.BlockedAt)
+	})
+
+	t.Run("admin dismisses report", func(t *testing.T) {
+		r := doRequest[model.ReportDTO](t, http.MethodPost, reportsURL,
+			`{"target_kind":"person","target_id":"`+customer.ID+`","reason":"abuse"}`, performer.AccessToken.String)
+
+		r = doRequest[model.ReportDTO](t, http.MethodPost, adminReportsURL+"/"+r.ID+"/dismiss", `{"resolution":"Nothing wrong"}`, admin.AccessToken.String)
+		assert.Equal(t, model.ReportDismissed, r.Status)
+
+		doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", customer.AccessToken.String)
+	})
+}
@@ -163,7 +163,7 @@ func TestPersonPatch(t *testing.T) {
 	queries := pgdao.New(db)
 
 	t.Run("patch email ok", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -204,7 +204,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch email will not change the original value if new value is an empty string", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -247,7 +247,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch ethereum_address ok", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -288,7 +288,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch display_name ok", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -329,7 +329,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch display_name will not change the original value if new value is an empty string", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -371,7 +371,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch invalid person", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -379,7 +379,7 @@ func TestPersonPatch(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		theStranger, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		theStranger, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -412,7 +412,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch not authorized", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -440,7 +440,7 @@ func TestPersonPatch(t *testing.T) {
 	})
 
 	t.Run("patch not found", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -479,7 +479,7 @@ func TestPersonResources(t *testing.T) {
 	require.NoError(t, pgdao.PurgeDB(ctx, db))
 
 	t.Run("put resources to new person", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -553,7 +553,7 @@ func TestPersonResources(t *testing.T) {
 	})
 
 	t.Run("put resources to none empty", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -633,7 +633,7 @@ func TestPersonResources(t *testing.T) {
 	})
 
 	t.Run("put resources as stranger", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -643,7 +643,7 @@ func TestPersonResources(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		theStranger, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		theStranger, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -679,7 +679,7 @@ func TestPersonResources(t *testing.T) {
 	})
 
 	t.Run("put resources invalid JSON", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -715,7 +715,7 @@ func TestPersonResources(t *testing.T) {
 	})
 
 	t.Run("put resources not authorized", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -746,7 +746,7 @@ func TestPersonResources(t *testing.T) {
 	})
 
 	t.Run("patch not found", func(t *testing.T) {
-		thePerson, err := queries.PersonAdd(ctx, pgdao.PersonAddParams{
+		thePerson, err := personAdd(ctx, personAddParams{
 			ID:           pgdao.NewID(),
 			Realm:        "inhouse",
 			Login:        pgdao.NewID(),
@@ -0,0 +1,148 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service/pgsvc"
+)
+
+var (
+	savedJobsURL     = appURL + "/me/saved-jobs"
+	savedSearchesURL = appURL + "/me/saved-searches"
+)
+
+func TestSavedJobs(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+
+		job   = addJob(t, "Some job", "Description", customer.ID, "10", "3")
+		draft = doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Draft","description":"Not ready","draft":true}`, customer.AccessToken.String)
+	)
+
+	t.Run("save job", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodPut, savedJobsURL+"/"+job.ID, "", performer.AccessToken.String)
+		doRequest[map[string]any](t, http.MethodPut, savedJobsURL+"/"+job.ID, "", performer.AccessToken.String)
+
+		doFailedRequest(t, http.MethodPut, savedJobsURL+"/nothing", "", performer.AccessToken.String, http.StatusNotFound)
+		doFailedRequest(t, http.MethodPut, savedJobsURL+"/"+draft.ID, "", performer.AccessToken.String, http.StatusNotFound)
+
+		jj := doRequest[[]*model.JobCardDTO](t, http.MethodGet, savedJobsURL, "", performer.AccessToken.String)
+		if assert.Len(t, jj, 1) {
+			assert.Equal(t, job.ID, jj[0].ID)
+			assert.Equal(t, model.JobStatusOpen, jj[0].Status)
+		}
+
+		jj = doRequest[[]*model.JobCardDTO](t, http.MethodGet, savedJobsURL, "", customer.AccessToken.String)
+		assert.Empty(t, jj)
+	})
+
+	t.Run("unsave job", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodDelete, savedJobsURL+"/"+job.ID, "", performer.AccessToken.String)
+		doFailedRequest(t, http.MethodDelete, savedJobsURL+"/"+job.ID, "", performer.AccessToken.String, http.StatusNotFound)
+
+		jj := doRequest[[]*model.JobCardDTO](t, http.MethodGet, savedJobsURL, "", performer.AccessToken.String)
+		assert.Empty(t, jj)
+	})
+}
+
+func TestSavedSearches(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		customer  = addPersonWithEthereumAddress(t, "customer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa6c")
+		performer = addPersonWithEthereumAddress(t, "performer", "0x8Ca2702c5bcc50D79d9a059D58607028aa36Aa78")
+		stranger  = addPerson(t, "stranger")
+	)
+
+	var search model.SavedSearchDTO
+
+	t.Run("validates search", func(t *testing.T) {
+		e := doFailedRequest(t, http.MethodPost, savedSearchesURL, `{"keyword":"golang"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "title is required", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, savedSearchesURL, `{"title":"Go","budget_min":"-1"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "budget_min must not be negative", e.Message)
+
+		e = doFailedRequest(t, http.MethodPost, savedSearchesURL, `{"title":"Go","budget_min":"100","budget_max":"10"}`, performer.AccessToken.String, http.StatusUnprocessableEntity)
+		assert.Equal(t, "budget_max is less than budget_min", e.Message)
+	})
+
+	t.Run("manage searches", func(t *testing.T) {
+		search = doRequest[model.SavedSearchDTO](t, http.MethodPost, savedSearchesURL,
+			`{"title":"Go","keyword":"golang","budget_min":"100"}`, performer.AccessToken.String)
+		assert.NotEmpty(t, search.ID)
+		assert.Equal(t, "golang", search.Keyword)
+		assert.Equal(t, "100", search.BudgetMin.String())
+		assert.True(t, search.BudgetMax.IsZero())
+
+		doFailedRequest(t, http.MethodGet, savedSearchesURL+"/"+search.ID, "", stranger.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodPut, savedSearchesURL+"/"+search.ID, `{"title":"Mine"}`, stranger.AccessToken.String, http.StatusForbidden)
+		doFailedRequest(t, http.MethodGet, savedSearchesURL+"/nothing", "", performer.AccessToken.String, http.StatusNotFound)
+
+		search = doRequest[model.SavedSearchDTO](t, http.MethodPut, savedSearchesURL+"/"+search.ID,
+			`{"title":"Golang","keyword":"golang","budget_min":"100","budget_max":"1000"}`, performer.AccessToken.String)
+		assert.Equal(t, "Golang", search.Title)
+		assert.Equal(t, "1000", search.BudgetMax.String())
+
+		ss := doRequest[[]*model.SavedSearchDTO](t, http.MethodGet, savedSearchesURL, "", performer.AccessToken.String)
+		if assert.Len(t, ss, 1) {
+			assert.Equal(t, search.ID, ss[0].ID)
+		}
+
+		ss = doRequest[[]*model.SavedSearchDTO](t, http.MethodGet, savedSearchesURL, "", stranger.AccessToken.String)
+		assert.Empty(t, ss)
+	})
+
+	t.Run("alerts about new matching jobs", func(t *testing.T) {
+		// the customer's own search must not match own jobs
+		doRequest[model.SavedSearchDTO](t, http.MethodPost, savedSearchesURL, `{"title":"Everything"}`, customer.AccessToken.String)
+
+		matched := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Backend in GoLang","description":"REST API","budget":"150"}`, customer.AccessToken.String)
+		doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Golang script","description":"Too cheap","budget":"50"}`, customer.AccessToken.String)
+		doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Frontend","description":"React","budget":"500"}`, customer.AccessToken.String)
+		draft := doRequest[model.JobDTO](t, http.MethodPost, jobsURL,
+			`{"title":"Golang draft","description":"Later","budget":"200","draft":true}`, customer.AccessToken.String)
+
+		n, err := pgsvc.NewSavedSearch(db).MatchNewJobs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		nn := doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", performer.AccessToken.String)
+		if assert.Len(t, nn, 1) {
+			assert.Equal(t, model.NotificationSavedSearchMatch, nn[0].Kind)
+			assert.Equal(t, "/jobs/"+matched.ID, nn[0].Link)
+			assert.Equal(t, `New job matches your saved search "Golang": Backend in GoLang`, nn[0].Text)
+		}
+
+		nn = doRequest[[]*model.PersonNotification](t, http.MethodGet, appURL+"/me/notifications", "", customer.AccessToken.String)
+		assert.Empty(t, nn)
+
+		n, err = pgsvc.NewSavedSearch(db).MatchNewJobs(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "jobs are matched only once")
+
+		doRequest[model.JobCardDTO](t, http.MethodPost, jobsURL+"/"+draft.ID+"/publish", ``, customer.AccessToken.String)
+
+		n, err = pgsvc.NewSavedSearch(db).MatchNewJobs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n, "drafts are matched after publication")
+	})
+
+	t.Run("delete search", func(t *testing.T) {
+		doFailedRequest(t, http.MethodDelete, savedSearchesURL+"/"+search.ID, "", stranger.AccessToken.String, http.StatusForbidden)
+		doRequest[map[string]any](t, http.MethodDelete, savedSearchesURL+"/"+search.ID, "", performer.AccessToken.String)
+		doFailedRequest(t, http.MethodGet, savedSearchesURL+"/"+search.ID, "", performer.AccessToken.String, http.StatusNotFound)
+	})
+}
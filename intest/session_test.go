@@ -0,0 +1,92 @@
+package intest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+var sessionsURL = appURL + "/me/sessions"
+
+func TestSessions(t *testing.T) {
+	require.NoError(t, pgdao.PurgeDB(ctx, db))
+
+	var (
+		person   = addPerson(t, "person")
+		stranger = addPerson(t, "stranger")
+
+		loginBody = `{"login":"person","password":"person-password"}`
+	)
+
+	laptop := doRequest[model.UserContext](t, http.MethodPost, appURL+"/login", loginBody, "")
+	phone := doRequest[model.UserContext](t, http.MethodPost, appURL+"/login", loginBody, "")
+
+	t.Run("many sessions are alive", func(t *testing.T) {
+		assert.NotEqual(t, laptop.Token, phone.Token)
+		assert.NotEqual(t, laptop.SessionID, phone.SessionID)
+
+		for _, token := range []string{person.AccessToken.String, laptop.Token, phone.Token} {
+			me := doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", token)
+			assert.Equal(t, person.ID, me.Subject.ID)
+		}
+	})
+
+	t.Run("tokens are not stored", func(t *testing.T) {
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from sessions where token_hash = $1`, laptop.Token).Scan(&n))
+		assert.Zero(t, n)
+
+		s, err := queries.SessionGetByTokenHash(ctx, tokenHash(laptop.Token))
+		require.NoError(t, err)
+		assert.Equal(t, laptop.SessionID, s.ID)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		ss := doRequest[[]*model.SessionDTO](t, http.MethodGet, sessionsURL, "", laptop.Token)
+		if assert.Len(t, ss, 3) {
+			for _, s := range ss {
+				assert.Equal(t, s.ID == laptop.SessionID, s.Current)
+				assert.True(t, s.ExpiresAt.After(s.LastUsedAt))
+			}
+		}
+
+		for _, s := range ss {
+			if s.ID == phone.SessionID {
+				assert.Contains(t, s.UserAgent, "Go-http-client")
+				assert.NotEmpty(t, s.IP)
+			}
+		}
+
+		ss = doRequest[[]*model.SessionDTO](t, http.MethodGet, sessionsURL, "", stranger.AccessToken.String)
+		assert.Len(t, ss, 1)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		doFailedRequest(t, http.MethodDelete, sessionsURL+"/unknown", "", laptop.Token, http.StatusNotFound)
+		doFailedRequest(t, http.MethodDelete, sessionsURL+"/"+phone.SessionID, "", stranger.AccessToken.String, http.StatusNotFound)
+
+		doRequest[map[string]any](t, http.MethodDelete, sessionsURL+"/"+phone.SessionID, "", laptop.Token)
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", phone.Token, http.StatusUnauthorized)
+		doRequest[model.UserContext](t, http.MethodGet, appURL+"/me", "", laptop.Token)
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		doRequest[map[string]any](t, http.MethodPost, appURL+"/logout", "", laptop.Token)
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", laptop.Token, http.StatusUnauthorized)
+		doFailedRequest(t, http.MethodPost, appURL+"/logout", "", laptop.Token, http.StatusUnauthorized)
+
+		ss := doRequest[[]*model.SessionDTO](t, http.MethodGet, sessionsURL, "", person.AccessToken.String)
+		assert.Len(t, ss, 1)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `update sessions set expires_at = now() - interval '1 minute' where person_id = $1`, stranger.ID)
+		require.NoError(t, err)
+
+		doFailedRequest(t, http.MethodGet, appURL+"/me", "", stranger.AccessToken.String, http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,394 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// Admin controller
+	Admin struct {
+		sm       service.Security
+		svc      service.Admin
+		connects service.Connects
+	}
+)
+
+// NewAdmin create new service
+func NewAdmin(sm service.Security, svc service.Admin, connects service.Connects) Registerer {
+	return &Admin{
+		sm:       sm,
+		svc:      svc,
+		connects: connects,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *Admin) Register(e *echo.Echo) {
+	prefix := resourceAdmin + "/" + resourcePerson
+	e.GET(prefix, cont.listPersons)
+	e.GET(prefix+"/:id", cont.getPerson)
+	e.POST(prefix+"/:id/grant-admin", cont.grantAdmin)
+	e.POST(prefix+"/:id/revoke-admin", cont.revokeAdmin)
+	e.POST(prefix+"/:id/reset-token", cont.resetToken)
+	e.POST(prefix+"/:id/impersonate", cont.impersonate)
+	e.POST(prefix+"/:id/connects", cont.adjustConnects)
+	e.GET(prefix+"/:id/jobs", cont.listPersonJobs)
+	e.GET(prefix+"/:id/applications", cont.listPersonApplications)
+	e.GET(prefix+"/:id/contracts", cont.listPersonContracts)
+	log.Debug().Str("controller", resourceAdmin).Msg("Registered")
+}
+
+type adminActionParams struct {
+	Reason string `json:"reason"`
+}
+
+// @Summary     List persons
+// @Description Returns persons matching the query by login, display name, email, ID or ethereum address. The newest persons go first.
+// @Description To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       q      query    string false "Search query"
+// @Param       limit  query    int    false "Max number of persons, 50 by default"
+// @Param       offset query    int    false "Number of persons to skip"
+// @Success     200    {array}  model.Person
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons [get]
+func (cont *Admin) listPersons(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := intQueryParam(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	offset, err := intQueryParam(c, "offset")
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListPersons(c.Request().Context(), uc.Subject.ID, c.QueryParam("q"), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// intQueryParam returns zero if the param is not specified
+func intQueryParam(c echo.Context, name string) (int, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, &model.BackendError{
+			Cause:    model.ErrValidationFailed,
+			Message:  model.ValidationErrorInvalidFormat(name),
+			TechInfo: err.Error(),
+		}
+	}
+
+	return i, nil
+}
+
+// @Summary     Get a person
+// @Description Returns the person with admin and blocking flags. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Person ID"
+// @Success     200 {object} model.Person
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id} [get]
+func (cont *Admin) getPerson(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.GetPerson(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Grant admin privileges
+// @Description Grants admin privileges to the person. The action is audited. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       reason body     controller.adminActionParams false "Action params"
+// @Param       id     path     string                       true  "Person ID"
+// @Success     200    {object} model.Person
+// @Failure     400    {object} model.BackendError "person is already admin"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     404    {object} model.BackendError "person not found"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/grant-admin [post]
+func (cont *Admin) grantAdmin(c echo.Context) error {
+	return cont.setAdmin(c, true)
+}
+
+// @Summary     Revoke admin privileges
+// @Description Revokes admin privileges from the person. Admin is unable to revoke own privileges. The action is audited.
+// @Description To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       reason body     controller.adminActionParams false "Action params"
+// @Param       id     path     string                       true  "Person ID"
+// @Success     200    {object} model.Person
+// @Failure     400    {object} model.BackendError "person is not admin"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     404    {object} model.BackendError "person not found"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/revoke-admin [post]
+func (cont *Admin) revokeAdmin(c echo.Context) error {
+	return cont.setAdmin(c, false)
+}
+
+func (cont *Admin) setAdmin(c echo.Context, isAdmin bool) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(adminActionParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.SetAdmin(c.Request().Context(), c.Param("id"), uc.Subject.ID, isAdmin, ie.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Reset access token
+// @Description Ends all sessions and impersonations of the person and revokes API keys, so the person has to log in again on every device.
+// @Description The action is audited.
+// @Description To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       reason body controller.adminActionParams false "Action params"
+// @Param       id     path string                       true  "Person ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/reset-token [post]
+func (cont *Admin) resetToken(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(adminActionParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.ResetAccessToken(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+type adjustConnectsParams struct {
+	Delta  int32  `json:"delta" validate:"required"`
+	Reason string `json:"reason"`
+}
+
+// @Summary     Adjust connects balance
+// @Description Adds connects to the balance of the person or takes them away with negative delta. The balance does not go below zero.
+// @Description The action is audited. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       params body     controller.adjustConnectsParams true "Adjustment params"
+// @Param       id     path     string                          true "Person ID"
+// @Success     200    {object} model.ConnectsDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     404    {object} model.BackendError "person not found"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/connects [post]
+func (cont *Admin) adjustConnects(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(adjustConnectsParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.connects.Adjust(c.Request().Context(), c.Param("id"), uc.Subject.ID, &model.AdjustConnectsDTO{
+		Delta:  ie.Delta,
+		Reason: ie.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Impersonate a person
+// @Description Issues a temporary token to act as the person for support. The reason is mandatory.
+// @Description Issuing of the token and every request made with it are audited. Admins and blocked persons can not be impersonated.
+// @Description To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       reason body     controller.adminActionParams true "Action params"
+// @Param       id     path     string                       true "Person ID"
+// @Success     200    {object} model.UserContext
+// @Failure     400    {object} model.BackendError "person can not be impersonated"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     404    {object} model.BackendError "person not found"
+// @Failure     422    {object} model.BackendError "reason is required"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/impersonate [post]
+func (cont *Admin) impersonate(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(adminActionParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.Impersonate(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     List jobs of a person
+// @Description Returns all jobs of the person in every state. The action is audited. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Person ID"
+// @Success     200 {array}  model.JobCardDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/jobs [get]
+func (cont *Admin) listPersonJobs(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListPersonJobs(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     List applications of a person
+// @Description Returns all applications of the person. The action is audited. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Person ID"
+// @Success     200 {array}  model.ApplicationDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/applications [get]
+func (cont *Admin) listPersonApplications(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListPersonApplications(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     List contracts of a person
+// @Description Returns all contracts where the person is a customer or a performer. The action is audited.
+// @Description To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Person ID"
+// @Success     200 {array}  model.ContractDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/contracts [get]
+func (cont *Admin) listPersonContracts(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListPersonContracts(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
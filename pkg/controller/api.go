@@ -20,7 +20,7 @@ import (
 // @securityDefinitions.apikey BearerToken
 // @in                         header
 // @name                       Authorization
-// @description                Bearer token in Authorization header
+// @description                Bearer token in Authorization header: the token of the session or the personal API key
 
 // SwaggerRegister publishes swagger specification with /swagger/index.html
 func SwaggerRegister(e *echo.Echo) {
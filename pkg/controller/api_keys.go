@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// APIKey controller for personal API keys of the current user
+	APIKey struct {
+		sm  service.Security
+		svc service.APIKey
+	}
+)
+
+// NewAPIKey create new service
+func NewAPIKey(sm service.Security, svc service.APIKey) Registerer {
+	return &APIKey{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+const apiKeysPath = "/me/api-keys"
+
+// Register implements Registerer interface
+func (cont *APIKey) Register(e *echo.Echo) {
+	e.GET(apiKeysPath, cont.list)
+	e.POST(apiKeysPath, cont.add)
+	e.DELETE(apiKeysPath+"/:id", cont.delete)
+	log.Debug().Str("controller", "api-keys").Msg("Registered")
+}
+
+type apiKeyParams struct {
+	Name        string     `json:"name" validate:"required"`
+	Scopes      []string   `json:"scopes" validate:"required" enums:"jobs:read,jobs:write,applications:read,applications:write,contracts:read,contracts:write,chats:read,chats:write"`
+	IPAllowlist []string   `json:"ip_allowlist"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// @Summary     List API keys
+// @Description Returns personal API keys of the current user from the newest. Keys themselves are not returned.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.APIKeyDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/api-keys [get]
+func (cont *APIKey) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Create an API key
+// @Description Creates a personal API key for integrations. The key is returned only in this response, it is not stored.
+// @Description Requests with the key in Bearer authorization are made on behalf of the current user, but only routes of the scopes are accessible.
+// @Description The key is accepted only from addresses of the allowlist if it is not empty. The key does not expire without expires_at.
+// @Description API keys are not able to manage API keys and sessions.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       params body     controller.apiKeyParams true "Key params"
+// @Success     201    {object} model.APIKeyDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/api-keys [post]
+func (cont *APIKey) add(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(apiKeyParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, &model.APIKeyParamsDTO{
+		Name:        ie.Name,
+		Scopes:      ie.Scopes,
+		IPAllowlist: ie.IPAllowlist,
+		ExpiresAt:   ie.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, path.Join(apiKeysPath, o.ID))
+	return c.JSON(http.StatusCreated, o)
+}
+
+// @Summary     Revoke an API key
+// @Description Deletes the API key of the current user, requests with the key are not authorized anymore
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       id path string true "API key ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "API key not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/api-keys/{id} [delete]
+func (cont *APIKey) delete(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Delete(c.Request().Context(), c.Param("id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
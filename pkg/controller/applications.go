@@ -35,6 +35,10 @@ func (cont *Application) Register(e *echo.Echo) {
 	e.GET(resourceApplication, cont.listByApplicant)
 	e.GET(resourceApplication+"/:id", cont.get)
 	e.GET(resourceApplication+"/:id/chat", cont.getChat)
+	e.PUT(resourceApplication+"/:id", cont.update)
+	e.POST(resourceApplication+"/:id/withdraw", cont.withdraw)
+	e.GET(resourceApplication+"/:id/revisions", cont.listRevisions)
+	e.POST(resourceApplication+"/:id/status", cont.setStatus)
 	e.GET(resourceJob+"/:job_id/application", cont.getForJob)
 	e.GET(resourceJob+"/:job_id/"+resourceApplication, cont.listByJob)
 	log.Debug().Str("controller", resourceApplication).Msg("Registered")
@@ -43,10 +47,39 @@ func (cont *Application) Register(e *echo.Echo) {
 type createApplicationParams struct {
 	Comment string          `json:"comment" validate:"required"`
 	Price   decimal.Decimal `json:"price" validate:"required"`
+
+	AttachmentIDs []string        `json:"attachment_ids"`
+	Answers       []*answerParams `json:"answers"`
+}
+
+type answerParams struct {
+	QuestionID string   `json:"question_id" validate:"required"`
+	Text       string   `json:"text"`
+	Options    []string `json:"options"`
+}
+
+func answersFromParams(pp []*answerParams) []*model.AnswerParamsDTO {
+	result := make([]*model.AnswerParamsDTO, 0, len(pp))
+	for _, p := range pp {
+		if p == nil {
+			result = append(result, nil)
+			continue
+		}
+
+		result = append(result, &model.AnswerParamsDTO{
+			QuestionID: p.QuestionID,
+			Text:       p.Text,
+			Options:    p.Options,
+		})
+	}
+
+	return result
 }
 
 // @Summary     Creates a new application for a job
-// @Description Applicant creates a new application for a job
+// @Description Applicant creates a new application for a job. Previously uploaded files can be attached with attachment_ids.
+// @Description Screening questions of the job are answered with answers: text for text questions and options for choice questions.
+// @Description Required questions must be answered.
 // @Tags        application, job
 // @Accept      json
 // @Produce     json
@@ -79,6 +112,9 @@ func (cont *Application) add(c echo.Context) error {
 		JobID:   c.Param("job_id"),
 		Comment: ie.Comment,
 		Price:   ie.Price,
+
+		AttachmentIDs: ie.AttachmentIDs,
+		Answers:       answersFromParams(ie.Answers),
 	}
 
 	newApplication, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, &dto)
@@ -175,14 +211,17 @@ func (cont *Application) getChat(c echo.Context) error {
 }
 
 // @Summary     List applications for the job
-// @Description Returns applications list for the job by job_id
+// @Description Returns applications list for the job by job_id. Only active applications in the status are returned if the status is specified.
+// @Description Every application contains answers to all screening questions of the job in the same order to compare applicants side by side.
 // @Tags        application, job
 // @Accept      json
 // @Produce     json
-// @Param       job_id path     string true "Job ID"
+// @Param       job_id path     string true  "Job ID"
+// @Param       status query    string false "Application status" Enums(new, shortlisted, interviewing, rejected, hired)
 // @Success     200    {array}  model.ApplicationDTO
 // @Failure     401 {object} model.BackendError "user not authorized"
 // @Failure     404    {object} model.BackendError "job not found"
+// @Failure     422    {object} model.BackendError "validation failed"
 // @Failure     500 {object} echo.HTTPError{message=string}
 // @Security    BearerToken
 // @Router      /jobs/{job_id}/applications [get]
@@ -195,7 +234,7 @@ func (cont *Application) listByJob(c echo.Context) error {
 		return err
 	}
 
-	oo, err := cont.svc.ListByJob(ctx, jobID, uc.Subject.ID)
+	oo, err := cont.svc.ListByJob(ctx, jobID, uc.Subject.ID, c.QueryParam("status"))
 	if err != nil {
 		return err
 	}
@@ -226,3 +265,148 @@ func (cont *Application) listByApplicant(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, oo)
 }
+
+type updateApplicationParams struct {
+	Comment string          `json:"comment" validate:"required"`
+	Price   decimal.Decimal `json:"price" validate:"required"`
+}
+
+// @Summary     Update an application
+// @Description Applicant changes comment and price of the application until a contract is created.
+// @Description Every change is saved as a new revision and posted to the application chat.
+// @Tags        application
+// @Accept      json
+// @Produce     json
+// @Param       application body     controller.updateApplicationParams true "Application terms"
+// @Param       id          path     string                             true "Application ID"
+// @Success     200         {object} model.ApplicationDTO
+// @Failure     400         {object} model.BackendError "application is withdrawn or has a contract"
+// @Failure     401         {object} model.BackendError "user not authorized"
+// @Failure     403         {object} model.BackendError "user is not an applicant"
+// @Failure     404         {object} model.BackendError "application not found"
+// @Failure     422         {object} model.BackendError "validation failed"
+// @Failure     500         {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /applications/{id} [put]
+func (cont *Application) update(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(updateApplicationParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.Update(c.Request().Context(), c.Param("id"), uc.Subject.ID, &model.UpdateApplicationDTO{
+		Comment: ie.Comment,
+		Price:   ie.Price,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Withdraw an application
+// @Description Applicant withdraws the application until a contract is created. The applicant is able to apply for the job again.
+// @Tags        application
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Application ID"
+// @Success     200 {object} model.ApplicationDTO
+// @Failure     400 {object} model.BackendError "application is withdrawn or has a contract"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an applicant"
+// @Failure     404 {object} model.BackendError "application not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /applications/{id}/withdraw [post]
+func (cont *Application) withdraw(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.Withdraw(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     List application revisions
+// @Description Returns the edit history of the application from the newest revision to the oldest one. It is available for the applicant and the job owner.
+// @Tags        application
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Application ID"
+// @Success     200 {array}  model.ApplicationRevisionDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is neither an applicant nor a job owner"
+// @Failure     404 {object} model.BackendError "application not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /applications/{id}/revisions [get]
+func (cont *Application) listRevisions(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListRevisions(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+type applicationStatusParams struct {
+	Status          string `json:"status" validate:"required" enums:"new,shortlisted,interviewing,rejected,hired"`
+	RejectionReason string `json:"rejection_reason"`
+}
+
+// @Summary     Change application status
+// @Description Job owner moves the application through the pipeline. Rejection reason is allowed for rejected status only.
+// @Description The applicant gets a notification on each change.
+// @Tags        application
+// @Accept      json
+// @Produce     json
+// @Param       status body     controller.applicationStatusParams true "New status"
+// @Param       id     path     string                             true "Application ID"
+// @Success     200    {object} model.ApplicationDTO
+// @Failure     400    {object} model.BackendError "application is withdrawn or already in the status"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not a job owner"
+// @Failure     404    {object} model.BackendError "application not found"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /applications/{id}/status [post]
+func (cont *Application) setStatus(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(applicationStatusParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.SetStatus(c.Request().Context(), c.Param("id"), uc.Subject.ID, &model.ApplicationStatusDTO{
+		Status:          ie.Status,
+		RejectionReason: ie.RejectionReason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
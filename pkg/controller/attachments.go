@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// Attachment controller
+	Attachment struct {
+		sm  service.Security
+		svc service.Attachment
+	}
+)
+
+// NewAttachment create new service
+func NewAttachment(sm service.Security, svc service.Attachment) Registerer {
+	return &Attachment{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *Attachment) Register(e *echo.Echo) {
+	e.POST(resourceAttachment, cont.add)
+	e.GET(resourceAttachment+"/:id", cont.get)
+	e.GET(resourceAttachment+"/:id/thumbnail", cont.getThumbnail)
+	e.DELETE(resourceAttachment+"/:id", cont.delete)
+	e.GET(resourceJob+"/:job_id/"+resourceAttachment, cont.listByJob)
+	e.GET(resourceApplication+"/:application_id/"+resourceAttachment, cont.listByApplication)
+	log.Debug().Str("controller", resourceAttachment).Msg("Registered")
+}
+
+// @Summary     Upload a file
+// @Description Uploads a file. Use its ID in attachment_ids when creating a job, an application or a chat message to attach the file.
+// @Description Until then the file is available only for the uploader. A thumbnail is made for PNG, JPEG and GIF images.
+// @Tags        attachment
+// @Accept      mpfd
+// @Produce     json
+// @Param       file formData file true "File to upload"
+// @Success     201  {object} model.AttachmentDTO
+// @Failure     401  {object} model.BackendError "user not authorized"
+// @Failure     422  {object} model.BackendError "file is too long, file type is not allowed or file is infected"
+// @Failure     500  {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /attachments [post]
+func (cont *Attachment) add(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return &model.BackendError{
+			Cause:    model.ErrValidationFailed,
+			Message:  model.ValidationErrorRequired("file"),
+			TechInfo: err.Error(),
+		}
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open uploaded file: %w", err)
+	}
+	defer f.Close()
+
+	dto := model.CreateAttachmentDTO{
+		Name:    fh.Filename,
+		Size:    fh.Size,
+		Content: f,
+	}
+
+	o, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, &dto)
+	if err != nil {
+		return fmt.Errorf("unable to add attachment: %w", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, path.Join("/", resourceAttachment, o.ID))
+	return c.JSON(http.StatusCreated, o)
+}
+
+// @Summary     Download a file
+// @Description Returns content of the attached file. Job attachments are available for everyone who can see the job,
+// @Description application attachments are available for the applicant and the job owner only,
+// @Description message attachments are available for participants of the chat only.
+// @Tags        attachment
+// @Produce     octet-stream
+// @Param       id  path     string true "Attachment ID"
+// @Success     200 {file}   binary
+// @Failure     404 {object} model.BackendError "attachment not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /attachments/{id} [get]
+func (cont *Attachment) get(c echo.Context) error {
+	o, content, err := cont.svc.Get(c.Request().Context(), c.Param("id"), optionalActorID(cont.sm, c))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	h := c.Response().Header()
+	h.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": o.Name}))
+	h.Set(echo.HeaderContentLength, strconv.FormatInt(o.Size, 10))
+	h.Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, o.ContentType, content)
+}
+
+// @Summary     Download a thumbnail
+// @Description Returns a preview of the attached image which fits into 256x256 pixels.
+// @Description The thumbnail is available for those who can download the file.
+// @Tags        attachment
+// @Produce     image/png,image/jpeg
+// @Param       id  path     string true "Attachment ID"
+// @Success     200 {file}   binary
+// @Failure     404 {object} model.BackendError "attachment not found or it has no thumbnail"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /attachments/{id}/thumbnail [get]
+func (cont *Attachment) getThumbnail(c echo.Context) error {
+	_, content, err := cont.svc.GetThumbnail(c.Request().Context(), c.Param("id"), optionalActorID(cont.sm, c))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("unable to read thumbnail: %w", err)
+	}
+
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Blob(http.StatusOK, http.DetectContentType(data), data)
+}
+
+// @Summary     Delete a file
+// @Description Deletes the file uploaded by the current user
+// @Tags        attachment
+// @Accept      json
+// @Produce     json
+// @Param       id path string true "Attachment ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an uploader"
+// @Failure     404 {object} model.BackendError "attachment not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /attachments/{id} [delete]
+func (cont *Attachment) delete(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Delete(c.Request().Context(), c.Param("id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     List job attachments
+// @Description Returns files attached to the job
+// @Tags        attachment, job
+// @Accept      json
+// @Produce     json
+// @Param       job_id path     string true "Job ID"
+// @Success     200    {array}  model.AttachmentDTO
+// @Failure     404    {object} model.BackendError "job not found"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{job_id}/attachments [get]
+func (cont *Attachment) listByJob(c echo.Context) error {
+	oo, err := cont.svc.ListByJob(c.Request().Context(), c.Param("job_id"), optionalActorID(cont.sm, c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     List application attachments
+// @Description Returns files attached to the application. Only the applicant and the job owner can list them.
+// @Tags        attachment, application
+// @Accept      json
+// @Produce     json
+// @Param       application_id path     string true "Application ID"
+// @Success     200            {array}  model.AttachmentDTO
+// @Failure     401            {object} model.BackendError "user not authorized"
+// @Failure     404            {object} model.BackendError "application not found"
+// @Failure     500            {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /applications/{application_id}/attachments [get]
+func (cont *Attachment) listByApplication(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListByApplication(c.Request().Context(), c.Param("application_id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
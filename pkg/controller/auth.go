@@ -15,16 +15,20 @@ import (
 type (
 	// Auth controller
 	Auth struct {
-		sm     service.Security
-		person service.Person
+		sm       service.Security
+		person   service.Person
+		connects service.Connects
+		chat     service.Chat
 	}
 )
 
 // NewAuth create new service
-func NewAuth(sm service.Security, person service.Person) Registerer {
+func NewAuth(sm service.Security, person service.Person, connects service.Connects, chat service.Chat) Registerer {
 	return &Auth{
-		sm:     sm,
-		person: person,
+		sm:       sm,
+		person:   person,
+		connects: connects,
+		chat:     chat,
 	}
 }
 
@@ -44,6 +48,7 @@ type loginParams struct {
 
 // @Summary     Login
 // @Description Create user security token for supplied conditionals
+// @Description Every login starts a new session, so the user may be logged in on many devices at once.
 // @Tags        auth
 // @Accept      json
 // @Produce     json
@@ -74,7 +79,7 @@ func (cont *Auth) login(c echo.Context) error {
 		}
 	}
 
-	p, err := cont.sm.FromLoginPassword(c.Request().Context(), ie.Login, ie.Password)
+	p, err := cont.sm.FromLoginPassword(c.Request().Context(), ie.Login, ie.Password, c.Request().UserAgent(), c.RealIP())
 	if err != nil {
 		return err
 	}
@@ -132,6 +137,8 @@ func (cont *Auth) signup(c echo.Context) error {
 
 // @Summary     Returns current user information
 // @Description Returns information about current authenticated user
+// @Description The remaining balance of connects spent on applications is returned if connects are limited.
+// @Description The total count of unread messages in all chats of the user is returned too.
 // @Tags        auth
 // @Accept      json
 // @Produce     json
@@ -146,7 +153,21 @@ func (cont *Auth) me(c echo.Context) error {
 		return fmt.Errorf("unable to acquire user context: %w", err)
 	}
 
-	return c.JSON(http.StatusOK, uctx)
+	connects, err := cont.connects.Balance(c.Request().Context(), uctx.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	unread, err := cont.chat.UnreadCount(c.Request().Context(), uctx.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	result := *uctx
+	result.Connects = connects
+	result.UnreadMessages = unread
+
+	return c.JSON(http.StatusOK, result)
 }
 
 type newPasswordParams struct {
@@ -1,43 +1,58 @@
 package controller
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
 	"optrispace.com/work/pkg/service"
 )
 
 type (
 	// Chat controller for messaging
 	Chat struct {
-		sm  service.Security
-		svc service.Chat
+		sm     service.Security
+		svc    service.Chat
+		events service.ChatEvents
 	}
 )
 
 // NewChat create new service
-func NewChat(sm service.Security, svc service.Chat) Registerer {
+func NewChat(sm service.Security, svc service.Chat, events service.ChatEvents) Registerer {
 	return &Chat{
-		sm:  sm,
-		svc: svc,
+		sm:     sm,
+		svc:    svc,
+		events: events,
 	}
 }
 
 // Register implements Registerer interface
 func (cont *Chat) Register(e *echo.Echo) {
 	e.POST("/chats/:chat_id/messages", cont.addMessage)
+	e.GET("/chats/:chat_id/messages", cont.listMessages)
+	e.PUT("/chats/:chat_id/messages/:message_id", cont.editMessage)
+	e.DELETE("/chats/:chat_id/messages/:message_id", cont.deleteMessage)
 	e.GET("/chats", cont.list)
+	e.GET("/chats/search", cont.search)
+	e.GET("/chats/ws", cont.ws)
+	e.POST("/chats/ws/ticket", cont.wsTicket)
+	e.GET("/chats/events", cont.sse)
+	e.POST("/chats/:chat_id/typing", cont.typing)
+	e.POST("/chats/:chat_id/read", cont.markRead)
 	e.GET("/chats/:chat_id", cont.getChat)
 	log.Debug().Str("controller", "chats").Msg("Registered")
 }
 
 type newMessage struct {
-	Text string `json:"text,omitempty"`
+	Text          string   `json:"text,omitempty"`
+	AttachmentIDs []string `json:"attachment_ids,omitempty"` // IDs of files uploaded with POST /attachments
 }
 
 // @Summary     Post a new message to the chat
-// @Description A chat participant sending message to the chat
+// @Description A chat participant sending message to the chat. Previously uploaded files may be attached to the message,
+// @Description the text may be omitted then. Attached files are available for participants of the chat only.
 // @Tags        chat
 // @Accept      json
 // @Produce     json
@@ -47,7 +62,7 @@ type newMessage struct {
 // @Failure     401 {object} model.BackendError "user is not authorized"
 // @Failure     403 {object} model.BackendError "user is not conversation participant"
 // @Failure     404 {object} model.BackendError "chat does not exist"
-// @Failure     422     {object} model.BackendError "message text exceeds maximum text length"
+// @Failure     422     {object} model.BackendError "message text exceeds maximum text length or attachments are unknown"
 // @Failure     500 {object} echo.HTTPError{message=string}
 // @Security    BearerToken
 // @Router      /chats/{chat_id}/messages [post]
@@ -63,20 +78,92 @@ func (cont *Chat) addMessage(c echo.Context) error {
 		return e
 	}
 
-	m, err := cont.svc.AddMessage(c.Request().Context(), c.Param("chat_id"), uc.Subject.ID, ie.Text)
+	m, err := cont.svc.AddMessage(c.Request().Context(), c.Param("chat_id"), uc.Subject.ID, &model.CreateMessageDTO{
+		Text:          ie.Text,
+		AttachmentIDs: ie.AttachmentIDs,
+	})
 	if err == nil {
 		return c.JSON(http.StatusCreated, m)
 	}
 	return err
 }
 
+// @Summary     Edit own message
+// @Description The author replaces the text of the message within the edit window after posting.
+// @Description The previous text is kept for moderation. System messages can not be edited.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       message    body     controller.newMessage true "New text of the message"
+// @Param       chat_id    path     string                true "chat id"
+// @Param       message_id path     string                true "message id"
+// @Success     200        {object} model.Message
+// @Failure     400        {object} model.BackendError "message is a system one, is deleted or the edit window has passed"
+// @Failure     401        {object} model.BackendError "user is not authorized"
+// @Failure     403        {object} model.BackendError "user is not the author of the message"
+// @Failure     404        {object} model.BackendError "message does not exist or user is not conversation participant"
+// @Failure     422        {object} model.BackendError "message text is empty or exceeds maximum text length"
+// @Failure     500        {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/{chat_id}/messages/{message_id} [put]
+func (cont *Chat) editMessage(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(newMessage)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	m, err := cont.svc.EditMessage(c.Request().Context(), c.Param("chat_id"), c.Param("message_id"), uc.Subject.ID, ie.Text)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, m)
+}
+
+// @Summary     Delete own message
+// @Description The author deletes the message within the edit window after posting.
+// @Description The message remains in the chat with deleted_at and empty text, the text is kept for moderation.
+// @Description System messages can not be deleted.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       chat_id    path string true "chat id"
+// @Param       message_id path string true "message id"
+// @Success     200
+// @Failure     400 {object} model.BackendError "message is a system one, is already deleted or the edit window has passed"
+// @Failure     401 {object} model.BackendError "user is not authorized"
+// @Failure     403 {object} model.BackendError "user is not the author of the message"
+// @Failure     404 {object} model.BackendError "message does not exist or user is not conversation participant"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/{chat_id}/messages/{message_id} [delete]
+func (cont *Chat) deleteMessage(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.DeleteMessage(c.Request().Context(), c.Param("chat_id"), c.Param("message_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
 // @Summary     Returns a fully chat description
-// @Description A chat participant is requesting chat description with all messages
+// @Description A chat participant is requesting chat description with the most recent messages.
+// @Description has_older is set if there are older messages, they are available with GET /chats/{chat_id}/messages.
 // @Tags        chat
 // @Accept      json
 // @Produce     json
 // @Param       chat_id path     string                true "chat id"
-// @Success     200     {object} model.Chat         "chat will be returned with all messages"
+// @Success     200     {object} model.Chat         "chat will be returned with the most recent messages"
 // @Failure     401     {object} model.BackendError "user is not authorized"
 // @Failure     403     {object} model.BackendError "user is not conversation participant"
 // @Failure     404     {object} model.BackendError "chat does not exist"
@@ -96,8 +183,88 @@ func (cont *Chat) getChat(c echo.Context) error {
 	return err
 }
 
+// @Summary     Returns a page of chat messages
+// @Description A chat participant is requesting messages of the chat in order of creation.
+// @Description The most recent messages are returned without cursors.
+// @Description Messages right before the message with the before ID are returned to scroll back,
+// @Description messages right after the message with the after ID are returned to catch up.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       chat_id path     string true  "chat id"
+// @Param       before  query    string false "ID of the message, older messages are returned"
+// @Param       after   query    string false "ID of the message, newer messages are returned"
+// @Param       limit   query    int    false "Max number of messages, 50 by default"
+// @Success     200     {object} model.MessagePageDTO
+// @Failure     401     {object} model.BackendError "user is not authorized"
+// @Failure     404     {object} model.BackendError "chat does not exist or user is not conversation participant"
+// @Failure     422     {object} model.BackendError "invalid limit or the message of the cursor does not belong to the chat"
+// @Failure     500     {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/{chat_id}/messages [get]
+func (cont *Chat) listMessages(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := intQueryParam(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.ListMessages(c.Request().Context(), c.Param("chat_id"), uc.Subject.ID, &model.MessagePageParamsDTO{
+		Before: c.QueryParam("before"),
+		After:  c.QueryParam("after"),
+		Limit:  limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Search chat messages
+// @Description Full-text search over messages of all chats of the current user, the newest messages go first.
+// @Description The query supports web search syntax: "quoted phrase", OR and -excluded words. Deleted messages are not found.
+// @Description The snippet contains HTML-escaped fragments of the message where matches are wrapped into <mark> tags.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       q     query    string true  "Search query"
+// @Param       limit query    int    false "Max number of messages, 50 by default"
+// @Success     200   {array}  model.MessageSearchHitDTO
+// @Failure     401   {object} model.BackendError "user is not authorized"
+// @Failure     422   {object} model.BackendError "empty query or invalid limit"
+// @Failure     500   {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/search [get]
+func (cont *Chat) search(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := intQueryParam(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.SearchMessages(c.Request().Context(), uc.Subject.ID, &model.MessageSearchParamsDTO{
+		Query: c.QueryParam("q"),
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
 // @Summary     Returns list of chats for the current user
-// @Description User is requesting all available chats sorted by last message create time in reverse order
+// @Description User is requesting all available chats sorted by last message create time in reverse order.
+// @Description Every chat contains the count of messages which are not read by the user.
 // @Tags        chat
 // @Accept      json
 // @Produce     json
@@ -121,3 +288,42 @@ func (cont *Chat) list(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, m)
 }
+
+type markReadParams struct {
+	MessageID string `json:"message_id,omitempty"`
+}
+
+// @Summary     Mark chat messages as read
+// @Description Marks messages of the chat as read by the current user up to the message with message_id including it.
+// @Description All messages are marked as read if message_id is not specified. The read marker never moves backward.
+// @Description Other participants get a read event, so they know their messages are seen.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       params  body controller.markReadParams false "Read params"
+// @Param       chat_id path string                    true  "chat id"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user is not authorized"
+// @Failure     404 {object} model.BackendError "chat does not exist or user is not conversation participant"
+// @Failure     422 {object} model.BackendError "message does not belong to the chat"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/{chat_id}/read [post]
+func (cont *Chat) markRead(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(markReadParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.MarkRead(c.Request().Context(), c.Param("chat_id"), uc.Subject.ID, ie.MessageID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
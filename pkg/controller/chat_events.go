@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/model"
+)
+
+const (
+	// chatEventsPingPeriod is a period of pings and heartbeats keeping connections alive behind proxies
+	chatEventsPingPeriod = 30 * time.Second
+
+	// chatEventsPongWait is a time to wait for a pong from the WebSocket client
+	chatEventsPongWait = 2 * chatEventsPingPeriod
+
+	chatEventsWriteWait = 10 * time.Second
+
+	// chatEventsReadLimit is a max size of a WebSocket message from the client
+	chatEventsReadLimit = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	// the bearer token is checked explicitly, so clients of any origin are allowed like with enabled CORS
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// chatClientEvent is an event sent by the WebSocket client
+type chatClientEvent struct {
+	Kind   string `json:"kind"`
+	ChatID string `json:"chat_id"`
+}
+
+// wsPersonID authenticates the user of the WebSocket
+// Browsers can not set headers for WebSocket, so the ticket from POST /chats/ws/ticket may be passed with the ticket query parameter.
+// The access token is never accepted in the query, because URLs get into proxy and access logs.
+func (cont *Chat) wsPersonID(c echo.Context) (string, error) {
+	if ticket := c.QueryParam("ticket"); ticket != "" {
+		return cont.events.UseTicket(c.Request().Context(), ticket)
+	}
+
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.Subject.ID, nil
+}
+
+// @Summary     Issue a WebSocket ticket
+// @Description Issues a single-use ticket for the WebSocket handshake of GET /chats/ws, it expires in 30 seconds.
+// @Description Browsers can not set headers for WebSocket, so the ticket is passed in the URL instead of the access token.
+// @Tags        chat
+// @Produce     json
+// @Success     201 {object} model.ChatTicketDTO
+// @Failure     401 {object} model.BackendError "user is not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/ws/ticket [post]
+func (cont *Chat) wsTicket(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	t, err := cont.events.Ticket(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, t)
+}
+
+// @Summary     Chat events over WebSocket
+// @Description Upgrades the connection to WebSocket and pushes events of all chats of the current user as JSON objects:
+// @Description new messages including system ones (kind is message), edited and deleted messages (kind is edit or delete),
+// @Description typing indicators of other participants (kind is typing)
+// @Description and read markers of participants (kind is read).
+// @Description The client may send {"kind":"typing","chat_id":"..."} to notify other participants that the user is typing.
+// @Description Browsers can not set headers for WebSocket, so they pass the ticket issued by POST /chats/ws/ticket instead.
+// @Tags        chat
+// @Param       ticket query    string false "Ticket from POST /chats/ws/ticket, if Authorization header is not set"
+// @Success     101    {object} model.ChatEvent
+// @Failure     401    {object} model.BackendError "user is not authorized or the ticket is used or expired"
+// @Security    BearerToken
+// @Router      /chats/ws [get]
+func (cont *Chat) ws(c echo.Context) error {
+	personID, err := cont.wsPersonID(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		clog.Ectx(c).Warn().Err(err).Msg("Unable to upgrade connection to WebSocket")
+		return nil // the upgrader has already replied with an error
+	}
+	defer conn.Close()
+
+	events, cancel := cont.events.Subscribe(personID)
+	defer cancel()
+
+	ctx := c.Request().Context()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		conn.SetReadLimit(chatEventsReadLimit)
+		_ = conn.SetReadDeadline(time.Now().Add(chatEventsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(chatEventsPongWait))
+		})
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			in := new(chatClientEvent)
+			if e := json.Unmarshal(data, in); e != nil || in.Kind != model.ChatEventTyping {
+				continue // unknown events are ignored
+			}
+
+			if e := cont.events.Typing(ctx, in.ChatID, personID); e != nil {
+				clog.Ectx(c).Warn().Err(e).Str("chat", in.ChatID).Msg("Unable to notify about typing")
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(chatEventsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return nil
+
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(chatEventsWriteWait))
+			if e := conn.WriteJSON(ev); e != nil {
+				return nil
+			}
+
+		case <-ticker.C:
+			if e := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(chatEventsWriteWait)); e != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// @Summary     Chat events over Server-Sent Events
+// @Description Streams events of all chats of the current user as a fallback for clients without WebSocket.
+// @Description The event name is the kind of the event (message, edit, delete, typing or read), the data is the JSON object of the event.
+// @Description Typing indicators are sent with POST /chats/{chat_id}/typing.
+// @Description The Authorization header is required, browsers need an EventSource implementation supporting headers.
+// @Tags        chat
+// @Produce     text/event-stream
+// @Success     200 {object} model.ChatEvent
+// @Failure     401 {object} model.BackendError "user is not authorized"
+// @Security    BearerToken
+// @Router      /chats/events [get]
+func (cont *Chat) sse(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	events, cancel := cont.events.Subscribe(uc.Subject.ID)
+	defer cancel()
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disables buffering in nginx
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	ticker := time.NewTicker(chatEventsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(ev)
+			if err != nil {
+				return fmt.Errorf("unable to marshal chat event: %w", err)
+			}
+
+			if _, e := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Kind, data); e != nil {
+				return nil
+			}
+			w.Flush()
+
+		case <-ticker.C:
+			if _, e := fmt.Fprint(w, ": heartbeat\n\n"); e != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
+
+// @Summary     Notify about typing
+// @Description Notifies other participants of the chat that the current user is typing. It is intended for clients using Server-Sent Events.
+// @Tags        chat
+// @Accept      json
+// @Produce     json
+// @Param       chat_id path string true "chat id"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user is not authorized"
+// @Failure     404 {object} model.BackendError "chat does not exist or user is not conversation participant"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /chats/{chat_id}/typing [post]
+func (cont *Chat) typing(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.events.Typing(c.Request().Context(), c.Param("chat_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
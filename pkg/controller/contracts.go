@@ -80,6 +80,7 @@ func (cont *Contract) Register(e *echo.Echo) {
 	e.POST(resourceContract, cont.add)
 	e.GET(resourceContract, cont.list)
 	e.GET(resourceContract+"/:id", cont.get)
+	e.GET(resourceContract+"/:id/chat", cont.chat)
 	e.POST(resourceContract+"/:id/accept", cont.accept)
 	e.POST(resourceContract+"/:id/deploy", cont.deploy)
 	e.POST(resourceContract+"/:id/sign", cont.sign)
@@ -172,6 +173,33 @@ func (cont *Contract) get(c echo.Context) error {
 	return c.JSON(http.StatusOK, o)
 }
 
+// @Summary     Get contract chat
+// @Description Returns the chat of the contract with the latest messages. Contract status changes are posted into this chat.
+// @Description This operation is allowed only for performer, customer or administrator joined the chat.
+// @Tags        contract
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Contract ID"
+// @Success     200 {object} model.Chat
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "contract not found or user not authorized to view the chat"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /contracts/{id}/chat [get]
+func (cont *Contract) chat(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.GetChat(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
 // @Summary     List contracts
 // @Description Returns list of contracts where the current user is performer or customer
 // @Tags        contract
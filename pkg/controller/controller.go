@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/labstack/echo/v4"
+	"optrispace.com/work/pkg/service"
 )
 
 type (
@@ -20,4 +21,23 @@ const (
 	resourceContract     = "contracts"
 	resourceNotification = "notifications"
 	resourceStats        = "stats"
+	resourceInvitation   = "invitations"
+	resourceAttachment   = "attachments"
+	resourceReport       = "reports"
+	resourceAdmin        = "admin"
 )
+
+// optionalActorID returns ID of the authenticated user or an empty string for anonymous requests
+// It is useful for endpoints which are available without authentication
+func optionalActorID(sm service.Security, c echo.Context) string {
+	if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+		return ""
+	}
+
+	uc, err := sm.FromEchoContext(c)
+	if err != nil {
+		return ""
+	}
+
+	return uc.Subject.ID
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// EmailDigest controller for email digests of unread messages
+	EmailDigest struct {
+		sm  service.Security
+		svc service.EmailDigest
+	}
+)
+
+// NewEmailDigest create new service
+func NewEmailDigest(sm service.Security, svc service.EmailDigest) Registerer {
+	return &EmailDigest{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+const emailDigestPath = "/me/email-digest"
+
+// Register implements Registerer interface
+func (cont *EmailDigest) Register(e *echo.Echo) {
+	e.GET(emailDigestPath, cont.get)
+	e.PUT(emailDigestPath, cont.update)
+	e.GET("/email-digest/unsubscribe", cont.checkUnsubscribe)
+	e.POST("/email-digest/unsubscribe", cont.unsubscribe)
+	log.Debug().Str("controller", "email-digest").Msg("Registered")
+}
+
+type emailDigestParams struct {
+	Frequency string `json:"frequency" validate:"required" enums:"never,hourly,daily,weekly"`
+}
+
+// @Summary     Get email digest settings
+// @Description Returns how often the current user receives email digests of unread chat messages and contract status changes.
+// @Description Digests are off (never) until the user chooses a frequency.
+// @Tags        auth, chat
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} model.EmailDigestSettingsDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/email-digest [get]
+func (cont *EmailDigest) get(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.GetSettings(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Update email digest settings
+// @Description Changes how often the current user receives email digests. Digests are not sent with never frequency.
+// @Tags        auth, chat
+// @Accept      json
+// @Produce     json
+// @Param       params body     controller.emailDigestParams true "Digest params"
+// @Success     200    {object} model.EmailDigestSettingsDTO
+// @Failure     400    {object} model.BackendError "invalid format"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     422    {object} model.BackendError "unknown frequency"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/email-digest [put]
+func (cont *EmailDigest) update(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(emailDigestParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.SetFrequency(c.Request().Context(), uc.Subject.ID, ie.Frequency)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Check unsubscribe token
+// @Description Checks the token from the link in the email without unsubscribing, so the web page is able to ask for confirmation.
+// @Description Authorization is not required.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       token query string true "Token from the unsubscribe link"
+// @Success     200
+// @Failure     404 {object} model.BackendError "unknown token"
+// @Failure     422 {object} model.BackendError "token is required"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Router      /email-digest/unsubscribe [get]
+func (cont *EmailDigest) checkUnsubscribe(c echo.Context) error {
+	if e := cont.svc.CheckUnsubscribe(c.Request().Context(), c.QueryParam("token")); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Unsubscribe from email digests
+// @Description Stops email digests for the person with the token from the link in the email. Authorization is not required.
+// @Description It is used for one-click unsubscribe by mail clients (RFC 8058) and by the confirmation web page.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       token query string true "Token from the unsubscribe link"
+// @Success     200
+// @Failure     404 {object} model.BackendError "unknown token"
+// @Failure     422 {object} model.BackendError "token is required"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Router      /email-digest/unsubscribe [post]
+func (cont *EmailDigest) unsubscribe(c echo.Context) error {
+	if e := cont.svc.Unsubscribe(c.Request().Context(), c.QueryParam("token")); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
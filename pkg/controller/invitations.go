@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// Invitation controller
+	Invitation struct {
+		sm  service.Security
+		svc service.Invitation
+	}
+)
+
+// NewInvitation create new service
+func NewInvitation(sm service.Security, svc service.Invitation) Registerer {
+	return &Invitation{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *Invitation) Register(e *echo.Echo) {
+	e.POST(resourceJob+"/:job_id/"+resourceInvitation, cont.add)
+	e.GET(resourceJob+"/:job_id/"+resourceInvitation, cont.listByJob)
+	e.GET(resourceInvitation, cont.listByPerson)
+	e.GET(resourceInvitation+"/:id", cont.get)
+	e.POST(resourceInvitation+"/:id/accept", cont.accept)
+	e.POST(resourceInvitation+"/:id/decline", cont.decline)
+	log.Debug().Str("controller", resourceInvitation).Msg("Registered")
+}
+
+type createInvitationParams struct {
+	PersonID string `json:"person_id"`
+	Login    string `json:"login"`
+	Message  string `json:"message"`
+}
+
+// @Summary     Invite a person to the job
+// @Description Customer invites a person by ID or login to apply for the job. The invited person gets a notification and a chat with the customer.
+// @Tags        invitation, job
+// @Accept      json
+// @Produce     json
+// @Param       invitation body     controller.createInvitationParams true "Invitation params (person_id or login is required)"
+// @Param       job_id     path     string                            true "Job ID"
+// @Success     201        {object} model.InvitationDTO
+// @Failure     400        {object} model.BackendError "inappropriate action"
+// @Failure     401        {object} model.BackendError "user not authorized"
+// @Failure     403        {object} model.BackendError "user is not an owner of the job or is blocked by the person"
+// @Failure     404        {object} model.BackendError "job or person not found"
+// @Failure     409        {object} model.BackendError "person is already invited or applied"
+// @Failure     422        {object} model.BackendError "validation failed"
+// @Failure     500        {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{job_id}/invitations [post]
+func (cont *Invitation) add(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(createInvitationParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	dto := model.CreateInvitationDTO{
+		JobID:    c.Param("job_id"),
+		PersonID: ie.PersonID,
+		Login:    ie.Login,
+		Message:  ie.Message,
+	}
+
+	o, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, &dto)
+	if err != nil {
+		return fmt.Errorf("unable to add invitation: %w", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, path.Join("/", resourceInvitation, o.ID))
+	return c.JSON(http.StatusCreated, o)
+}
+
+// @Summary     List invitations to the job
+// @Description Returns invitations to the job. Only the job owner can list them.
+// @Tags        invitation, job
+// @Accept      json
+// @Produce     json
+// @Param       job_id path     string true "Job ID"
+// @Success     200    {array}  model.InvitationDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not an owner of the job"
+// @Failure     404    {object} model.BackendError "job not found"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{job_id}/invitations [get]
+func (cont *Invitation) listByJob(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListByJob(c.Request().Context(), c.Param("job_id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     List invitations of the current user
+// @Description Returns invitations addressed to the current user
+// @Tags        invitation
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.InvitationDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /invitations [get]
+func (cont *Invitation) listByPerson(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListByPerson(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Get an invitation
+// @Description Returns an invitation by ID. It is available for the job owner and the invited person only.
+// @Tags        invitation
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Invitation ID"
+// @Success     200 {object} model.InvitationDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "invitation not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /invitations/{id} [get]
+func (cont *Invitation) get(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.Get(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+type acceptInvitationParams struct {
+	Comment       string          `json:"comment" validate:"required"`
+	Price         decimal.Decimal `json:"price" validate:"required"`
+	AttachmentIDs []string        `json:"attachment_ids"`
+	Answers       []*answerParams `json:"answers"`
+}
+
+// @Summary     Accept an invitation
+// @Description Invited person accepts the invitation. A new application for the job is created with supplied comment, price, attachments and answers to screening questions.
+// @Description The application costs connects like a direct one.
+// @Tags        invitation, application
+// @Accept      json
+// @Produce     json
+// @Param       application body     controller.acceptInvitationParams true "Application params"
+// @Param       id          path     string                            true "Invitation ID"
+// @Success     200         {object} model.InvitationDTO
+// @Failure     400         {object} model.BackendError "invitation is already responded"
+// @Failure     401         {object} model.BackendError "user not authorized"
+// @Failure     403         {object} model.BackendError "user is not an invited person"
+// @Failure     404         {object} model.BackendError "invitation not found"
+// @Failure     409         {object} model.BackendError "application already exists"
+// @Failure     422         {object} model.BackendError "validation failed or not enough connects"
+// @Failure     500         {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /invitations/{id}/accept [post]
+func (cont *Invitation) accept(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(acceptInvitationParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if err = validateStruct(ie); err != nil {
+		return err
+	}
+
+	dto := model.AcceptInvitationDTO{
+		Comment:       ie.Comment,
+		Price:         ie.Price,
+		AttachmentIDs: ie.AttachmentIDs,
+		Answers:       answersFromParams(ie.Answers),
+	}
+
+	o, err := cont.svc.Accept(c.Request().Context(), c.Param("id"), uc.Subject.ID, &dto)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Decline an invitation
+// @Description Invited person declines the invitation
+// @Tags        invitation
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Invitation ID"
+// @Success     200 {object} model.InvitationDTO
+// @Failure     400 {object} model.BackendError "invitation is already responded"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an invited person"
+// @Failure     404 {object} model.BackendError "invitation not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /invitations/{id}/decline [post]
+func (cont *Invitation) decline(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.Decline(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
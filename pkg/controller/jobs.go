@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
@@ -33,11 +34,20 @@ func NewJob(sm service.Security, svc service.Job) Registerer {
 func (cont *Job) Register(e *echo.Echo) {
 	e.POST(resourceJob, cont.add)
 	e.GET(resourceJob, cont.list)
+	e.GET(resourceJob+"/mine", cont.listMine)
+	e.GET(resourceJob+"/feed.atom", cont.feedAtom)
+	e.GET(resourceJob+"/feed.rss", cont.feedRSS)
+	e.GET(resourceJob+"/feed.json", cont.feedJSON)
 	e.GET(resourceJob+"/:id", cont.get)
+	e.GET(resourceJob+"/:id/revisions", cont.listRevisions)
+	e.PUT(resourceJob+"/:id/questions", cont.setQuestions)
 	e.PUT(resourceJob+"/:id", cont.update)
 	e.POST(resourceJob+"/:id/block", cont.block)
+	e.POST(resourceJob+"/:id/unblock", cont.unblock)
 	e.POST(resourceJob+"/:id/suspend", cont.suspend)
 	e.POST(resourceJob+"/:id/resume", cont.resume)
+	e.POST(resourceJob+"/:id/reopen", cont.reopen)
+	e.POST(resourceJob+"/:id/publish", cont.publish)
 	log.Debug().Str("controller", resourceJob).Msg("Registered")
 }
 
@@ -46,10 +56,48 @@ type createJobParams struct {
 	Description string          `json:"description" validate:"required"`
 	Budget      decimal.Decimal `json:"budget"`
 	Duration    int32           `json:"duration"`
+	Visibility  string          `json:"visibility" enums:"public,private"`
+	ExpiresAt   *time.Time      `json:"expires_at"`
+
+	AttachmentIDs []string             `json:"attachment_ids"`
+	Questions     []*jobQuestionParams `json:"questions"`
+
+	Draft     bool       `json:"draft"`
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+type jobQuestionParams struct {
+	Kind     string   `json:"kind" validate:"required" enums:"text,single_choice,multi_choice"`
+	Text     string   `json:"text" validate:"required"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+func jobQuestionsFromParams(pp []*jobQuestionParams) []*model.JobQuestionParamsDTO {
+	result := make([]*model.JobQuestionParamsDTO, 0, len(pp))
+	for _, p := range pp {
+		if p == nil {
+			result = append(result, nil)
+			continue
+		}
+
+		result = append(result, &model.JobQuestionParamsDTO{
+			Kind:     p.Kind,
+			Text:     p.Text,
+			Options:  p.Options,
+			Required: p.Required,
+		})
+	}
+
+	return result
 }
 
 // @Summary     Create a new job
-// @Description Creates a new job
+// @Description Creates a new job. If expires_at is specified, the job stops receiving applications after this moment.
+// @Description Previously uploaded files can be attached with attachment_ids.
+// @Description If draft is true or publish_at is specified, the job is saved as a draft which is visible for the owner only.
+// @Description The draft with publish_at is published automatically at this moment.
+// @Description Screening questions can be specified with questions, applicants have to answer required ones.
 // @Tags        job
 // @Accept      json
 // @Produce     json
@@ -81,6 +129,14 @@ func (cont *Job) add(c echo.Context) error {
 		Description: ie.Description,
 		Budget:      ie.Budget,
 		Duration:    ie.Duration,
+		Visibility:  ie.Visibility,
+		ExpiresAt:   ie.ExpiresAt,
+
+		AttachmentIDs: ie.AttachmentIDs,
+		Questions:     jobQuestionsFromParams(ie.Questions),
+
+		Draft:     ie.Draft,
+		PublishAt: ie.PublishAt,
 	}
 
 	newJob, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, &dto)
@@ -93,16 +149,82 @@ func (cont *Job) add(c echo.Context) error {
 }
 
 // @Summary     List jobs
-// @Description Returns list of jobs
+// @Description Returns list of open public jobs from the last updated
 // @Tags        job
 // @Accept      json
 // @Produce     json
-// @Success     200 {array}  model.JobDTO
-// @Failure     500 {object} echo.HTTPError{message=string}
+// @Param       keyword    query    string false "Text which the job title or description has to contain"
+// @Param       budget_min query    string false "Minimal job budget"
+// @Param       budget_max query    string false "Maximal job budget"
+// @Success     200        {array}  model.JobDTO
+// @Failure     422        {object} model.BackendError "validation failed"
+// @Failure     500        {object} echo.HTTPError{message=string}
 // @Security    BearerToken
 // @Router      /jobs [get]
 func (cont *Job) list(c echo.Context) error {
-	oo, err := cont.svc.List(c.Request().Context())
+	filter, err := jobFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// jobFilterFromQuery reads the job list filter from query params
+func jobFilterFromQuery(c echo.Context) (*model.JobFilterDTO, error) {
+	filter := &model.JobFilterDTO{
+		Keyword: c.QueryParam("keyword"),
+	}
+
+	for _, p := range []struct {
+		name  string
+		value *decimal.Decimal
+	}{
+		{"budget_min", &filter.BudgetMin},
+		{"budget_max", &filter.BudgetMax},
+	} {
+		v := c.QueryParam(p.name)
+		if v == "" {
+			continue
+		}
+
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			return nil, &model.BackendError{
+				Cause:    model.ErrValidationFailed,
+				Message:  model.ValidationErrorInvalidFormat(p.name),
+				TechInfo: err.Error(),
+			}
+		}
+
+		*p.value = d
+	}
+
+	return filter, nil
+}
+
+// @Summary     List jobs of the current user
+// @Description Returns all jobs of the current user in every state: drafts, open, suspended, expired, closed and blocked
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.JobCardDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/mine [get]
+func (cont *Job) listMine(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListByOwner(c.Request().Context(), uc.Subject.ID)
 	if err != nil {
 		return err
 	}
@@ -111,7 +233,7 @@ func (cont *Job) list(c echo.Context) error {
 }
 
 // @Summary     Get job by id
-// @Description Returns job by id
+// @Description Returns job by id. Private job is available only for its owner and invited persons. Draft is available only for its owner. Blocked job is available only for its owner with blocked status.
 // @Tags        job
 // @Accept      json
 // @Produce     json
@@ -122,7 +244,7 @@ func (cont *Job) list(c echo.Context) error {
 // @Security    BearerToken
 // @Router      /jobs/{id} [get]
 func (cont *Job) get(c echo.Context) error {
-	o, err := cont.svc.Get(c.Request().Context(), c.Param("id"))
+	o, err := cont.svc.Get(c.Request().Context(), c.Param("id"), optionalActorID(cont.sm, c))
 	if err != nil {
 		return err
 	}
@@ -130,15 +252,80 @@ func (cont *Job) get(c echo.Context) error {
 	return c.JSON(http.StatusOK, o)
 }
 
+// @Summary     List job revisions
+// @Description Returns the edit history of the job from the newest revision to the oldest one. It is available for everyone who can see the job.
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Job ID"
+// @Success     200 {array}  model.JobRevisionDTO
+// @Failure     404 {object} model.BackendError "job not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{id}/revisions [get]
+func (cont *Job) listRevisions(c echo.Context) error {
+	oo, err := cont.svc.ListRevisions(c.Request().Context(), c.Param("id"), optionalActorID(cont.sm, c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+type setJobQuestionsParams struct {
+	Questions []*jobQuestionParams `json:"questions"`
+}
+
+// @Summary     Set job screening questions
+// @Description Replaces screening questions of the job. Questions can be changed only until the job gets the first application.
+// @Description Options are required for single_choice and multi_choice questions. An empty list removes all questions.
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Param       questions body     controller.setJobQuestionsParams true "Questions"
+// @Param       id        path     string                           true "Job ID"
+// @Success     200       {array}  model.JobQuestionDTO
+// @Failure     400       {object} model.BackendError "invalid format or the job already has applications"
+// @Failure     401       {object} model.BackendError "user not authorized"
+// @Failure     403       {object} model.BackendError "current user is not the job owner"
+// @Failure     404       {object} model.BackendError "job not found"
+// @Failure     422       {object} model.BackendError "validation failed"
+// @Failure     500       {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{id}/questions [put]
+func (cont *Job) setQuestions(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(setJobQuestionsParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	oo, err := cont.svc.SetQuestions(c.Request().Context(), c.Param("id"), uc.Subject.ID, jobQuestionsFromParams(ie.Questions))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
 type updateJobParams struct {
 	Title       string          `json:"title" validate:"required"`
 	Description string          `json:"description" validate:"required"`
 	Budget      decimal.Decimal `json:"budget"`
 	Duration    int32           `json:"duration"`
+	ExpiresAt   *time.Time      `json:"expires_at"`
+
+	ClearExpiresAt bool `json:"clear_expires_at"`
 }
 
 // @Summary     Update job
-// @Description Updates job
+// @Description Updates job. Every change of the job terms is saved as a new revision. Applicants are notified in application chats when the budget or description changes.
+// @Description The expiration is kept without expires_at, clear_expires_at removes it.
 // @Tags        job
 // @Accept      json
 // @Produce     json
@@ -174,6 +361,9 @@ func (cont *Job) update(c echo.Context) error {
 		Description: ie.Description,
 		Budget:      ie.Budget,
 		Duration:    ie.Duration,
+		ExpiresAt:   ie.ExpiresAt,
+
+		ClearExpiresAt: ie.ClearExpiresAt,
 	}
 
 	o, err := cont.svc.Patch(c.Request().Context(), id, uc.Subject.ID, &dto)
@@ -184,15 +374,23 @@ func (cont *Job) update(c echo.Context) error {
 	return c.JSON(http.StatusOK, o)
 }
 
+type blockParams struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
 // @Summary     Block a job
 // @Description Blocks existent job to hide it from public access. To execute this action, user must have admin privileges.
+// @Description The reason is mandatory, it is kept in the audit log.
 // @Tags        job
 // @Accept      json
 // @Produce     json
-// @Param       id path string true "Job ID"
+// @Param       reason body controller.blockParams true "Block params"
+// @Param       id     path string                 true "Job ID"
 // @Success     200
 // @Failure     401 {object} model.BackendError "user not authorized"
 // @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "job not found or already blocked"
+// @Failure     422 {object} model.BackendError "reason is required"
 // @Failure     500 {object} echo.HTTPError{message=string}
 // @Security    BearerToken
 // @Router      /jobs/{id}/block [post]
@@ -202,7 +400,49 @@ func (cont *Job) block(c echo.Context) error {
 		return err
 	}
 
-	if e := cont.svc.Block(c.Request().Context(), c.Param("id"), uc.Subject.ID); e != nil {
+	ie := new(blockParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.Block(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Unblock a job
+// @Description Unblocks previously blocked job. To execute this action, user must have admin privileges.
+// @Description The reason is mandatory, it is kept in the audit log.
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Param       reason body controller.blockParams true "Unblock params"
+// @Param       id     path string                 true "Job ID"
+// @Success     200
+// @Failure     400 {object} model.BackendError "job is not blocked"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "job not found"
+// @Failure     422 {object} model.BackendError "reason is required"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{id}/unblock [post]
+func (cont *Job) unblock(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(blockParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.Unblock(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason); e != nil {
 		return e
 	}
 
@@ -258,3 +498,88 @@ func (cont *Job) resume(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, json.RawMessage("{}"))
 }
+
+type reopenJobParams struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// @Summary     Reopen a job
+// @Description Reopens closed or expired job to continue receiving applications. New expiration moment may be specified, otherwise the job does not expire.
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Param       job body     controller.reopenJobParams false "Reopen params"
+// @Param       id  path     string                     true  "Job ID"
+// @Success     200 {object} model.JobCardDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an owner"
+// @Failure     404 {object} model.BackendError "job not found"
+// @Failure     422 {object} model.BackendError "job is not closed or expired"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{id}/reopen [post]
+func (cont *Job) reopen(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(reopenJobParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	dto := model.ReopenJobDTO{
+		ExpiresAt: ie.ExpiresAt,
+	}
+
+	o, err := cont.svc.Reopen(c.Request().Context(), c.Param("id"), uc.Subject.ID, &dto)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+type publishJobParams struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// @Summary     Publish a draft
+// @Description Publishes the draft immediately or schedules its publication, if publish_at is in the future
+// @Tags        job
+// @Accept      json
+// @Produce     json
+// @Param       job body     controller.publishJobParams false "Publish params"
+// @Param       id  path     string                      true  "Job ID"
+// @Success     200 {object} model.JobCardDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "job not found"
+// @Failure     422 {object} model.BackendError "job is already published"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /jobs/{id}/publish [post]
+func (cont *Job) publish(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(publishJobParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	dto := model.PublishJobDTO{
+		PublishAt: ie.PublishAt,
+	}
+
+	o, err := cont.svc.Publish(c.Request().Context(), c.Param("id"), uc.Subject.ID, &dto)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"optrispace.com/work/pkg/model"
+)
+
+const (
+	feedTitle = "OptriSpace jobs"
+	feedSize  = 50 // number of the last updated jobs in a feed
+
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+type (
+	// jobFeed is a source of a feed in any format
+	jobFeed struct {
+		selfURL string
+		baseURL string
+		updated time.Time
+		jobs    []*model.JobDTO
+	}
+
+	atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Link    atomLink    `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Rel  string `xml:"rel,attr,omitempty"`
+		Href string `xml:"href,attr"`
+	}
+
+	atomEntry struct {
+		ID        string     `xml:"id"`
+		Title     string     `xml:"title"`
+		Updated   string     `xml:"updated"`
+		Published string     `xml:"published"`
+		Author    atomAuthor `xml:"author"`
+		Link      atomLink   `xml:"link"`
+		Content   atomText   `xml:"content"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomText struct {
+		Type string `xml:"type,attr"`
+		Text string `xml:",chardata"`
+	}
+
+	rssFeed struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string  `xml:"title"`
+		Link        string  `xml:"link"`
+		GUID        rssGUID `xml:"guid"`
+		Description string  `xml:"description"`
+		PubDate     string  `xml:"pubDate"`
+	}
+
+	rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+
+	jsonFeed struct {
+		Version     string         `json:"version"`
+		Title       string         `json:"title"`
+		HomePageURL string         `json:"home_page_url"`
+		FeedURL     string         `json:"feed_url"`
+		Items       []jsonFeedItem `json:"items"`
+	}
+
+	jsonFeedItem struct {
+		ID            string           `json:"id"`
+		URL           string           `json:"url"`
+		Title         string           `json:"title"`
+		ContentText   string           `json:"content_text"`
+		DatePublished time.Time        `json:"date_published"`
+		DateModified  time.Time        `json:"date_modified"`
+		Authors       []jsonFeedAuthor `json:"authors"`
+	}
+
+	jsonFeedAuthor struct {
+		Name string `json:"name"`
+	}
+)
+
+// @Summary     Atom feed of jobs
+// @Description Returns the last updated open public jobs as an Atom feed. It supports the same filter as the job list
+// @Description and conditional requests with If-None-Match and If-Modified-Since headers.
+// @Tags        job
+// @Produce     application/atom+xml
+// @Param       keyword    query string false "Text which the job title or description has to contain"
+// @Param       budget_min query string false "Minimal job budget"
+// @Param       budget_max query string false "Maximal job budget"
+// @Success     200
+// @Success     304
+// @Failure     422 {object} model.BackendError "validation failed"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Router      /jobs/feed.atom [get]
+func (cont *Job) feedAtom(c echo.Context) error {
+	return cont.feed(c, func(f *jobFeed) error {
+		o := atomFeed{
+			ID:      f.selfURL,
+			Title:   feedTitle,
+			Updated: f.updatedOrNow().Format(time.RFC3339),
+			Link:    atomLink{Rel: "self", Href: f.selfURL},
+			Entries: make([]atomEntry, 0, len(f.jobs)),
+		}
+
+		for _, j := range f.jobs {
+			o.Entries = append(o.Entries, atomEntry{
+				ID:        f.jobURL(j),
+				Title:     j.Title,
+				Updated:   j.UpdatedAt.UTC().Format(time.RFC3339),
+				Published: j.CreatedAt.UTC().Format(time.RFC3339),
+				Author:    atomAuthor{Name: j.CustomerDisplayName},
+				Link:      atomLink{Href: f.jobURL(j)},
+				Content:   atomText{Type: "text", Text: jobFeedText(j)},
+			})
+		}
+
+		return writeXML(c, "application/atom+xml; charset=UTF-8", o)
+	})
+}
+
+// @Summary     RSS feed of jobs
+// @Description Returns the last updated open public jobs as an RSS 2.0 feed. It supports the same filter as the job list
+// @Description and conditional requests with If-None-Match and If-Modified-Since headers.
+// @Tags        job
+// @Produce     application/rss+xml
+// @Param       keyword    query string false "Text which the job title or description has to contain"
+// @Param       budget_min query string false "Minimal job budget"
+// @Param       budget_max query string false "Maximal job budget"
+// @Success     200
+// @Success     304
+// @Failure     422 {object} model.BackendError "validation failed"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Router      /jobs/feed.rss [get]
+func (cont *Job) feedRSS(c echo.Context) error {
+	return cont.feed(c, func(f *jobFeed) error {
+		o := rssFeed{
+			Version: "2.0",
+			Channel: rssChannel{
+				Title:       feedTitle,
+				Link:        f.selfURL,
+				Description: "Open public jobs",
+				Items:       make([]rssItem, 0, len(f.jobs)),
+			},
+		}
+
+		if !f.updated.IsZero() {
+			o.Channel.LastBuildDate = f.updated.UTC().Format(time.RFC1123Z)
+		}
+
+		for _, j := range f.jobs {
+			o.Channel.Items = append(o.Channel.Items, rssItem{
+				Title:       j.Title,
+				Link:        f.jobURL(j),
+				GUID:        rssGUID{IsPermaLink: true, Value: f.jobURL(j)},
+				Description: jobFeedText(j),
+				PubDate:     j.CreatedAt.UTC().Format(time.RFC1123Z),
+			})
+		}
+
+		return writeXML(c, "application/rss+xml; charset=UTF-8", o)
+	})
+}
+
+// @Summary     JSON Feed of jobs
+// @Description Returns the last updated open public jobs as a JSON Feed 1.1. It supports the same filter as the job list
+// @Description and conditional requests with If-None-Match and If-Modified-Since headers.
+// @Tags        job
+// @Produce     application/feed+json
+// @Param       keyword    query string false "Text which the job title or description has to contain"
+// @Param       budget_min query string false "Minimal job budget"
+// @Param       budget_max query string false "Maximal job budget"
+// @Success     200
+// @Success     304
+// @Failure     422 {object} model.BackendError "validation failed"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Router      /jobs/feed.json [get]
+func (cont *Job) feedJSON(c echo.Context) error {
+	return cont.feed(c, func(f *jobFeed) error {
+		o := jsonFeed{
+			Version:     "https://jsonfeed.org/version/1.1",
+			Title:       feedTitle,
+			HomePageURL: f.baseURL,
+			FeedURL:     f.selfURL,
+			Items:       make([]jsonFeedItem, 0, len(f.jobs)),
+		}
+
+		for _, j := range f.jobs {
+			o.Items = append(o.Items, jsonFeedItem{
+				ID:            j.ID,
+				URL:           f.jobURL(j),
+				Title:         j.Title,
+				ContentText:   jobFeedText(j),
+				DatePublished: j.CreatedAt,
+				DateModified:  j.UpdatedAt,
+				Authors:       []jsonFeedAuthor{{Name: j.CustomerDisplayName}},
+			})
+		}
+
+		// JSON renderer keeps the content type if it is set
+		c.Response().Header().Set(echo.HeaderContentType, "application/feed+json; charset=UTF-8")
+		return c.JSON(http.StatusOK, o)
+	})
+}
+
+// feed loads jobs for the feed and calls write unless the client has the actual version of the feed
+func (cont *Job) feed(c echo.Context, write func(f *jobFeed) error) error {
+	filter, err := jobFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	filter.Limit = feedSize
+
+	oo, err := cont.svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	f := &jobFeed{
+		baseURL: c.Scheme() + "://" + c.Request().Host,
+		jobs:    oo,
+	}
+	f.selfURL = f.baseURL + c.Request().RequestURI
+
+	for _, o := range oo {
+		if o.UpdatedAt.After(f.updated) {
+			f.updated = o.UpdatedAt
+		}
+	}
+
+	// count of jobs is a part of the tag because a job leaves the feed without updating, when it is closed for example
+	etag := fmt.Sprintf(`W/"%x-%d"`, f.updated.UnixNano(), len(oo))
+
+	h := c.Response().Header()
+	h.Set(headerETag, etag)
+	if !f.updated.IsZero() {
+		h.Set(echo.HeaderLastModified, f.updated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request(), etag, f.updated) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return write(f)
+}
+
+// notModified checks conditional request headers. If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, updated time.Time) bool {
+	if inm := r.Header.Get(headerIfNoneMatch); inm != "" {
+		for _, t := range strings.Split(inm, ",") {
+			t = strings.TrimSpace(t)
+			if t == "*" || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if updated.IsZero() {
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	// HTTP dates have one second precision
+	return !updated.Truncate(time.Second).After(ims)
+}
+
+func (f *jobFeed) jobURL(j *model.JobDTO) string {
+	return f.baseURL + "/" + resourceJob + "/" + j.ID
+}
+
+func (f *jobFeed) updatedOrNow() time.Time {
+	if f.updated.IsZero() {
+		return time.Now().UTC()
+	}
+
+	return f.updated.UTC()
+}
+
+// jobFeedText returns a plain text description of the job
+func jobFeedText(j *model.JobDTO) string {
+	if j.Budget.IsZero() {
+		return j.Description
+	}
+
+	return j.Description + "\n\nBudget: " + j.Budget.String()
+}
+
+func writeXML(c echo.Context, contentType string, o any) error {
+	b, err := xml.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal feed: %w", err)
+	}
+
+	return c.Blob(http.StatusOK, contentType, append([]byte(xml.Header), b...))
+}
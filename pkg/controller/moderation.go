@@ -0,0 +1,396 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// Moderation controller
+	Moderation struct {
+		sm  service.Security
+		svc service.Moderation
+	}
+)
+
+// NewModeration create new service
+func NewModeration(sm service.Security, svc service.Moderation) Registerer {
+	return &Moderation{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *Moderation) Register(e *echo.Echo) {
+	e.POST(resourceReport, cont.addReport)
+	e.GET(resourceAdmin+"/"+resourceReport, cont.listReports)
+	e.GET(resourceAdmin+"/"+resourceReport+"/:id", cont.getReport)
+	e.POST(resourceAdmin+"/"+resourceReport+"/:id/resolve", cont.resolveReport)
+	e.POST(resourceAdmin+"/"+resourceReport+"/:id/dismiss", cont.dismissReport)
+	e.POST(resourceAdmin+"/"+resourcePerson+"/:id/block", cont.blockPerson)
+	e.POST(resourceAdmin+"/"+resourcePerson+"/:id/unblock", cont.unblockPerson)
+	e.GET(resourceAdmin+"/messages/:id/revisions", cont.listMessageRevisions)
+	e.POST(resourceAdmin+"/"+resourceContract+"/:id/chat/join", cont.joinContractChat)
+	e.GET(resourceAdmin+"/audit", cont.listAudit)
+	log.Debug().Str("controller", resourceReport).Msg("Registered")
+}
+
+type createReportParams struct {
+	TargetKind string `json:"target_kind" validate:"required" enums:"job,person,message"`
+	TargetID   string `json:"target_id" validate:"required"`
+	Reason     string `json:"reason" validate:"required" enums:"spam,fraud,abuse,other"`
+	Comment    string `json:"comment"`
+}
+
+// @Summary     Report spam or abuse
+// @Description Reports a job, a person or a chat message to admins. Comment is required for the "other" reason.
+// @Tags        report
+// @Accept      json
+// @Produce     json
+// @Param       report body     controller.createReportParams true "Report params"
+// @Success     201    {object} model.ReportDTO
+// @Failure     400    {object} model.BackendError "user reports own entity"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     404    {object} model.BackendError "reported entity not found"
+// @Failure     409    {object} model.BackendError "it is already reported"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /reports [post]
+func (cont *Moderation) addReport(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(createReportParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	dto := model.CreateReportDTO{
+		TargetKind: ie.TargetKind,
+		TargetID:   ie.TargetID,
+		Reason:     ie.Reason,
+		Comment:    ie.Comment,
+	}
+
+	o, err := cont.svc.AddReport(c.Request().Context(), uc.Subject.ID, &dto)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, path.Join("/", resourceAdmin, resourceReport, o.ID))
+	return c.JSON(http.StatusCreated, o)
+}
+
+// @Summary     List reports
+// @Description Returns the moderation queue. Open reports go first, the oldest first. To execute this action, user must have admin privileges.
+// @Tags        report, admin
+// @Accept      json
+// @Produce     json
+// @Param       status query    string false "Report status" Enums(open, resolved, dismissed)
+// @Success     200    {array}  model.ReportDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     422    {object} model.BackendError "invalid status"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/reports [get]
+func (cont *Moderation) listReports(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListReports(c.Request().Context(), uc.Subject.ID, c.QueryParam("status"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Get a report
+// @Description Returns a report by ID. To execute this action, user must have admin privileges.
+// @Tags        report, admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Report ID"
+// @Success     200 {object} model.ReportDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "report not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/reports/{id} [get]
+func (cont *Moderation) getReport(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.GetReport(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+type resolveReportParams struct {
+	Resolution string `json:"resolution" validate:"required"`
+	Block      bool   `json:"block"`
+}
+
+// @Summary     Resolve a report
+// @Description Resolves the open report. If block is true, the reported job or person is blocked, the author is blocked for the reported message.
+// @Description All other open reports about the blocked entity are resolved as well. To execute this action, user must have admin privileges.
+// @Tags        report, admin
+// @Accept      json
+// @Produce     json
+// @Param       resolution body     controller.resolveReportParams true "Resolution params"
+// @Param       id         path     string                         true "Report ID"
+// @Success     200        {object} model.ReportDTO
+// @Failure     400        {object} model.BackendError "report is already resolved"
+// @Failure     401        {object} model.BackendError "user not authorized"
+// @Failure     403        {object} model.BackendError "user is not admin"
+// @Failure     404        {object} model.BackendError "report not found"
+// @Failure     422        {object} model.BackendError "resolution is required"
+// @Failure     500        {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/reports/{id}/resolve [post]
+func (cont *Moderation) resolveReport(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(resolveReportParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	dto := model.ResolveReportDTO{
+		Resolution: ie.Resolution,
+		Block:      ie.Block,
+	}
+
+	o, err := cont.svc.ResolveReport(c.Request().Context(), c.Param("id"), uc.Subject.ID, &dto)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+type dismissReportParams struct {
+	Resolution string `json:"resolution"`
+}
+
+// @Summary     Dismiss a report
+// @Description Dismisses the open report without any action. To execute this action, user must have admin privileges.
+// @Tags        report, admin
+// @Accept      json
+// @Produce     json
+// @Param       resolution body     controller.dismissReportParams false "Dismiss params"
+// @Param       id         path     string                         true  "Report ID"
+// @Success     200        {object} model.ReportDTO
+// @Failure     400        {object} model.BackendError "report is already resolved"
+// @Failure     401        {object} model.BackendError "user not authorized"
+// @Failure     403        {object} model.BackendError "user is not admin"
+// @Failure     404        {object} model.BackendError "report not found"
+// @Failure     500        {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/reports/{id}/dismiss [post]
+func (cont *Moderation) dismissReport(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(dismissReportParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.DismissReport(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Resolution)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Block a person
+// @Description Blocks the person. Blocked person is unable to log in and to use the access token.
+// @Description The reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.
+// @Tags        admin, person
+// @Accept      json
+// @Produce     json
+// @Param       reason body controller.blockParams true "Block params"
+// @Param       id     path string                 true "Person ID"
+// @Success     200
+// @Failure     400 {object} model.BackendError "person is already blocked"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     422 {object} model.BackendError "reason is required"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/block [post]
+func (cont *Moderation) blockPerson(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(blockParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.BlockPerson(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Unblock a person
+// @Description Unblocks previously blocked person. The reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.
+// @Tags        admin, person
+// @Accept      json
+// @Produce     json
+// @Param       reason body controller.blockParams true "Unblock params"
+// @Param       id     path string                 true "Person ID"
+// @Success     200
+// @Failure     400 {object} model.BackendError "person is not blocked"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     422 {object} model.BackendError "reason is required"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/persons/{id}/unblock [post]
+func (cont *Moderation) unblockPerson(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(blockParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	if e := cont.svc.UnblockPerson(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     List previous versions of the message
+// @Description Returns previous texts of the edited or deleted chat message from the newest to the oldest.
+// @Description The access is audited. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "message ID"
+// @Success     200 {array}  model.MessageRevisionDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not admin"
+// @Failure     404 {object} model.BackendError "message not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/messages/{id}/revisions [get]
+func (cont *Moderation) listMessageRevisions(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListMessageRevisions(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Join the chat of the contract
+// @Description Adds the admin to participants of the contract chat to settle a dispute between the customer and the performer.
+// @Description The reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.
+// @Tags        admin, contract
+// @Accept      json
+// @Produce     json
+// @Param       reason body     controller.blockParams true "Join params"
+// @Param       id     path     string                 true "Contract ID"
+// @Success     200    {object} model.Chat
+// @Failure     400    {object} model.BackendError "admin is already a participant"
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not admin"
+// @Failure     404    {object} model.BackendError "contract not found"
+// @Failure     422    {object} model.BackendError "reason is required"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/contracts/{id}/chat/join [post]
+func (cont *Moderation) joinContractChat(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(blockParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.JoinContractChat(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     List audit log
+// @Description Returns records of privileged actions from the newest to the oldest. To execute this action, user must have admin privileges.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       target_kind query    string false "Kind of the affected entity" Enums(job, person, message, contract)
+// @Param       target_id   query    string false "ID of the affected entity"
+// @Success     200         {array}  model.AuditRecordDTO
+// @Failure     401         {object} model.BackendError "user not authorized"
+// @Failure     403         {object} model.BackendError "user is not admin"
+// @Failure     500         {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /admin/audit [get]
+func (cont *Moderation) listAudit(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListAudit(c.Request().Context(), uc.Subject.ID, c.QueryParam("target_kind"), c.QueryParam("target_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// PersonBlock controller for persons blocked by the current user
+	PersonBlock struct {
+		sm  service.Security
+		svc service.PersonBlock
+	}
+)
+
+// NewPersonBlock create new service
+func NewPersonBlock(sm service.Security, svc service.PersonBlock) Registerer {
+	return &PersonBlock{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *PersonBlock) Register(e *echo.Echo) {
+	e.GET("/me/blocked", cont.list)
+	e.PUT("/me/blocked/:person_id", cont.block)
+	e.DELETE("/me/blocked/:person_id", cont.unblock)
+	log.Debug().Str("controller", "blocked").Msg("Registered")
+}
+
+// @Summary     List blocked persons
+// @Description Returns persons blocked by the current user from the last blocked
+// @Tags        auth, person
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.BlockedPersonDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/blocked [get]
+func (cont *PersonBlock) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Block a person
+// @Description Blocks the person for the current user. Blocked person is unable to post into shared chats,
+// @Description to apply to jobs of the current user, and the current user is unable to offer contracts to the blocked person.
+// @Description Blocking of the already blocked person has no effect.
+// @Tags        auth, person
+// @Accept      json
+// @Produce     json
+// @Param       person_id path string true "Person ID"
+// @Success     200
+// @Failure     400 {object} model.BackendError "unable to block yourself"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "person not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/blocked/{person_id} [put]
+func (cont *PersonBlock) block(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Block(c.Request().Context(), c.Param("person_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Unblock a person
+// @Description Removes the person from persons blocked by the current user
+// @Tags        auth, person
+// @Accept      json
+// @Produce     json
+// @Param       person_id path string true "Person ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "person is not blocked"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/blocked/{person_id} [delete]
+func (cont *PersonBlock) unblock(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Unblock(c.Request().Context(), c.Param("person_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// PersonNotification controller for in-app notifications of the current user
+	PersonNotification struct {
+		sm  service.Security
+		svc service.PersonNotification
+	}
+)
+
+// NewPersonNotification create new service
+func NewPersonNotification(sm service.Security, svc service.PersonNotification) Registerer {
+	return &PersonNotification{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *PersonNotification) Register(e *echo.Echo) {
+	e.GET("/me/notifications", cont.list)
+	e.POST("/me/notifications/:id/read", cont.markRead)
+	log.Debug().Str("controller", "person-notifications").Msg("Registered")
+}
+
+// @Summary     List notifications of the current user
+// @Description Returns in-app notifications addressed to the current user from the newest to the oldest
+// @Tags        auth, notification
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.PersonNotification
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/notifications [get]
+func (cont *PersonNotification) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.ListByPerson(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Mark notification as read
+// @Description Marks the notification of the current user as read
+// @Tags        auth, notification
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Notification ID"
+// @Success     200 {object} model.PersonNotification
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "notification not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/notifications/{id}/read [post]
+func (cont *PersonNotification) markRead(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.MarkRead(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// SavedJob controller for jobs bookmarked by the current user
+	SavedJob struct {
+		sm  service.Security
+		svc service.SavedJob
+	}
+)
+
+// NewSavedJob create new service
+func NewSavedJob(sm service.Security, svc service.SavedJob) Registerer {
+	return &SavedJob{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *SavedJob) Register(e *echo.Echo) {
+	e.GET("/me/saved-jobs", cont.list)
+	e.PUT("/me/saved-jobs/:job_id", cont.save)
+	e.DELETE("/me/saved-jobs/:job_id", cont.unsave)
+	log.Debug().Str("controller", "saved-jobs").Msg("Registered")
+}
+
+// @Summary     List saved jobs
+// @Description Returns jobs saved by the current user from the last saved
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.JobCardDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-jobs [get]
+func (cont *SavedJob) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Save a job
+// @Description Bookmarks the job for the current user. Saving of the already saved job has no effect.
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       job_id path string true "Job ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "job not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-jobs/{job_id} [put]
+func (cont *SavedJob) save(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Save(c.Request().Context(), c.Param("job_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Unsave a job
+// @Description Removes the job from bookmarks of the current user
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       job_id path string true "Job ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "job is not saved"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-jobs/{job_id} [delete]
+func (cont *SavedJob) unsave(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Unsave(c.Request().Context(), c.Param("job_id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
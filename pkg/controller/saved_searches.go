@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// SavedSearch controller for search criteria saved by the current user
+	SavedSearch struct {
+		sm  service.Security
+		svc service.SavedSearch
+	}
+)
+
+// NewSavedSearch create new service
+func NewSavedSearch(sm service.Security, svc service.SavedSearch) Registerer {
+	return &SavedSearch{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+const savedSearchesPath = "/me/saved-searches"
+
+// Register implements Registerer interface
+func (cont *SavedSearch) Register(e *echo.Echo) {
+	e.GET(savedSearchesPath, cont.list)
+	e.POST(savedSearchesPath, cont.add)
+	e.GET(savedSearchesPath+"/:id", cont.get)
+	e.PUT(savedSearchesPath+"/:id", cont.update)
+	e.DELETE(savedSearchesPath+"/:id", cont.delete)
+	log.Debug().Str("controller", "saved-searches").Msg("Registered")
+}
+
+type savedSearchParams struct {
+	Title     string          `json:"title" validate:"required"`
+	Keyword   string          `json:"keyword"`
+	BudgetMin decimal.Decimal `json:"budget_min"`
+	BudgetMax decimal.Decimal `json:"budget_max"`
+}
+
+func (p *savedSearchParams) toDTO() *model.SavedSearchParamsDTO {
+	return &model.SavedSearchParamsDTO{
+		Title:     p.Title,
+		Keyword:   p.Keyword,
+		BudgetMin: p.BudgetMin,
+		BudgetMax: p.BudgetMax,
+	}
+}
+
+// @Summary     List saved searches
+// @Description Returns search criteria saved by the current user
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.SavedSearchDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-searches [get]
+func (cont *SavedSearch) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     Save a search
+// @Description Saves search criteria. The user gets alerts about new jobs which contain the keyword in the title or description
+// @Description and which budget is in the range. Zero budget limit means no limit.
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       search body     controller.savedSearchParams true "Search criteria"
+// @Success     201    {object} model.SavedSearchDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-searches [post]
+func (cont *SavedSearch) add(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(savedSearchParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.Add(c.Request().Context(), uc.Subject.ID, ie.toDTO())
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, path.Join(savedSearchesPath, o.ID))
+	return c.JSON(http.StatusCreated, o)
+}
+
+// @Summary     Get a saved search
+// @Description Returns the search criteria saved by the current user
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "Saved search ID"
+// @Success     200 {object} model.SavedSearchDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an owner of the search"
+// @Failure     404 {object} model.BackendError "saved search not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-searches/{id} [get]
+func (cont *SavedSearch) get(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	o, err := cont.svc.Get(c.Request().Context(), c.Param("id"), uc.Subject.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Update a saved search
+// @Description Replaces criteria of the search saved by the current user
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       search body     controller.savedSearchParams true "Search criteria"
+// @Param       id     path     string                       true "Saved search ID"
+// @Success     200    {object} model.SavedSearchDTO
+// @Failure     401    {object} model.BackendError "user not authorized"
+// @Failure     403    {object} model.BackendError "user is not an owner of the search"
+// @Failure     404    {object} model.BackendError "saved search not found"
+// @Failure     422    {object} model.BackendError "validation failed"
+// @Failure     500    {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-searches/{id} [put]
+func (cont *SavedSearch) update(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	ie := new(savedSearchParams)
+
+	if e := c.Bind(ie); e != nil {
+		return e
+	}
+
+	o, err := cont.svc.Update(c.Request().Context(), c.Param("id"), uc.Subject.ID, ie.toDTO())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// @Summary     Delete a saved search
+// @Description Deletes the search saved by the current user, the user does not get alerts about it anymore
+// @Tags        auth, job
+// @Accept      json
+// @Produce     json
+// @Param       id path string true "Saved search ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     403 {object} model.BackendError "user is not an owner of the search"
+// @Failure     404 {object} model.BackendError "saved search not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/saved-searches/{id} [delete]
+func (cont *SavedSearch) delete(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Delete(c.Request().Context(), c.Param("id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service"
+)
+
+type (
+	// Session controller for logged in sessions of the current user
+	Session struct {
+		sm  service.Security
+		svc service.Session
+	}
+)
+
+// NewSession create new service
+func NewSession(sm service.Security, svc service.Session) Registerer {
+	return &Session{
+		sm:  sm,
+		svc: svc,
+	}
+}
+
+// Register implements Registerer interface
+func (cont *Session) Register(e *echo.Echo) {
+	e.GET("/me/sessions", cont.list)
+	e.DELETE("/me/sessions/:id", cont.delete)
+	e.POST("/logout", cont.logout)
+	log.Debug().Str("controller", "sessions").Msg("Registered")
+}
+
+// @Summary     List sessions
+// @Description Returns logged in sessions of the current user from the recently used.
+// @Description The session of the request is marked as current. Sessions expire after 30 days without use.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Success     200 {array}  model.SessionDTO
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/sessions [get]
+func (cont *Session) list(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	oo, err := cont.svc.List(c.Request().Context(), uc.Subject.ID, uc.SessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, oo)
+}
+
+// @Summary     End a session
+// @Description Ends the session of the current user, for example on the lost device. The token of the session is not valid anymore.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       id path string true "Session ID"
+// @Success     200
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     404 {object} model.BackendError "session not found"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /me/sessions/{id} [delete]
+func (cont *Session) delete(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if e := cont.svc.Delete(c.Request().Context(), c.Param("id"), uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
+
+// @Summary     Logout
+// @Description Ends the session of the request, other sessions of the current user stay alive
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Success     200
+// @Failure     400 {object} model.BackendError "impersonation is not a session"
+// @Failure     401 {object} model.BackendError "user not authorized"
+// @Failure     500 {object} echo.HTTPError{message=string}
+// @Security    BearerToken
+// @Router      /logout [post]
+func (cont *Session) logout(c echo.Context) error {
+	uc, err := cont.sm.FromEchoContext(c)
+	if err != nil {
+		return err
+	}
+
+	if uc.SessionID == "" {
+		return fmt.Errorf("%w: impersonation is not a session", model.ErrInappropriateAction)
+	}
+
+	if e := cont.svc.Delete(c.Request().Context(), uc.SessionID, uc.Subject.ID); e != nil {
+		return e
+	}
+
+	return c.JSON(http.StatusOK, json.RawMessage("{}"))
+}
@@ -0,0 +1,17 @@
+drop index person_notifications_person_id;
+
+drop table person_notifications;
+
+drop index job_invitations_job_id_person_id;
+
+drop table job_invitations;
+
+delete from messages where chat_id in (select id from chats where starts_with(topic, 'urn:invitation:'));
+
+delete from chats where starts_with(topic, 'urn:invitation:');
+
+alter table chats
+drop constraint chats_topic_check;
+
+alter table chats
+add constraint chats_topic_check check (starts_with(topic, 'urn:application:') or starts_with(topic, 'urn:contract:'));
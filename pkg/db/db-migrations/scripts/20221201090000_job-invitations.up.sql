@@ -0,0 +1,53 @@
+alter table chats
+drop constraint chats_topic_check;
+
+alter table chats
+add constraint chats_topic_check check (starts_with(topic, 'urn:application:') or starts_with(topic, 'urn:contract:') or starts_with(topic, 'urn:invitation:'));
+
+create table job_invitations (
+    id varchar primary key not null
+    , job_id varchar not null references jobs(id)
+    , person_id varchar not null references persons(id)
+    , created_by varchar not null references persons(id)
+    , created_at timestamp not null default now()
+    , "message" text not null default ''
+    , status varchar(20) not null default 'pending' check (status in ('pending', 'accepted', 'declined'))
+    , responded_at timestamp null default null
+    , application_id varchar null references applications(id)
+);
+
+create unique index job_invitations_job_id_person_id on job_invitations (job_id, person_id);
+
+comment on table job_invitations is 'Invitations sent by customers to persons to apply for their jobs';
+
+comment on column job_invitations.id is 'PK';
+comment on column job_invitations.job_id is 'Job the person is invited to';
+comment on column job_invitations.person_id is 'Invited person';
+comment on column job_invitations.created_by is 'Customer who sent the invitation';
+comment on column job_invitations.created_at is 'Creation timestamp';
+comment on column job_invitations.message is 'Personal message from the customer';
+comment on column job_invitations.status is 'Invitation status: pending, accepted or declined';
+comment on column job_invitations.responded_at is 'When the invited person accepted or declined the invitation';
+comment on column job_invitations.application_id is 'Application created when the invitation was accepted';
+
+create table person_notifications (
+    id varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , kind varchar(50) not null
+    , "text" text not null
+    , link varchar not null default ''
+    , created_at timestamp not null default now()
+    , read_at timestamp null default null
+);
+
+create index person_notifications_person_id on person_notifications (person_id, created_at);
+
+comment on table person_notifications is 'In-app notifications addressed to a specific person';
+
+comment on column person_notifications.id is 'PK';
+comment on column person_notifications.person_id is 'Recipient';
+comment on column person_notifications.kind is 'Notification kind. Like job_invitation';
+comment on column person_notifications.text is 'Human readable notification text';
+comment on column person_notifications.link is 'URN of the entity the notification is about';
+comment on column person_notifications.created_at is 'Creation timestamp';
+comment on column person_notifications.read_at is 'When the recipient has read the notification';
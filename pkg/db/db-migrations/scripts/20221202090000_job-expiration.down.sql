@@ -0,0 +1,5 @@
+drop index jobs_expires_at;
+
+alter table jobs
+drop column closed_at,
+drop column expires_at;
@@ -0,0 +1,8 @@
+alter table jobs
+add column expires_at timestamp null default null,
+add column closed_at timestamp null default null;
+
+create index jobs_expires_at on jobs (expires_at) where expires_at is not null;
+
+comment on column jobs.expires_at is 'Moment after which the job stops accepting applications and becomes suspended.';
+comment on column jobs.closed_at is 'Moment when the job was closed. The job is closed automatically when a contract for it becomes funded.';
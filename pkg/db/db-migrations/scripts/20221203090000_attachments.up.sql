@@ -0,0 +1,27 @@
+create table attachments (
+    id varchar primary key not null
+    , created_by varchar not null references persons(id)
+    , created_at timestamp not null default now()
+    , "name" varchar not null
+    , content_type varchar not null
+    , size bigint not null
+    , storage_key varchar not null
+    , job_id varchar null references jobs(id)
+    , application_id varchar null references applications(id)
+    , check (job_id is null or application_id is null)
+);
+
+create index attachments_job_id on attachments (job_id) where job_id is not null;
+create index attachments_application_id on attachments (application_id) where application_id is not null;
+
+comment on table attachments is 'Files attached to jobs and applications';
+
+comment on column attachments.id is 'PK';
+comment on column attachments.created_by is 'Person who uploaded the file';
+comment on column attachments.created_at is 'Upload timestamp';
+comment on column attachments.name is 'Original file name';
+comment on column attachments.content_type is 'MIME type detected by the content';
+comment on column attachments.size is 'File size in bytes';
+comment on column attachments.storage_key is 'Key of the file content in the storage backend';
+comment on column attachments.job_id is 'Job the file is attached to';
+comment on column attachments.application_id is 'Application the file is attached to';
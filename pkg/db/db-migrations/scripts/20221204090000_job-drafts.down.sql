@@ -0,0 +1,5 @@
+drop index jobs_publish_at;
+
+alter table jobs
+drop column publish_at,
+drop column published_at;
@@ -0,0 +1,10 @@
+alter table jobs
+add column published_at timestamp null default now(),
+add column publish_at timestamp null default null;
+
+update jobs set published_at = created_at;
+
+create index jobs_publish_at on jobs (publish_at) where publish_at is not null;
+
+comment on column jobs.published_at is 'Moment when the job was published. Job is a draft until it is published.';
+comment on column jobs.publish_at is 'Moment when the draft should be published automatically.';
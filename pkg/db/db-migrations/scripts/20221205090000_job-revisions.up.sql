@@ -0,0 +1,31 @@
+create table job_revisions (
+    id varchar primary key not null
+    , job_id varchar not null references jobs(id) on delete cascade
+    , version int not null
+    , title varchar not null
+    , description text not null
+    , budget decimal null
+    , duration int null
+    , expires_at timestamp null
+    , created_by varchar not null references persons(id)
+    , created_at timestamp not null default now()
+    , unique (job_id, version)
+);
+
+comment on table job_revisions is 'History of job edits. Every revision is a snapshot of the job terms';
+
+comment on column job_revisions.id is 'PK';
+comment on column job_revisions.job_id is 'Job the revision belongs to';
+comment on column job_revisions.version is 'Sequential number of the revision within the job, starting from 1';
+comment on column job_revisions.title is 'Job title in this revision';
+comment on column job_revisions.description is 'Job description in this revision';
+comment on column job_revisions.budget is 'Job budget in this revision';
+comment on column job_revisions.duration is 'Job duration in this revision';
+comment on column job_revisions.expires_at is 'Job expiration timestamp in this revision';
+comment on column job_revisions.created_by is 'Person who made the revision';
+comment on column job_revisions.created_at is 'Revision timestamp';
+
+-- the current state of existing jobs becomes their first revision
+insert into job_revisions (id, job_id, version, title, description, budget, duration, expires_at, created_by, created_at)
+select j.id, j.id, 1, j.title, j.description, j.budget, j.duration, j.expires_at, j.created_by, j.updated_at
+from jobs j;
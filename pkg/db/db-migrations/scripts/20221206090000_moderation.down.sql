@@ -0,0 +1,6 @@
+drop table audit_log;
+
+drop table abuse_reports;
+
+alter table persons
+drop column blocked_at;
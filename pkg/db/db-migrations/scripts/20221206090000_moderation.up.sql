@@ -0,0 +1,57 @@
+alter table persons
+add column blocked_at timestamp null default null;
+
+comment on column persons.blocked_at is 'Person is blocked and unable to log in if this field is not null';
+
+create table abuse_reports (
+    id varchar primary key not null
+    , target_kind varchar not null check (target_kind in ('job', 'person', 'message'))
+    , target_id varchar not null
+    , reason varchar not null check (reason in ('spam', 'fraud', 'abuse', 'other'))
+    , "comment" text not null default ''
+    , created_by varchar not null references persons(id) on delete cascade
+    , created_at timestamp not null default now()
+    , status varchar not null default 'open' check (status in ('open', 'resolved', 'dismissed'))
+    , resolved_by varchar null references persons(id)
+    , resolved_at timestamp null
+    , resolution text not null default ''
+);
+
+create unique index abuse_reports_open_idx on abuse_reports (created_by, target_kind, target_id) where status = 'open';
+create index abuse_reports_status on abuse_reports (status, created_at);
+
+comment on table abuse_reports is 'Reports of users about spam and abuse, it is a moderation queue for admins';
+
+comment on column abuse_reports.id is 'PK';
+comment on column abuse_reports.target_kind is 'Kind of the reported entity: job, person or message';
+comment on column abuse_reports.target_id is 'ID of the reported entity';
+comment on column abuse_reports.reason is 'Reason of the report: spam, fraud, abuse or other';
+comment on column abuse_reports.comment is 'Details from the reporter';
+comment on column abuse_reports.created_by is 'Person who reported';
+comment on column abuse_reports.created_at is 'Creation timestamp';
+comment on column abuse_reports.status is 'Status of the report: open, resolved or dismissed';
+comment on column abuse_reports.resolved_by is 'Admin who reviewed the report';
+comment on column abuse_reports.resolved_at is 'Review timestamp';
+comment on column abuse_reports.resolution is 'Admin comment on the review';
+
+create table audit_log (
+    id varchar primary key not null
+    , actor_id varchar not null references persons(id)
+    , "action" varchar not null
+    , target_kind varchar not null
+    , target_id varchar not null
+    , reason text not null default ''
+    , created_at timestamp not null default now()
+);
+
+create index audit_log_target on audit_log (target_kind, target_id, created_at);
+
+comment on table audit_log is 'Record of privileged actions: who did what and why';
+
+comment on column audit_log.id is 'PK';
+comment on column audit_log.actor_id is 'Person who performed the action';
+comment on column audit_log.action is 'Action name like block or unblock';
+comment on column audit_log.target_kind is 'Kind of the affected entity: job, person etc.';
+comment on column audit_log.target_id is 'ID of the affected entity';
+comment on column audit_log.reason is 'Why the action was performed';
+comment on column audit_log.created_at is 'Action timestamp';
@@ -0,0 +1,19 @@
+create table impersonations (
+    id varchar primary key not null
+    , token varchar not null unique
+    , admin_id varchar not null references persons(id) on delete cascade
+    , person_id varchar not null references persons(id) on delete cascade
+    , reason text not null
+    , created_at timestamp not null default now()
+    , expires_at timestamp not null
+);
+
+comment on table impersonations is 'Temporary access tokens which let admins act as other persons for support';
+
+comment on column impersonations.id is 'PK';
+comment on column impersonations.token is 'Access token of the impersonation';
+comment on column impersonations.admin_id is 'Admin who impersonates';
+comment on column impersonations.person_id is 'Person who is impersonated';
+comment on column impersonations.reason is 'Why the admin needs to act as the person';
+comment on column impersonations.created_at is 'Creation timestamp';
+comment on column impersonations.expires_at is 'The token is not valid after this moment';
@@ -0,0 +1,5 @@
+alter table jobs drop column alerts_matched_at;
+
+drop table saved_searches;
+
+drop table saved_jobs;
@@ -0,0 +1,43 @@
+create table saved_jobs (
+    person_id varchar not null references persons(id) on delete cascade
+    , job_id varchar not null references jobs(id) on delete cascade
+    , created_at timestamp not null default now()
+    , primary key (person_id, job_id)
+);
+
+comment on table saved_jobs is 'Jobs bookmarked by persons';
+
+comment on column saved_jobs.person_id is 'Person who saved the job';
+comment on column saved_jobs.job_id is 'Saved job';
+comment on column saved_jobs.created_at is 'Creation timestamp';
+
+create table saved_searches (
+    id varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , title varchar not null
+    , keyword varchar not null default ''
+    , budget_min decimal
+    , budget_max decimal
+    , created_at timestamp not null default now()
+    , updated_at timestamp not null default now()
+);
+
+comment on table saved_searches is 'Search criteria saved by persons to get alerts about new jobs';
+
+comment on column saved_searches.id is 'PK';
+comment on column saved_searches.person_id is 'Owner of the search';
+comment on column saved_searches.title is 'Name of the search for the owner';
+comment on column saved_searches.keyword is 'Text which the job title or description has to contain, any job matches if empty';
+comment on column saved_searches.budget_min is 'Minimal job budget, not limited if null';
+comment on column saved_searches.budget_max is 'Maximal job budget, not limited if null';
+comment on column saved_searches.created_at is 'Creation timestamp';
+comment on column saved_searches.updated_at is 'Modification timestamp';
+
+create index saved_searches_person_id_idx on saved_searches(person_id);
+
+alter table jobs add column alerts_matched_at timestamp;
+
+comment on column jobs.alerts_matched_at is 'When the job was matched against saved searches, the job is waiting for matching if null';
+
+-- existing jobs are not new, so nobody gets alerts about them
+update jobs set alerts_matched_at = now();
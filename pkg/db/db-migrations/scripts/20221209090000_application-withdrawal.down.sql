@@ -0,0 +1,16 @@
+drop table application_revisions;
+
+-- withdrawn applications which are superseded by newer ones violate the former unique index
+create temporary table superseded_applications as
+select a.id from applications a
+where a.withdrawn_at is not null
+    and exists (select 1 from applications n where n.job_id = a.job_id and n.applicant_id = a.applicant_id and n.created_at > a.created_at);
+
+update job_invitations set application_id = null where application_id in (select id from superseded_applications);
+delete from attachments where application_id in (select id from superseded_applications);
+delete from applications where id in (select id from superseded_applications);
+
+drop index applications_job_id_applicant_id;
+create unique index applications_job_id_applicant_id on applications (job_id,applicant_id);
+
+alter table applications drop column withdrawn_at;
@@ -0,0 +1,31 @@
+alter table applications add column withdrawn_at timestamp null;
+
+comment on column applications.withdrawn_at is 'When the applicant withdrew the application. The applicant is able to apply again after withdrawal.';
+
+-- withdrawn applications do not prevent applying again
+drop index applications_job_id_applicant_id;
+create unique index applications_job_id_applicant_id on applications (job_id,applicant_id) where withdrawn_at is null;
+
+create table application_revisions (
+    id varchar primary key not null
+    , application_id varchar not null references applications(id) on delete cascade
+    , version int not null
+    , "comment" text not null
+    , price decimal not null
+    , created_at timestamp not null default now()
+    , unique (application_id, version)
+);
+
+comment on table application_revisions is 'History of application edits. Every revision is a snapshot of the application terms';
+
+comment on column application_revisions.id is 'PK';
+comment on column application_revisions.application_id is 'Application the revision belongs to';
+comment on column application_revisions.version is 'Sequential number of the revision within the application, starting from 1';
+comment on column application_revisions.comment is 'Applicant''s comment in this revision';
+comment on column application_revisions.price is 'Proposed price in this revision';
+comment on column application_revisions.created_at is 'Revision timestamp';
+
+-- the current state of existing applications becomes their first revision
+insert into application_revisions (id, application_id, version, "comment", price, created_at)
+select a.id, a.id, 1, a."comment", a.price, a.updated_at
+from applications a;
@@ -0,0 +1,9 @@
+drop index applications_job_id_status;
+
+alter table applications
+drop constraint applications_status_check;
+
+alter table applications
+drop column status_changed_at,
+drop column rejection_reason,
+drop column status;
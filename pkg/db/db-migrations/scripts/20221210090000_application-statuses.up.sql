@@ -0,0 +1,13 @@
+alter table applications
+add column status varchar not null default 'new',
+add column rejection_reason text not null default '',
+add column status_changed_at timestamp null;
+
+alter table applications
+add constraint applications_status_check check (status in ('new', 'shortlisted', 'interviewing', 'rejected', 'hired'));
+
+comment on column applications.status is 'Stage of the application in the pipeline of the customer: new, shortlisted, interviewing, rejected or hired';
+comment on column applications.rejection_reason is 'Optional reason explained to the applicant when the application is rejected';
+comment on column applications.status_changed_at is 'When the customer changed the status last time';
+
+create index applications_job_id_status on applications (job_id, status);
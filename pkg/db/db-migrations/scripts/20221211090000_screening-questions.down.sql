@@ -0,0 +1,3 @@
+drop table application_answers;
+
+drop table job_questions;
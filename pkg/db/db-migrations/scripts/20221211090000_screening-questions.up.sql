@@ -0,0 +1,39 @@
+create table job_questions (
+    id varchar primary key not null
+    , job_id varchar not null references jobs(id) on delete cascade
+    , "position" int not null
+    , kind varchar not null check (kind in ('text', 'single_choice', 'multi_choice'))
+    , "text" text not null
+    , "options" varchar[] not null default '{}'
+    , required boolean not null default false
+    , created_at timestamp not null default now()
+    , unique (job_id, "position")
+);
+
+comment on table job_questions is 'Screening questions which applicants answer when they apply for the job';
+
+comment on column job_questions.id is 'PK';
+comment on column job_questions.job_id is 'Job the question belongs to';
+comment on column job_questions."position" is 'Order of the question in the job starting from 1';
+comment on column job_questions.kind is 'Kind of the answer: text, single_choice or multi_choice';
+comment on column job_questions."text" is 'Text of the question';
+comment on column job_questions."options" is 'Options to choose from for single_choice and multi_choice questions';
+comment on column job_questions.required is 'Whether the applicant has to answer the question';
+comment on column job_questions.created_at is 'Creation timestamp';
+
+create table application_answers (
+    application_id varchar not null references applications(id) on delete cascade
+    , question_id varchar not null references job_questions(id) on delete cascade
+    , "text" text not null default ''
+    , "options" varchar[] not null default '{}'
+    , created_at timestamp not null default now()
+    , primary key (application_id, question_id)
+);
+
+comment on table application_answers is 'Answers of the applicant to screening questions of the job';
+
+comment on column application_answers.application_id is 'Application the answer belongs to';
+comment on column application_answers.question_id is 'Answered question';
+comment on column application_answers."text" is 'Answer to a text question';
+comment on column application_answers."options" is 'Chosen options of a single_choice or multi_choice question';
+comment on column application_answers.created_at is 'Creation timestamp';
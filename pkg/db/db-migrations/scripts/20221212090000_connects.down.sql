@@ -0,0 +1,6 @@
+alter table persons
+drop constraint persons_connects_check;
+
+alter table persons
+drop column connects_refilled_at,
+drop column connects;
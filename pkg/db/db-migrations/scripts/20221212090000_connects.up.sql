@@ -0,0 +1,9 @@
+alter table persons
+add column connects int not null default 0,
+add column connects_refilled_at timestamp null;
+
+alter table persons
+add constraint persons_connects_check check (connects >= 0);
+
+comment on column persons.connects is 'Balance of connects which are spent on applications';
+comment on column persons.connects_refilled_at is 'When the balance was topped up to the weekly allowance last time';
@@ -0,0 +1,3 @@
+drop trigger messages_notify_insert on messages;
+
+drop function notify_chat_message();
@@ -0,0 +1,16 @@
+create or replace function notify_chat_message() returns trigger as $$
+begin
+    perform pg_notify('chat_events', json_build_object(
+        'kind', 'message',
+        'chat_id', new.chat_id,
+        'message_id', new.id
+    )::text);
+    return new;
+end;
+$$ language plpgsql;
+
+comment on function notify_chat_message() is 'Notifies listeners of the chat_events channel about new messages, so every replica can push them to chat participants';
+
+create trigger messages_notify_insert
+after insert on messages
+for each row execute function notify_chat_message();
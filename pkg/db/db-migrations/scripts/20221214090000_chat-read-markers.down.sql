@@ -0,0 +1,2 @@
+alter table chats_participants
+drop column last_read_at;
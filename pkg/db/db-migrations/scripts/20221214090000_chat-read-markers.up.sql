@@ -0,0 +1,7 @@
+alter table chats_participants
+add column last_read_at timestamp null;
+
+comment on column chats_participants.last_read_at is 'Messages of other participants created before this moment are read by the participant';
+
+-- existing messages are not reported as unread
+update chats_participants set last_read_at = now();
@@ -0,0 +1 @@
+drop index messages_chat_id_created_at_idx;
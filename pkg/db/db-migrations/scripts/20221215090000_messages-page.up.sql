@@ -0,0 +1 @@
+create index messages_chat_id_created_at_idx on messages (chat_id, created_at, id);
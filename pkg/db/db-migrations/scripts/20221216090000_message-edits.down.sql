@@ -0,0 +1,13 @@
+-- deleted messages get their last text back
+update messages m set "text" = r."text"
+from message_revisions r
+where r.message_id = m.id
+    and m.deleted_at is not null
+    and r.version = (select max(l.version) from message_revisions l where l.message_id = m.id);
+
+drop table message_revisions;
+
+alter table messages
+drop column deleted_at
+, drop column edited_at
+, drop column "system";
@@ -0,0 +1,41 @@
+alter table messages
+add column "system" boolean not null default false
+, add column edited_at timestamp null
+, add column deleted_at timestamp null;
+
+comment on column messages.system is 'Message is generated by the platform, it can not be edited or deleted';
+comment on column messages.edited_at is 'When the author edited the message last time';
+comment on column messages.deleted_at is 'When the author deleted the message, the text of deleted message is kept in revisions only';
+
+-- contract status changes are posted on behalf of the actor to the chat of the application with exactly these texts
+update messages m set "system" = true
+from chats ch
+    join contracts c on ch.topic = 'urn:application:' || c.application_id
+where m.chat_id = ch.id
+    and m.created_by in (c.customer_id, c.performer_id)
+    and m."text" in (
+        'Contract has been created'
+        , 'Contract has been accepted'
+        , 'Contract has been deployed'
+        , 'Contract has been signed'
+        , 'Contract has been funded'
+        , 'Contract has been approved'
+        , 'Contract has been completed'
+    );
+
+create table message_revisions (
+    id varchar primary key not null
+    , message_id varchar not null references messages(id) on delete cascade
+    , version int not null
+    , "text" text not null
+    , created_at timestamp not null default now()
+    , unique (message_id, version)
+);
+
+comment on table message_revisions is 'Previous versions of edited and deleted messages kept for moderation';
+
+comment on column message_revisions.id is 'PK';
+comment on column message_revisions.message_id is 'Message the revision belongs to';
+comment on column message_revisions.version is 'Sequential number of the revision within the message, starting from 1';
+comment on column message_revisions.text is 'Text of the message before the change';
+comment on column message_revisions.created_at is 'When the text was replaced';
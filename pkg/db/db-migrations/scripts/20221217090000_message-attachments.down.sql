@@ -0,0 +1,10 @@
+delete from attachments where message_id is not null;
+
+alter table attachments drop constraint attachments_check;
+alter table attachments add constraint attachments_check check (job_id is null or application_id is null);
+
+alter table attachments
+drop column thumbnail_key
+, drop column message_id;
+
+comment on table attachments is 'Files attached to jobs and applications';
@@ -0,0 +1,13 @@
+alter table attachments
+add column message_id varchar null references messages(id)
+, add column thumbnail_key varchar null;
+
+alter table attachments drop constraint attachments_check;
+alter table attachments add constraint attachments_check check (num_nonnulls(job_id, application_id, message_id) <= 1);
+
+create index attachments_message_id on attachments (message_id) where message_id is not null;
+
+comment on table attachments is 'Files attached to jobs, applications and chat messages';
+
+comment on column attachments.message_id is 'Chat message the file is attached to';
+comment on column attachments.thumbnail_key is 'Key of the preview of the image in the storage backend, null if the file is not an image';
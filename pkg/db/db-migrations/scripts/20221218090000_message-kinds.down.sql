@@ -0,0 +1,9 @@
+alter table messages add column "system" boolean not null default false;
+
+comment on column messages.system is 'Message is generated by the platform, it can not be edited or deleted';
+
+update messages set "system" = true where kind = 'system';
+
+alter table messages
+drop column payload
+, drop column kind;
@@ -0,0 +1,20 @@
+alter table messages
+add column kind varchar not null default 'user' check (kind in ('user', 'system'))
+, add column payload jsonb not null default '{}'::jsonb;
+
+comment on column messages.kind is 'Kind of the message: user writes text, system describes an event with payload. System message can not be edited or deleted';
+comment on column messages.payload is 'Structured description of the event for system messages, like {"event":"contract_status_changed","contract_id":"...","old_status":"created","new_status":"accepted"}';
+
+update messages set kind = 'system' where "system";
+
+-- the previous status of existing messages is unknown
+update messages m set payload = jsonb_build_object(
+    'event', 'contract_status_changed',
+    'contract_id', c.id,
+    'new_status', substring(m."text" from '^Contract has been (.*)$')
+)
+from chats ch
+    join contracts c on ch.topic = 'urn:application:' || c.application_id
+where m.chat_id = ch.id and m.kind = 'system';
+
+alter table messages drop column "system";
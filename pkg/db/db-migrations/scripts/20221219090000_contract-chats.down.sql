@@ -0,0 +1,17 @@
+update messages m set chat_id = ach.id
+from chats ch
+    join contracts c on ch.topic = 'urn:contract:' || c.id
+    join chats ach on ach.topic = 'urn:application:' || c.application_id
+where m.chat_id = ch.id;
+
+-- messages of contracts without application chats are lost
+update attachments a set message_id = null
+from messages m
+    join chats ch on ch.id = m.chat_id
+where a.message_id = m.id and starts_with(ch.topic, 'urn:contract:');
+
+delete from messages m
+using chats ch
+where ch.id = m.chat_id and starts_with(ch.topic, 'urn:contract:');
+
+delete from chats where starts_with(topic, 'urn:contract:');
@@ -0,0 +1,19 @@
+-- every contract gets own chat of the customer and the performer
+insert into chats (id, topic)
+select c.id, 'urn:contract:' || c.id
+from contracts c
+where not exists (select 1 from chats ch where ch.topic = 'urn:contract:' || c.id);
+
+insert into chats_participants (chat_id, person_id, last_read_at)
+select ch.id, p.person_id, now()
+from chats ch
+    join contracts c on ch.topic = 'urn:contract:' || c.id
+    cross join lateral (values (c.customer_id), (c.performer_id)) p(person_id)
+on conflict do nothing;
+
+-- status messages are moved from application chats
+update messages m set chat_id = ch.id
+from chats ch
+where ch.topic = 'urn:contract:' || (m.payload->>'contract_id')
+    and m.kind = 'system'
+    and m.payload->>'event' = 'contract_status_changed';
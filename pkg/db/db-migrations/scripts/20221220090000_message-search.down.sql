@@ -0,0 +1 @@
+drop index messages_text_search;
@@ -0,0 +1,2 @@
+-- the expression must be the same as in MessagesSearch query to use the index
+create index messages_text_search on messages using gin (to_tsvector('english', "text"));
@@ -0,0 +1,5 @@
+drop table unsubscribe_tokens;
+
+alter table persons
+    drop column email_digest
+    , drop column email_digest_sent_at;
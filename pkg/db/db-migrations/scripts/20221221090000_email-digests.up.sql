@@ -0,0 +1,20 @@
+alter table persons
+    add column email_digest varchar not null default 'never' check (email_digest in ('never', 'hourly', 'daily', 'weekly'))
+    , add column email_digest_sent_at timestamp;
+
+comment on column persons.email_digest is 'How often the email digest of unread messages is sent: never, hourly, daily or weekly. Persons opt in to digests';
+comment on column persons.email_digest_sent_at is 'When the last email digest was sent, messages created before are not included into the next one';
+
+create table unsubscribe_tokens (
+    token_hash varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , created_at timestamp not null default now()
+);
+
+comment on table unsubscribe_tokens is 'Secrets of unsubscribe links in emails, every email has its own link';
+
+comment on column unsubscribe_tokens.token_hash is 'SHA-256 hex of the token, the token itself is not stored';
+comment on column unsubscribe_tokens.person_id is 'Person who receives the email';
+comment on column unsubscribe_tokens.created_at is 'When the email was sent, links of too old emails stop working';
+
+create index unsubscribe_tokens_person_id_idx on unsubscribe_tokens(person_id);
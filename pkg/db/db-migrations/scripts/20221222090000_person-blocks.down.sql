@@ -0,0 +1 @@
+drop table person_blocks;
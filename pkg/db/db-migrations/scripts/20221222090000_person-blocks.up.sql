@@ -0,0 +1,15 @@
+create table person_blocks (
+    person_id varchar not null references persons(id) on delete cascade
+    , blocked_id varchar not null references persons(id) on delete cascade
+    , created_at timestamp not null default now()
+    , primary key (person_id, blocked_id)
+    , check (person_id <> blocked_id)
+);
+
+comment on table person_blocks is 'Persons blocked by other persons, blocked person is unable to contact the blocking one';
+
+comment on column person_blocks.person_id is 'Person who blocked';
+comment on column person_blocks.blocked_id is 'Blocked person';
+comment on column person_blocks.created_at is 'Creation timestamp';
+
+create index person_blocks_blocked_id_idx on person_blocks(blocked_id);
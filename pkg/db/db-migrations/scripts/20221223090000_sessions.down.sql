@@ -0,0 +1,13 @@
+-- Tokens are not restorable from hashes, so everybody has to log in again
+alter table persons
+add column access_token varchar null unique;
+
+comment on column persons.access_token is 'Person''s personal access token for Bearer authentication schema';
+
+drop table sessions;
+
+delete from impersonations;
+
+alter table impersonations rename column token_hash to token;
+
+comment on column impersonations.token is 'Access token of the impersonation';
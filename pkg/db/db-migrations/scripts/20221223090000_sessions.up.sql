@@ -0,0 +1,38 @@
+create table sessions (
+    id varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , token_hash varchar not null unique
+    , created_at timestamp not null default now()
+    , last_used_at timestamp not null default now()
+    , expires_at timestamp not null
+    , user_agent varchar not null default ''
+    , ip varchar not null default ''
+);
+
+comment on table sessions is 'Logged in sessions of persons, a person may have many sessions on different devices';
+
+comment on column sessions.id is 'PK';
+comment on column sessions.person_id is 'Person who logged in';
+comment on column sessions.token_hash is 'SHA-256 hex of the access token, the token itself is not stored';
+comment on column sessions.created_at is 'Creation timestamp';
+comment on column sessions.last_used_at is 'When the session was used the last time';
+comment on column sessions.expires_at is 'The session is not valid after this moment, it is prolonged on use';
+comment on column sessions.user_agent is 'User agent of the client which started the session';
+comment on column sessions.ip is 'IP address of the client which started the session';
+
+create index sessions_person_id_idx on sessions(person_id);
+
+-- existing tokens keep working, the person ID is unique enough for the only session
+insert into sessions (id, person_id, token_hash, expires_at)
+select p.id, p.id, encode(sha256(p.access_token::bytea), 'hex'), now() + interval '30 days'
+from persons p
+where p.access_token is not null;
+
+alter table persons drop column access_token;
+
+-- impersonation tokens are short-lived, so existing ones are hashed in place
+alter table impersonations rename column token to token_hash;
+
+update impersonations set token_hash = encode(sha256(token_hash::bytea), 'hex');
+
+comment on column impersonations.token_hash is 'SHA-256 hex of the access token of the impersonation, the token itself is not stored';
@@ -0,0 +1,27 @@
+create table api_keys (
+    id varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , "name" varchar not null
+    , token_hash varchar not null unique
+    , token_prefix varchar not null
+    , scopes varchar[] not null
+    , ip_allowlist varchar[] not null default '{}'
+    , created_at timestamp not null default now()
+    , last_used_at timestamp null
+    , expires_at timestamp null
+);
+
+comment on table api_keys is 'Personal API keys of persons for integrations, a key gives access only to routes of its scopes';
+
+comment on column api_keys.id is 'PK';
+comment on column api_keys.person_id is 'Owner of the key, requests with the key are made on behalf of the person';
+comment on column api_keys.name is 'Name of the key given by the owner';
+comment on column api_keys.token_hash is 'SHA-256 hex of the key, the key itself is not stored';
+comment on column api_keys.token_prefix is 'The beginning of the key to recognize it in the list';
+comment on column api_keys.scopes is 'Scopes granted to the key, like jobs:write';
+comment on column api_keys.ip_allowlist is 'IP addresses and CIDR ranges the key is accepted from, empty means any address';
+comment on column api_keys.created_at is 'Creation timestamp';
+comment on column api_keys.last_used_at is 'When the key was used the last time';
+comment on column api_keys.expires_at is 'The key is not valid after this moment, null means the key does not expire';
+
+create index api_keys_person_id_idx on api_keys(person_id);
@@ -0,0 +1,11 @@
+create table chat_tickets (
+    token_hash varchar primary key not null
+    , person_id varchar not null references persons(id) on delete cascade
+    , expires_at timestamp not null
+);
+
+comment on table chat_tickets is 'Short-lived single-use tickets authenticating WebSocket handshakes of chat events, so the access token is not passed in the URL';
+
+comment on column chat_tickets.token_hash is 'SHA-256 hex of the ticket, the ticket itself is not stored';
+comment on column chat_tickets.person_id is 'Person who requested the ticket';
+comment on column chat_tickets.expires_at is 'The ticket is not valid after this moment';
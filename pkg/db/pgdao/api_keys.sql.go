@@ -0,0 +1,176 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: api_keys.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const aPIKeyAdd = `-- name: APIKeyAdd :one
+insert into api_keys (
+    id, person_id, "name", token_hash, token_prefix, scopes, ip_allowlist, expires_at
+) values (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) returning id, person_id, name, token_hash, token_prefix, scopes, ip_allowlist, created_at, last_used_at, expires_at
+`
+
+type APIKeyAddParams struct {
+	ID          string
+	PersonID    string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []string
+	IpAllowlist []string
+	ExpiresAt   sql.NullTime
+}
+
+func (q *Queries) APIKeyAdd(ctx context.Context, arg APIKeyAddParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, aPIKeyAdd,
+		arg.ID,
+		arg.PersonID,
+		arg.Name,
+		arg.TokenHash,
+		arg.TokenPrefix,
+		pq.Array(arg.Scopes),
+		pq.Array(arg.IpAllowlist),
+		arg.ExpiresAt,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Name,
+		&i.TokenHash,
+		&i.TokenPrefix,
+		pq.Array(&i.Scopes),
+		pq.Array(&i.IpAllowlist),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const aPIKeyDelete = `-- name: APIKeyDelete :execrows
+delete from api_keys
+where id = $1::varchar and person_id = $2::varchar
+`
+
+type APIKeyDeleteParams struct {
+	ID       string
+	PersonID string
+}
+
+func (q *Queries) APIKeyDelete(ctx context.Context, arg APIKeyDeleteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, aPIKeyDelete, arg.ID, arg.PersonID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const aPIKeyGetByTokenHash = `-- name: APIKeyGetByTokenHash :one
+select k.id, k.person_id, k.name, k.token_hash, k.token_prefix, k.scopes, k.ip_allowlist, k.created_at, k.last_used_at, k.expires_at from api_keys k
+where k.token_hash = $1::varchar and (k.expires_at is null or k.expires_at > now())
+`
+
+// Returns only not expired key
+func (q *Queries) APIKeyGetByTokenHash(ctx context.Context, tokenHash string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, aPIKeyGetByTokenHash, tokenHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Name,
+		&i.TokenHash,
+		&i.TokenPrefix,
+		pq.Array(&i.Scopes),
+		pq.Array(&i.IpAllowlist),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const aPIKeyTouch = `-- name: APIKeyTouch :exec
+update api_keys
+set
+    last_used_at = now()
+where
+    id = $1::varchar
+    and (last_used_at is null or last_used_at < now() - interval '1 minute')
+`
+
+// It is written not more often than once a minute
+func (q *Queries) APIKeyTouch(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, aPIKeyTouch, id)
+	return err
+}
+
+const aPIKeysDeleteByPerson = `-- name: APIKeysDeleteByPerson :exec
+delete from api_keys where person_id = $1::varchar
+`
+
+func (q *Queries) APIKeysDeleteByPerson(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, aPIKeysDeleteByPerson, personID)
+	return err
+}
+
+const aPIKeysListByPerson = `-- name: APIKeysListByPerson :many
+select k.id, k.person_id, k.name, k.token_hash, k.token_prefix, k.scopes, k.ip_allowlist, k.created_at, k.last_used_at, k.expires_at from api_keys k
+where k.person_id = $1::varchar
+order by k.created_at desc, k.id
+`
+
+// The newest keys go first
+func (q *Queries) APIKeysListByPerson(ctx context.Context, personID string) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeysListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.Name,
+			&i.TokenHash,
+			&i.TokenPrefix,
+			pq.Array(&i.Scopes),
+			pq.Array(&i.IpAllowlist),
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aPIKeysPurge = `-- name: APIKeysPurge :exec
+DELETE FROM api_keys
+`
+
+// Handle with care!
+func (q *Queries) APIKeysPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, aPIKeysPurge)
+	return err
+}
@@ -0,0 +1,94 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: application_revisions.sql
+
+package pgdao
+
+import (
+	"context"
+)
+
+const applicationRevisionAdd = `-- name: ApplicationRevisionAdd :one
+insert into application_revisions (
+    id, application_id, version, "comment", price
+) values (
+    $1,
+    $2,
+    (select coalesce(max(r.version), 0) + 1 from application_revisions r where r.application_id = $2::varchar),
+    $3,
+    $4
+) returning id, application_id, version, comment, price, created_at
+`
+
+type ApplicationRevisionAddParams struct {
+	ID            string
+	ApplicationID string
+	Comment       string
+	Price         string
+}
+
+func (q *Queries) ApplicationRevisionAdd(ctx context.Context, arg ApplicationRevisionAddParams) (ApplicationRevision, error) {
+	row := q.db.QueryRowContext(ctx, applicationRevisionAdd,
+		arg.ID,
+		arg.ApplicationID,
+		arg.Comment,
+		arg.Price,
+	)
+	var i ApplicationRevision
+	err := row.Scan(
+		&i.ID,
+		&i.ApplicationID,
+		&i.Version,
+		&i.Comment,
+		&i.Price,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const applicationRevisionsListByApplication = `-- name: ApplicationRevisionsListByApplication :many
+select r.id, r.application_id, r.version, r.comment, r.price, r.created_at from application_revisions r
+where r.application_id = $1::varchar
+order by r.version desc
+`
+
+func (q *Queries) ApplicationRevisionsListByApplication(ctx context.Context, applicationID string) ([]ApplicationRevision, error) {
+	rows, err := q.db.QueryContext(ctx, applicationRevisionsListByApplication, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationRevision
+	for rows.Next() {
+		var i ApplicationRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.ApplicationID,
+			&i.Version,
+			&i.Comment,
+			&i.Price,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const applicationRevisionsPurge = `-- name: ApplicationRevisionsPurge :exec
+DELETE FROM application_revisions
+`
+
+// Handle with care!
+func (q *Queries) ApplicationRevisionsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, applicationRevisionsPurge)
+	return err
+}
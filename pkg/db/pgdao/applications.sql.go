@@ -17,7 +17,7 @@ insert into applications (
 ) values (
     $1, $2, $3, $4, $5
 )
-returning id, created_at, updated_at, comment, price, job_id, applicant_id
+returning id, created_at, updated_at, comment, price, job_id, applicant_id, withdrawn_at, status, rejection_reason, status_changed_at
 `
 
 type ApplicationAddParams struct {
@@ -45,12 +45,16 @@ func (q *Queries) ApplicationAdd(ctx context.Context, arg ApplicationAddParams)
 		&i.Price,
 		&i.JobID,
 		&i.ApplicantID,
+		&i.WithdrawnAt,
+		&i.Status,
+		&i.RejectionReason,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
 
 const applicationFindByJobAndApplicant = `-- name: ApplicationFindByJobAndApplicant :one
-select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id
+select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id, a.withdrawn_at, a.status, a.rejection_reason, a.status_changed_at
 	, j.title AS job_title
 	, j.budget AS job_budget
 	, j.description AS job_description
@@ -62,7 +66,7 @@ select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applica
 	join persons p on p.id = a.applicant_id
 	join jobs j on j.id = a.job_id
 	left join contracts c on c.application_id  = a.id and c.performer_id = a.applicant_id
-	where a.job_id = $1::varchar and a.applicant_id = $2::varchar
+	where a.job_id = $1::varchar and a.applicant_id = $2::varchar and a.withdrawn_at is null
 	limit 1
 `
 
@@ -79,6 +83,10 @@ type ApplicationFindByJobAndApplicantRow struct {
 	Price                    string
 	JobID                    string
 	ApplicantID              string
+	WithdrawnAt              sql.NullTime
+	Status                   string
+	RejectionReason          string
+	StatusChangedAt          sql.NullTime
 	JobTitle                 string
 	JobBudget                sql.NullString
 	JobDescription           string
@@ -99,6 +107,10 @@ func (q *Queries) ApplicationFindByJobAndApplicant(ctx context.Context, arg Appl
 		&i.Price,
 		&i.JobID,
 		&i.ApplicantID,
+		&i.WithdrawnAt,
+		&i.Status,
+		&i.RejectionReason,
+		&i.StatusChangedAt,
 		&i.JobTitle,
 		&i.JobBudget,
 		&i.JobDescription,
@@ -111,10 +123,11 @@ func (q *Queries) ApplicationFindByJobAndApplicant(ctx context.Context, arg Appl
 }
 
 const applicationGet = `-- name: ApplicationGet :one
-select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id
+select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id, a.withdrawn_at, a.status, a.rejection_reason, a.status_changed_at
 	, j.title AS job_title
 	, j.budget AS job_budget
 	, j.description AS job_description
+	, j.created_by AS customer_id
 	, c.id AS contract_id
 	, c.status AS contract_status
 	, (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS applicant_display_name
@@ -134,9 +147,14 @@ type ApplicationGetRow struct {
 	Price                    string
 	JobID                    string
 	ApplicantID              string
+	WithdrawnAt              sql.NullTime
+	Status                   string
+	RejectionReason          string
+	StatusChangedAt          sql.NullTime
 	JobTitle                 string
 	JobBudget                sql.NullString
 	JobDescription           string
+	CustomerID               string
 	ContractID               sql.NullString
 	ContractStatus           sql.NullString
 	ApplicantDisplayName     string
@@ -154,9 +172,14 @@ func (q *Queries) ApplicationGet(ctx context.Context, id string) (ApplicationGet
 		&i.Price,
 		&i.JobID,
 		&i.ApplicantID,
+		&i.WithdrawnAt,
+		&i.Status,
+		&i.RejectionReason,
+		&i.StatusChangedAt,
 		&i.JobTitle,
 		&i.JobBudget,
 		&i.JobDescription,
+		&i.CustomerID,
 		&i.ContractID,
 		&i.ContractStatus,
 		&i.ApplicantDisplayName,
@@ -165,8 +188,161 @@ func (q *Queries) ApplicationGet(ctx context.Context, id string) (ApplicationGet
 	return i, err
 }
 
+const applicationSetStatus = `-- name: ApplicationSetStatus :one
+update applications
+set
+    status = $1::varchar,
+    rejection_reason = $2::text,
+    status_changed_at = now()
+where id = $3::varchar
+returning id, created_at, updated_at, comment, price, job_id, applicant_id, withdrawn_at, status, rejection_reason, status_changed_at
+`
+
+type ApplicationSetStatusParams struct {
+	Status          string
+	RejectionReason string
+	ID              string
+}
+
+func (q *Queries) ApplicationSetStatus(ctx context.Context, arg ApplicationSetStatusParams) (Application, error) {
+	row := q.db.QueryRowContext(ctx, applicationSetStatus, arg.Status, arg.RejectionReason, arg.ID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Comment,
+		&i.Price,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.WithdrawnAt,
+		&i.Status,
+		&i.RejectionReason,
+		&i.StatusChangedAt,
+	)
+	return i, err
+}
+
+const applicationStatusCountsByJob = `-- name: ApplicationStatusCountsByJob :many
+select a.status, count(*) as count
+from applications a
+where a.job_id = $1::varchar and a.withdrawn_at is null
+group by a.status
+`
+
+type ApplicationStatusCountsByJobRow struct {
+	Status string
+	Count  int64
+}
+
+// Counts of active applications of the job per status
+func (q *Queries) ApplicationStatusCountsByJob(ctx context.Context, jobID string) ([]ApplicationStatusCountsByJobRow, error) {
+	rows, err := q.db.QueryContext(ctx, applicationStatusCountsByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationStatusCountsByJobRow
+	for rows.Next() {
+		var i ApplicationStatusCountsByJobRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const applicationStatusCountsByOwner = `-- name: ApplicationStatusCountsByOwner :many
+select a.job_id, a.status, count(*) as count
+from applications a
+join jobs j on j.id = a.job_id
+where j.created_by = $1::varchar and a.withdrawn_at is null
+group by a.job_id, a.status
+`
+
+type ApplicationStatusCountsByOwnerRow struct {
+	JobID  string
+	Status string
+	Count  int64
+}
+
+// Counts of active applications per job and status for all jobs of the owner
+func (q *Queries) ApplicationStatusCountsByOwner(ctx context.Context, createdBy string) ([]ApplicationStatusCountsByOwnerRow, error) {
+	rows, err := q.db.QueryContext(ctx, applicationStatusCountsByOwner, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationStatusCountsByOwnerRow
+	for rows.Next() {
+		var i ApplicationStatusCountsByOwnerRow
+		if err := rows.Scan(&i.JobID, &i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const applicationUpdate = `-- name: ApplicationUpdate :one
+update applications
+set
+    "comment" = $1::text,
+    price = $2::decimal,
+    updated_at = now()
+where id = $3::varchar
+returning id, created_at, updated_at, comment, price, job_id, applicant_id, withdrawn_at, status, rejection_reason, status_changed_at
+`
+
+type ApplicationUpdateParams struct {
+	Comment string
+	Price   string
+	ID      string
+}
+
+func (q *Queries) ApplicationUpdate(ctx context.Context, arg ApplicationUpdateParams) (Application, error) {
+	row := q.db.QueryRowContext(ctx, applicationUpdate, arg.Comment, arg.Price, arg.ID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Comment,
+		&i.Price,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.WithdrawnAt,
+		&i.Status,
+		&i.RejectionReason,
+		&i.StatusChangedAt,
+	)
+	return i, err
+}
+
+const applicationWithdraw = `-- name: ApplicationWithdraw :exec
+update applications set withdrawn_at = now(), updated_at = now() where id = $1::varchar
+`
+
+func (q *Queries) ApplicationWithdraw(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, applicationWithdraw, id)
+	return err
+}
+
 const applicationsGetByApplicant = `-- name: ApplicationsGetByApplicant :many
-select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id
+select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id, a.withdrawn_at, a.status, a.rejection_reason, a.status_changed_at
 	, j.title AS job_title
 	, j.budget AS job_budget
 	, j.description AS job_description
@@ -191,6 +367,10 @@ type ApplicationsGetByApplicantRow struct {
 	Price                    string
 	JobID                    string
 	ApplicantID              string
+	WithdrawnAt              sql.NullTime
+	Status                   string
+	RejectionReason          string
+	StatusChangedAt          sql.NullTime
 	JobTitle                 string
 	JobBudget                sql.NullString
 	JobDescription           string
@@ -218,6 +398,10 @@ func (q *Queries) ApplicationsGetByApplicant(ctx context.Context, applicantID st
 			&i.Price,
 			&i.JobID,
 			&i.ApplicantID,
+			&i.WithdrawnAt,
+			&i.Status,
+			&i.RejectionReason,
+			&i.StatusChangedAt,
 			&i.JobTitle,
 			&i.JobBudget,
 			&i.JobDescription,
@@ -241,7 +425,7 @@ func (q *Queries) ApplicationsGetByApplicant(ctx context.Context, applicantID st
 }
 
 const applicationsGetByJob = `-- name: ApplicationsGetByJob :many
-select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id
+select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applicant_id, a.withdrawn_at, a.status, a.rejection_reason, a.status_changed_at
 	, c.id AS contract_id
 	, c.status AS contract_status
 	, (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS applicant_display_name
@@ -250,9 +434,15 @@ select a.id, a.created_at, a.updated_at, a.comment, a.price, a.job_id, a.applica
 	join persons p on p.id = a.applicant_id
 	left join contracts c on c.application_id  = a.id and c.performer_id = a.applicant_id
 	where a.job_id = $1::varchar
+		and ($2::varchar = '' or (a.status = $2::varchar and a.withdrawn_at is null))
 	order by a.created_at desc
 `
 
+type ApplicationsGetByJobParams struct {
+	JobID  string
+	Status string
+}
+
 type ApplicationsGetByJobRow struct {
 	ID                       string
 	CreatedAt                time.Time
@@ -261,14 +451,18 @@ type ApplicationsGetByJobRow struct {
 	Price                    string
 	JobID                    string
 	ApplicantID              string
+	WithdrawnAt              sql.NullTime
+	Status                   string
+	RejectionReason          string
+	StatusChangedAt          sql.NullTime
 	ContractID               sql.NullString
 	ContractStatus           sql.NullString
 	ApplicantDisplayName     string
 	ApplicantEthereumAddress string
 }
 
-func (q *Queries) ApplicationsGetByJob(ctx context.Context, jobID string) ([]ApplicationsGetByJobRow, error) {
-	rows, err := q.db.QueryContext(ctx, applicationsGetByJob, jobID)
+func (q *Queries) ApplicationsGetByJob(ctx context.Context, arg ApplicationsGetByJobParams) ([]ApplicationsGetByJobRow, error) {
+	rows, err := q.db.QueryContext(ctx, applicationsGetByJob, arg.JobID, arg.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +478,10 @@ func (q *Queries) ApplicationsGetByJob(ctx context.Context, jobID string) ([]App
 			&i.Price,
 			&i.JobID,
 			&i.ApplicantID,
+			&i.WithdrawnAt,
+			&i.Status,
+			&i.RejectionReason,
+			&i.StatusChangedAt,
 			&i.ContractID,
 			&i.ContractStatus,
 			&i.ApplicantDisplayName,
@@ -0,0 +1,290 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: attachments.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const attachmentAdd = `-- name: AttachmentAdd :one
+insert into attachments (
+    id, created_by, "name", content_type, size, storage_key, thumbnail_key
+) values (
+    $1, $2, $3, $4, $5, $6, $7
+) returning id, created_by, created_at, name, content_type, size, storage_key, job_id, application_id, message_id, thumbnail_key
+`
+
+type AttachmentAddParams struct {
+	ID           string
+	CreatedBy    string
+	Name         string
+	ContentType  string
+	Size         int64
+	StorageKey   string
+	ThumbnailKey sql.NullString
+}
+
+func (q *Queries) AttachmentAdd(ctx context.Context, arg AttachmentAddParams) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, attachmentAdd,
+		arg.ID,
+		arg.CreatedBy,
+		arg.Name,
+		arg.ContentType,
+		arg.Size,
+		arg.StorageKey,
+		arg.ThumbnailKey,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
+		&i.JobID,
+		&i.ApplicationID,
+		&i.MessageID,
+		&i.ThumbnailKey,
+	)
+	return i, err
+}
+
+const attachmentDelete = `-- name: AttachmentDelete :exec
+delete from attachments where id = $1::varchar
+`
+
+func (q *Queries) AttachmentDelete(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, attachmentDelete, id)
+	return err
+}
+
+const attachmentGet = `-- name: AttachmentGet :one
+select a.id, a.created_by, a.created_at, a.name, a.content_type, a.size, a.storage_key, a.job_id, a.application_id, a.message_id, a.thumbnail_key from attachments a
+where a.id = $1::varchar
+`
+
+func (q *Queries) AttachmentGet(ctx context.Context, id string) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, attachmentGet, id)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
+		&i.JobID,
+		&i.ApplicationID,
+		&i.MessageID,
+		&i.ThumbnailKey,
+	)
+	return i, err
+}
+
+const attachmentsBindToApplication = `-- name: AttachmentsBindToApplication :execrows
+update attachments
+set application_id = $1::varchar
+where id = any($2::varchar[]) and created_by = $3::varchar
+    and job_id is null and application_id is null and message_id is null
+`
+
+type AttachmentsBindToApplicationParams struct {
+	ApplicationID string
+	Ids           []string
+	CreatedBy     string
+}
+
+// Binds only unbound attachments of the person
+func (q *Queries) AttachmentsBindToApplication(ctx context.Context, arg AttachmentsBindToApplicationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, attachmentsBindToApplication, arg.ApplicationID, pq.Array(arg.Ids), arg.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const attachmentsBindToJob = `-- name: AttachmentsBindToJob :execrows
+update attachments
+set job_id = $1::varchar
+where id = any($2::varchar[]) and created_by = $3::varchar
+    and job_id is null and application_id is null and message_id is null
+`
+
+type AttachmentsBindToJobParams struct {
+	JobID     string
+	Ids       []string
+	CreatedBy string
+}
+
+// Binds only unbound attachments of the person
+func (q *Queries) AttachmentsBindToJob(ctx context.Context, arg AttachmentsBindToJobParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, attachmentsBindToJob, arg.JobID, pq.Array(arg.Ids), arg.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const attachmentsBindToMessage = `-- name: AttachmentsBindToMessage :execrows
+update attachments
+set message_id = $1::varchar
+where id = any($2::varchar[]) and created_by = $3::varchar
+    and job_id is null and application_id is null and message_id is null
+`
+
+type AttachmentsBindToMessageParams struct {
+	MessageID string
+	Ids       []string
+	CreatedBy string
+}
+
+// Binds only unbound attachments of the person
+func (q *Queries) AttachmentsBindToMessage(ctx context.Context, arg AttachmentsBindToMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, attachmentsBindToMessage, arg.MessageID, pq.Array(arg.Ids), arg.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const attachmentsListByApplication = `-- name: AttachmentsListByApplication :many
+select a.id, a.created_by, a.created_at, a.name, a.content_type, a.size, a.storage_key, a.job_id, a.application_id, a.message_id, a.thumbnail_key from attachments a
+where a.application_id = $1::varchar
+order by a.created_at
+`
+
+func (q *Queries) AttachmentsListByApplication(ctx context.Context, applicationID string) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, attachmentsListByApplication, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.StorageKey,
+			&i.JobID,
+			&i.ApplicationID,
+			&i.MessageID,
+			&i.ThumbnailKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const attachmentsListByJob = `-- name: AttachmentsListByJob :many
+select a.id, a.created_by, a.created_at, a.name, a.content_type, a.size, a.storage_key, a.job_id, a.application_id, a.message_id, a.thumbnail_key from attachments a
+where a.job_id = $1::varchar
+order by a.created_at
+`
+
+func (q *Queries) AttachmentsListByJob(ctx context.Context, jobID string) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, attachmentsListByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.StorageKey,
+			&i.JobID,
+			&i.ApplicationID,
+			&i.MessageID,
+			&i.ThumbnailKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const attachmentsListByMessages = `-- name: AttachmentsListByMessages :many
+select a.id, a.created_by, a.created_at, a.name, a.content_type, a.size, a.storage_key, a.job_id, a.application_id, a.message_id, a.thumbnail_key from attachments a
+where a.message_id = any($1::varchar[])
+order by a.created_at
+`
+
+func (q *Queries) AttachmentsListByMessages(ctx context.Context, messageIds []string) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, attachmentsListByMessages, pq.Array(messageIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.StorageKey,
+			&i.JobID,
+			&i.ApplicationID,
+			&i.MessageID,
+			&i.ThumbnailKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const attachmentsPurge = `-- name: AttachmentsPurge :exec
+DELETE FROM attachments
+`
+
+// Handle with care!
+func (q *Queries) AttachmentsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, attachmentsPurge)
+	return err
+}
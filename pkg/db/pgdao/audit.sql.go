@@ -0,0 +1,120 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: audit.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+)
+
+const auditAdd = `-- name: AuditAdd :one
+insert into audit_log (
+    id, actor_id, "action", target_kind, target_id, reason
+) values (
+    $1, $2, $3, $4, $5, $6
+) returning id, actor_id, action, target_kind, target_id, reason, created_at
+`
+
+type AuditAddParams struct {
+	ID         string
+	ActorID    string
+	Action     string
+	TargetKind string
+	TargetID   string
+	Reason     string
+}
+
+func (q *Queries) AuditAdd(ctx context.Context, arg AuditAddParams) (AuditLog, error) {
+	row := q.db.QueryRowContext(ctx, auditAdd,
+		arg.ID,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetKind,
+		arg.TargetID,
+		arg.Reason,
+	)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.ActorID,
+		&i.Action,
+		&i.TargetKind,
+		&i.TargetID,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const auditList = `-- name: AuditList :many
+select
+      a.id, a.actor_id, a.action, a.target_kind, a.target_id, a.reason, a.created_at
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS actor_display_name
+from audit_log a
+join persons p on p.id = a.actor_id
+where ($1::varchar = '' or a.target_kind = $1::varchar)
+    and ($2::varchar = '' or a.target_id = $2::varchar)
+order by a.created_at desc
+`
+
+type AuditListParams struct {
+	TargetKind string
+	TargetID   string
+}
+
+type AuditListRow struct {
+	ID               string
+	ActorID          string
+	Action           string
+	TargetKind       string
+	TargetID         string
+	Reason           string
+	CreatedAt        time.Time
+	ActorDisplayName string
+}
+
+// Empty target_kind or target_id means any. The newest records go first.
+func (q *Queries) AuditList(ctx context.Context, arg AuditListParams) ([]AuditListRow, error) {
+	rows, err := q.db.QueryContext(ctx, auditList, arg.TargetKind, arg.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditListRow
+	for rows.Next() {
+		var i AuditListRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetKind,
+			&i.TargetID,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ActorDisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const auditPurge = `-- name: AuditPurge :exec
+DELETE FROM audit_log
+`
+
+// Handle with care!
+func (q *Queries) AuditPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, auditPurge)
+	return err
+}
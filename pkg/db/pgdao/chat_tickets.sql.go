@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: chat_tickets.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+)
+
+const chatTicketAdd = `-- name: ChatTicketAdd :exec
+insert into chat_tickets (
+    token_hash, person_id, expires_at
+) values (
+    $1, $2, $3
+)
+`
+
+type ChatTicketAddParams struct {
+	TokenHash string
+	PersonID  string
+	ExpiresAt time.Time
+}
+
+func (q *Queries) ChatTicketAdd(ctx context.Context, arg ChatTicketAddParams) error {
+	_, err := q.db.ExecContext(ctx, chatTicketAdd, arg.TokenHash, arg.PersonID, arg.ExpiresAt)
+	return err
+}
+
+const chatTicketUse = `-- name: ChatTicketUse :one
+delete from chat_tickets t
+using persons p
+where
+    t.token_hash = $1::varchar
+    and t.expires_at > now()
+    and p.id = t.person_id
+    and p.blocked_at is null
+returning t.person_id
+`
+
+// Removes the ticket, so it can not be used again, and returns the person if the ticket is not expired and the person is not blocked
+func (q *Queries) ChatTicketUse(ctx context.Context, tokenHash string) (string, error) {
+	row := q.db.QueryRowContext(ctx, chatTicketUse, tokenHash)
+	var person_id string
+	err := row.Scan(&person_id)
+	return person_id, err
+}
+
+const chatTicketsDeleteExpired = `-- name: ChatTicketsDeleteExpired :exec
+delete from chat_tickets where expires_at <= now()
+`
+
+func (q *Queries) ChatTicketsDeleteExpired(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, chatTicketsDeleteExpired)
+	return err
+}
+
+const chatTicketsPurge = `-- name: ChatTicketsPurge :exec
+DELETE FROM chat_tickets
+`
+
+// Handle with care!
+func (q *Queries) ChatTicketsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, chatTicketsPurge)
+	return err
+}
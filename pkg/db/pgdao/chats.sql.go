@@ -31,6 +31,16 @@ func (q *Queries) ChatAdd(ctx context.Context, arg ChatAddParams) (Chat, error)
 	return i, err
 }
 
+const chatEventNotify = `-- name: ChatEventNotify :exec
+select pg_notify('chat_events', $1::text)
+`
+
+// Notifies all replicas about the chat event, new messages are notified by the trigger
+func (q *Queries) ChatEventNotify(ctx context.Context, payload string) error {
+	_, err := q.db.ExecContext(ctx, chatEventNotify, payload)
+	return err
+}
+
 const chatGet = `-- name: ChatGet :one
 select
     c.id, c.topic, c.created_at
@@ -90,12 +100,72 @@ func (q *Queries) ChatGetDetailsByApplicationID(ctx context.Context, application
 	return i, err
 }
 
+const chatGetDetailsByContractID = `-- name: ChatGetDetailsByContractID :one
+select
+      c.id as contract_id
+    , c.application_id
+    , a.job_id
+    , c.title as contract_title
+from contracts c
+join applications a on a.id = c.application_id
+where c.id = $1::varchar
+`
+
+type ChatGetDetailsByContractIDRow struct {
+	ContractID    string
+	ApplicationID string
+	JobID         string
+	ContractTitle string
+}
+
+func (q *Queries) ChatGetDetailsByContractID(ctx context.Context, contractID string) (ChatGetDetailsByContractIDRow, error) {
+	row := q.db.QueryRowContext(ctx, chatGetDetailsByContractID, contractID)
+	var i ChatGetDetailsByContractIDRow
+	err := row.Scan(
+		&i.ContractID,
+		&i.ApplicationID,
+		&i.JobID,
+		&i.ContractTitle,
+	)
+	return i, err
+}
+
+const chatGetDetailsByInvitationID = `-- name: ChatGetDetailsByInvitationID :one
+select
+      i.id as invitation_id
+    , j.id as job_id
+    , j.title as job_title
+    , i.application_id as application_id
+from job_invitations i
+join jobs j on i.job_id = j.id
+where i.id = $1::varchar
+`
+
+type ChatGetDetailsByInvitationIDRow struct {
+	InvitationID  string
+	JobID         string
+	JobTitle      string
+	ApplicationID sql.NullString
+}
+
+func (q *Queries) ChatGetDetailsByInvitationID(ctx context.Context, invitationID string) (ChatGetDetailsByInvitationIDRow, error) {
+	row := q.db.QueryRowContext(ctx, chatGetDetailsByInvitationID, invitationID)
+	var i ChatGetDetailsByInvitationIDRow
+	err := row.Scan(
+		&i.InvitationID,
+		&i.JobID,
+		&i.JobTitle,
+		&i.ApplicationID,
+	)
+	return i, err
+}
+
 const chatParticipantAdd = `-- name: ChatParticipantAdd :one
 insert into chats_participants (
     chat_id, person_id
 ) values (
     $1, $2
-) returning chat_id, person_id
+) returning chat_id, person_id, last_read_at
 `
 
 type ChatParticipantAddParams struct {
@@ -106,13 +176,13 @@ type ChatParticipantAddParams struct {
 func (q *Queries) ChatParticipantAdd(ctx context.Context, arg ChatParticipantAddParams) (ChatsParticipant, error) {
 	row := q.db.QueryRowContext(ctx, chatParticipantAdd, arg.ChatID, arg.PersonID)
 	var i ChatsParticipant
-	err := row.Scan(&i.ChatID, &i.PersonID)
+	err := row.Scan(&i.ChatID, &i.PersonID, &i.LastReadAt)
 	return i, err
 }
 
 const chatParticipantGet = `-- name: ChatParticipantGet :one
 select
-    cp.chat_id, cp.person_id
+    cp.chat_id, cp.person_id, cp.last_read_at
 from chats_participants cp
 where
     cp.chat_id = $1::varchar
@@ -127,10 +197,98 @@ type ChatParticipantGetParams struct {
 func (q *Queries) ChatParticipantGet(ctx context.Context, arg ChatParticipantGetParams) (ChatsParticipant, error) {
 	row := q.db.QueryRowContext(ctx, chatParticipantGet, arg.ChatID, arg.PersonID)
 	var i ChatsParticipant
-	err := row.Scan(&i.ChatID, &i.PersonID)
+	err := row.Scan(&i.ChatID, &i.PersonID, &i.LastReadAt)
 	return i, err
 }
 
+const chatParticipantMarkRead = `-- name: ChatParticipantMarkRead :one
+update chats_participants
+set last_read_at = greatest(last_read_at, case when $1::boolean then now() else $2::timestamp end)
+where chat_id = $3::varchar and person_id = $4::varchar
+returning chat_id, person_id, last_read_at
+`
+
+type ChatParticipantMarkReadParams struct {
+	ReadAll  bool
+	ReadAt   time.Time
+	ChatID   string
+	PersonID string
+}
+
+// The read marker never moves backward, all messages up to now are read if read_all is true
+func (q *Queries) ChatParticipantMarkRead(ctx context.Context, arg ChatParticipantMarkReadParams) (ChatsParticipant, error) {
+	row := q.db.QueryRowContext(ctx, chatParticipantMarkRead,
+		arg.ReadAll,
+		arg.ReadAt,
+		arg.ChatID,
+		arg.PersonID,
+	)
+	var i ChatsParticipant
+	err := row.Scan(&i.ChatID, &i.PersonID, &i.LastReadAt)
+	return i, err
+}
+
+const chatParticipantsList = `-- name: ChatParticipantsList :many
+select cp.person_id from chats_participants cp
+where cp.chat_id = $1::varchar
+`
+
+func (q *Queries) ChatParticipantsList(ctx context.Context, chatID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, chatParticipantsList, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var person_id string
+		if err := rows.Scan(&person_id); err != nil {
+			return nil, err
+		}
+		items = append(items, person_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const chatParticipantsReadMarkers = `-- name: ChatParticipantsReadMarkers :many
+select cp.person_id, cp.last_read_at from chats_participants cp
+where cp.chat_id = $1::varchar and cp.last_read_at is not null
+`
+
+type ChatParticipantsReadMarkersRow struct {
+	PersonID   string
+	LastReadAt sql.NullTime
+}
+
+func (q *Queries) ChatParticipantsReadMarkers(ctx context.Context, chatID string) ([]ChatParticipantsReadMarkersRow, error) {
+	rows, err := q.db.QueryContext(ctx, chatParticipantsReadMarkers, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatParticipantsReadMarkersRow
+	for rows.Next() {
+		var i ChatParticipantsReadMarkersRow
+		if err := rows.Scan(&i.PersonID, &i.LastReadAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const chatsListByParticipant = `-- name: ChatsListByParticipant :many
 select
       c.id, c.topic, c.created_at
@@ -199,3 +357,59 @@ func (q *Queries) ChatsPurge(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, chatsPurge)
 	return err
 }
+
+const chatsUnreadCountsByParticipant = `-- name: ChatsUnreadCountsByParticipant :many
+select cp.chat_id, count(m.id) as unread_count
+from chats_participants cp
+join messages m on m.chat_id = cp.chat_id
+    and m.created_by <> cp.person_id
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and m.deleted_at is null
+where cp.person_id = $1::varchar
+group by cp.chat_id
+`
+
+type ChatsUnreadCountsByParticipantRow struct {
+	ChatID      string
+	UnreadCount int64
+}
+
+func (q *Queries) ChatsUnreadCountsByParticipant(ctx context.Context, participantID string) ([]ChatsUnreadCountsByParticipantRow, error) {
+	rows, err := q.db.QueryContext(ctx, chatsUnreadCountsByParticipant, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatsUnreadCountsByParticipantRow
+	for rows.Next() {
+		var i ChatsUnreadCountsByParticipantRow
+		if err := rows.Scan(&i.ChatID, &i.UnreadCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messagesUnreadCountByParticipant = `-- name: MessagesUnreadCountByParticipant :one
+select count(m.id) as unread_count
+from chats_participants cp
+join messages m on m.chat_id = cp.chat_id
+    and m.created_by <> cp.person_id
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and m.deleted_at is null
+where cp.person_id = $1::varchar
+`
+
+func (q *Queries) MessagesUnreadCountByParticipant(ctx context.Context, participantID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, messagesUnreadCountByParticipant, participantID)
+	var unread_count int64
+	err := row.Scan(&unread_count)
+	return unread_count, err
+}
@@ -0,0 +1,91 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: connects.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+)
+
+const personConnectsAdjust = `-- name: PersonConnectsAdjust :one
+update persons
+set connects = greatest(connects + $1::int, 0)
+where id = $2::varchar
+returning connects, connects_refilled_at
+`
+
+type PersonConnectsAdjustParams struct {
+	Delta int32
+	ID    string
+}
+
+type PersonConnectsAdjustRow struct {
+	Connects           int32
+	ConnectsRefilledAt sql.NullTime
+}
+
+func (q *Queries) PersonConnectsAdjust(ctx context.Context, arg PersonConnectsAdjustParams) (PersonConnectsAdjustRow, error) {
+	row := q.db.QueryRowContext(ctx, personConnectsAdjust, arg.Delta, arg.ID)
+	var i PersonConnectsAdjustRow
+	err := row.Scan(&i.Connects, &i.ConnectsRefilledAt)
+	return i, err
+}
+
+const personConnectsGet = `-- name: PersonConnectsGet :one
+select p.connects, p.connects_refilled_at from persons p
+where p.id = $1::varchar
+`
+
+type PersonConnectsGetRow struct {
+	Connects           int32
+	ConnectsRefilledAt sql.NullTime
+}
+
+func (q *Queries) PersonConnectsGet(ctx context.Context, id string) (PersonConnectsGetRow, error) {
+	row := q.db.QueryRowContext(ctx, personConnectsGet, id)
+	var i PersonConnectsGetRow
+	err := row.Scan(&i.Connects, &i.ConnectsRefilledAt)
+	return i, err
+}
+
+const personConnectsRefill = `-- name: PersonConnectsRefill :exec
+update persons
+set
+    connects = greatest(connects, $1::int),
+    connects_refilled_at = now()
+where id = $2::varchar
+    and (connects_refilled_at is null or connects_refilled_at <= now() - interval '7 days')
+`
+
+type PersonConnectsRefillParams struct {
+	Allowance int32
+	ID        string
+}
+
+// Tops up the balance to the allowance once a week, the balance above the allowance is kept
+func (q *Queries) PersonConnectsRefill(ctx context.Context, arg PersonConnectsRefillParams) error {
+	_, err := q.db.ExecContext(ctx, personConnectsRefill, arg.Allowance, arg.ID)
+	return err
+}
+
+const personConnectsSpend = `-- name: PersonConnectsSpend :execrows
+update persons
+set connects = connects - $1::int
+where id = $2::varchar and connects >= $1::int
+`
+
+type PersonConnectsSpendParams struct {
+	Cost int32
+	ID   string
+}
+
+func (q *Queries) PersonConnectsSpend(ctx context.Context, arg PersonConnectsSpendParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, personConnectsSpend, arg.Cost, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: impersonations.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+)
+
+const impersonationAdd = `-- name: ImpersonationAdd :one
+insert into impersonations (
+    id, token_hash, admin_id, person_id, reason, expires_at
+) values (
+    $1, $2, $3, $4, $5, $6
+) returning id, token_hash, admin_id, person_id, reason, created_at, expires_at
+`
+
+type ImpersonationAddParams struct {
+	ID        string
+	TokenHash string
+	AdminID   string
+	PersonID  string
+	Reason    string
+	ExpiresAt time.Time
+}
+
+func (q *Queries) ImpersonationAdd(ctx context.Context, arg ImpersonationAddParams) (Impersonation, error) {
+	row := q.db.QueryRowContext(ctx, impersonationAdd,
+		arg.ID,
+		arg.TokenHash,
+		arg.AdminID,
+		arg.PersonID,
+		arg.Reason,
+		arg.ExpiresAt,
+	)
+	var i Impersonation
+	err := row.Scan(
+		&i.ID,
+		&i.TokenHash,
+		&i.AdminID,
+		&i.PersonID,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const impersonationGetByTokenHash = `-- name: ImpersonationGetByTokenHash :one
+select i.id, i.token_hash, i.admin_id, i.person_id, i.reason, i.created_at, i.expires_at from impersonations i
+where i.token_hash = $1::varchar and i.expires_at > now()
+`
+
+// Returns only not expired impersonation
+func (q *Queries) ImpersonationGetByTokenHash(ctx context.Context, tokenHash string) (Impersonation, error) {
+	row := q.db.QueryRowContext(ctx, impersonationGetByTokenHash, tokenHash)
+	var i Impersonation
+	err := row.Scan(
+		&i.ID,
+		&i.TokenHash,
+		&i.AdminID,
+		&i.PersonID,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const impersonationsEndByPerson = `-- name: ImpersonationsEndByPerson :exec
+update impersonations set expires_at = now()
+where person_id = $1::varchar and expires_at > now()
+`
+
+// Expires all active impersonations of the person, they are kept for the audit
+func (q *Queries) ImpersonationsEndByPerson(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, impersonationsEndByPerson, personID)
+	return err
+}
+
+const impersonationsPurge = `-- name: ImpersonationsPurge :exec
+DELETE FROM impersonations
+`
+
+// Handle with care!
+func (q *Queries) ImpersonationsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, impersonationsPurge)
+	return err
+}
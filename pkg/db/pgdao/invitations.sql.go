@@ -0,0 +1,300 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: invitations.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const invitationAdd = `-- name: InvitationAdd :one
+insert into job_invitations (
+    id, job_id, person_id, created_by, "message"
+) values (
+    $1, $2, $3, $4, $5
+) returning id, job_id, person_id, created_by, created_at, message, status, responded_at, application_id
+`
+
+type InvitationAddParams struct {
+	ID        string
+	JobID     string
+	PersonID  string
+	CreatedBy string
+	Message   string
+}
+
+func (q *Queries) InvitationAdd(ctx context.Context, arg InvitationAddParams) (JobInvitation, error) {
+	row := q.db.QueryRowContext(ctx, invitationAdd,
+		arg.ID,
+		arg.JobID,
+		arg.PersonID,
+		arg.CreatedBy,
+		arg.Message,
+	)
+	var i JobInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.PersonID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Message,
+		&i.Status,
+		&i.RespondedAt,
+		&i.ApplicationID,
+	)
+	return i, err
+}
+
+const invitationFindByJobAndPerson = `-- name: InvitationFindByJobAndPerson :one
+select i.id, i.job_id, i.person_id, i.created_by, i.created_at, i.message, i.status, i.responded_at, i.application_id from job_invitations i
+where i.job_id = $1::varchar and i.person_id = $2::varchar
+`
+
+type InvitationFindByJobAndPersonParams struct {
+	JobID    string
+	PersonID string
+}
+
+func (q *Queries) InvitationFindByJobAndPerson(ctx context.Context, arg InvitationFindByJobAndPersonParams) (JobInvitation, error) {
+	row := q.db.QueryRowContext(ctx, invitationFindByJobAndPerson, arg.JobID, arg.PersonID)
+	var i JobInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.PersonID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Message,
+		&i.Status,
+		&i.RespondedAt,
+		&i.ApplicationID,
+	)
+	return i, err
+}
+
+const invitationGet = `-- name: InvitationGet :one
+select
+      i.id, i.job_id, i.person_id, i.created_by, i.created_at, i.message, i.status, i.responded_at, i.application_id
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.id = $1::varchar
+`
+
+type InvitationGetRow struct {
+	ID                  string
+	JobID               string
+	PersonID            string
+	CreatedBy           string
+	CreatedAt           time.Time
+	Message             string
+	Status              string
+	RespondedAt         sql.NullTime
+	ApplicationID       sql.NullString
+	JobTitle            string
+	PersonDisplayName   string
+	CustomerDisplayName string
+}
+
+func (q *Queries) InvitationGet(ctx context.Context, id string) (InvitationGetRow, error) {
+	row := q.db.QueryRowContext(ctx, invitationGet, id)
+	var i InvitationGetRow
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.PersonID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Message,
+		&i.Status,
+		&i.RespondedAt,
+		&i.ApplicationID,
+		&i.JobTitle,
+		&i.PersonDisplayName,
+		&i.CustomerDisplayName,
+	)
+	return i, err
+}
+
+const invitationRespond = `-- name: InvitationRespond :one
+update job_invitations
+set
+    status = $1::varchar,
+    application_id = $2,
+    responded_at = now()
+where
+    id = $3::varchar and status = 'pending'
+returning id, job_id, person_id, created_by, created_at, message, status, responded_at, application_id
+`
+
+type InvitationRespondParams struct {
+	Status        string
+	ApplicationID sql.NullString
+	ID            string
+}
+
+func (q *Queries) InvitationRespond(ctx context.Context, arg InvitationRespondParams) (JobInvitation, error) {
+	row := q.db.QueryRowContext(ctx, invitationRespond, arg.Status, arg.ApplicationID, arg.ID)
+	var i JobInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.PersonID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Message,
+		&i.Status,
+		&i.RespondedAt,
+		&i.ApplicationID,
+	)
+	return i, err
+}
+
+const invitationsListByJob = `-- name: InvitationsListByJob :many
+select
+      i.id, i.job_id, i.person_id, i.created_by, i.created_at, i.message, i.status, i.responded_at, i.application_id
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.job_id = $1::varchar
+order by i.created_at desc
+`
+
+type InvitationsListByJobRow struct {
+	ID                  string
+	JobID               string
+	PersonID            string
+	CreatedBy           string
+	CreatedAt           time.Time
+	Message             string
+	Status              string
+	RespondedAt         sql.NullTime
+	ApplicationID       sql.NullString
+	JobTitle            string
+	PersonDisplayName   string
+	CustomerDisplayName string
+}
+
+func (q *Queries) InvitationsListByJob(ctx context.Context, jobID string) ([]InvitationsListByJobRow, error) {
+	rows, err := q.db.QueryContext(ctx, invitationsListByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InvitationsListByJobRow
+	for rows.Next() {
+		var i InvitationsListByJobRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.PersonID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Message,
+			&i.Status,
+			&i.RespondedAt,
+			&i.ApplicationID,
+			&i.JobTitle,
+			&i.PersonDisplayName,
+			&i.CustomerDisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const invitationsListByPerson = `-- name: InvitationsListByPerson :many
+select
+      i.id, i.job_id, i.person_id, i.created_by, i.created_at, i.message, i.status, i.responded_at, i.application_id
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.person_id = $1::varchar and j.blocked_at is null
+order by i.created_at desc
+`
+
+type InvitationsListByPersonRow struct {
+	ID                  string
+	JobID               string
+	PersonID            string
+	CreatedBy           string
+	CreatedAt           time.Time
+	Message             string
+	Status              string
+	RespondedAt         sql.NullTime
+	ApplicationID       sql.NullString
+	JobTitle            string
+	PersonDisplayName   string
+	CustomerDisplayName string
+}
+
+func (q *Queries) InvitationsListByPerson(ctx context.Context, personID string) ([]InvitationsListByPersonRow, error) {
+	rows, err := q.db.QueryContext(ctx, invitationsListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InvitationsListByPersonRow
+	for rows.Next() {
+		var i InvitationsListByPersonRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.PersonID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Message,
+			&i.Status,
+			&i.RespondedAt,
+			&i.ApplicationID,
+			&i.JobTitle,
+			&i.PersonDisplayName,
+			&i.CustomerDisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const invitationsPurge = `-- name: InvitationsPurge :exec
+DELETE FROM job_invitations
+`
+
+// Handle with care!
+func (q *Queries) InvitationsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, invitationsPurge)
+	return err
+}
@@ -0,0 +1,261 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: job_questions.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const applicationAnswerAdd = `-- name: ApplicationAnswerAdd :exec
+insert into application_answers (
+    application_id, question_id, "text", "options"
+) values (
+    $1, $2, $3, $4::varchar[]
+)
+`
+
+type ApplicationAnswerAddParams struct {
+	ApplicationID string
+	QuestionID    string
+	Text          string
+	Options       []string
+}
+
+func (q *Queries) ApplicationAnswerAdd(ctx context.Context, arg ApplicationAnswerAddParams) error {
+	_, err := q.db.ExecContext(ctx, applicationAnswerAdd,
+		arg.ApplicationID,
+		arg.QuestionID,
+		arg.Text,
+		pq.Array(arg.Options),
+	)
+	return err
+}
+
+const applicationAnswersListByApplication = `-- name: ApplicationAnswersListByApplication :many
+select aa.application_id, aa.question_id, aa.text, aa.options, aa.created_at
+	, q.text as question_text
+	, q.kind as question_kind
+	from application_answers aa
+	join job_questions q on q.id = aa.question_id
+	where aa.application_id = $1::varchar
+	order by q."position"
+`
+
+type ApplicationAnswersListByApplicationRow struct {
+	ApplicationID string
+	QuestionID    string
+	Text          string
+	Options       []string
+	CreatedAt     time.Time
+	QuestionText  string
+	QuestionKind  string
+}
+
+func (q *Queries) ApplicationAnswersListByApplication(ctx context.Context, applicationID string) ([]ApplicationAnswersListByApplicationRow, error) {
+	rows, err := q.db.QueryContext(ctx, applicationAnswersListByApplication, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationAnswersListByApplicationRow
+	for rows.Next() {
+		var i ApplicationAnswersListByApplicationRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.QuestionID,
+			&i.Text,
+			pq.Array(&i.Options),
+			&i.CreatedAt,
+			&i.QuestionText,
+			&i.QuestionKind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const applicationAnswersListByJob = `-- name: ApplicationAnswersListByJob :many
+select aa.application_id, aa.question_id, aa.text, aa.options, aa.created_at
+	, q.text as question_text
+	, q.kind as question_kind
+	from application_answers aa
+	join job_questions q on q.id = aa.question_id
+	where q.job_id = $1::varchar
+	order by aa.application_id, q."position"
+`
+
+type ApplicationAnswersListByJobRow struct {
+	ApplicationID string
+	QuestionID    string
+	Text          string
+	Options       []string
+	CreatedAt     time.Time
+	QuestionText  string
+	QuestionKind  string
+}
+
+func (q *Queries) ApplicationAnswersListByJob(ctx context.Context, jobID string) ([]ApplicationAnswersListByJobRow, error) {
+	rows, err := q.db.QueryContext(ctx, applicationAnswersListByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationAnswersListByJobRow
+	for rows.Next() {
+		var i ApplicationAnswersListByJobRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.QuestionID,
+			&i.Text,
+			pq.Array(&i.Options),
+			&i.CreatedAt,
+			&i.QuestionText,
+			&i.QuestionKind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const applicationAnswersPurge = `-- name: ApplicationAnswersPurge :exec
+DELETE FROM application_answers
+`
+
+// Handle with care!
+func (q *Queries) ApplicationAnswersPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, applicationAnswersPurge)
+	return err
+}
+
+const applicationsCountByJob = `-- name: ApplicationsCountByJob :one
+select count(*) from applications a
+where a.job_id = $1::varchar
+`
+
+func (q *Queries) ApplicationsCountByJob(ctx context.Context, jobID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, applicationsCountByJob, jobID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const jobQuestionAdd = `-- name: JobQuestionAdd :one
+insert into job_questions (
+    id, job_id, "position", kind, "text", "options", required
+) values (
+    $1, $2, $3, $4, $5, $6::varchar[], $7
+) returning id, job_id, position, kind, text, options, required, created_at
+`
+
+type JobQuestionAddParams struct {
+	ID       string
+	JobID    string
+	Position int32
+	Kind     string
+	Text     string
+	Options  []string
+	Required bool
+}
+
+func (q *Queries) JobQuestionAdd(ctx context.Context, arg JobQuestionAddParams) (JobQuestion, error) {
+	row := q.db.QueryRowContext(ctx, jobQuestionAdd,
+		arg.ID,
+		arg.JobID,
+		arg.Position,
+		arg.Kind,
+		arg.Text,
+		pq.Array(arg.Options),
+		arg.Required,
+	)
+	var i JobQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.Position,
+		&i.Kind,
+		&i.Text,
+		pq.Array(&i.Options),
+		&i.Required,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const jobQuestionsDeleteByJob = `-- name: JobQuestionsDeleteByJob :exec
+delete from job_questions where job_id = $1::varchar
+`
+
+func (q *Queries) JobQuestionsDeleteByJob(ctx context.Context, jobID string) error {
+	_, err := q.db.ExecContext(ctx, jobQuestionsDeleteByJob, jobID)
+	return err
+}
+
+const jobQuestionsListByJob = `-- name: JobQuestionsListByJob :many
+select q.id, q.job_id, q.position, q.kind, q.text, q.options, q.required, q.created_at from job_questions q
+where q.job_id = $1::varchar
+order by q."position"
+`
+
+func (q *Queries) JobQuestionsListByJob(ctx context.Context, jobID string) ([]JobQuestion, error) {
+	rows, err := q.db.QueryContext(ctx, jobQuestionsListByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobQuestion
+	for rows.Next() {
+		var i JobQuestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.Position,
+			&i.Kind,
+			&i.Text,
+			pq.Array(&i.Options),
+			&i.Required,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobQuestionsPurge = `-- name: JobQuestionsPurge :exec
+DELETE FROM job_questions
+`
+
+// Handle with care!
+func (q *Queries) JobQuestionsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, jobQuestionsPurge)
+	return err
+}
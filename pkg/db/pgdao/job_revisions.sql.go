@@ -0,0 +1,135 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: job_revisions.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const jobRevisionAdd = `-- name: JobRevisionAdd :one
+insert into job_revisions (
+    id, job_id, version, title, description, budget, duration, expires_at, created_by
+) values (
+    $1,
+    $2,
+    (select coalesce(max(r.version), 0) + 1 from job_revisions r where r.job_id = $2::varchar),
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) returning id, job_id, version, title, description, budget, duration, expires_at, created_by, created_at
+`
+
+type JobRevisionAddParams struct {
+	ID          string
+	JobID       string
+	Title       string
+	Description string
+	Budget      sql.NullString
+	Duration    sql.NullInt32
+	ExpiresAt   sql.NullTime
+	CreatedBy   string
+}
+
+func (q *Queries) JobRevisionAdd(ctx context.Context, arg JobRevisionAddParams) (JobRevision, error) {
+	row := q.db.QueryRowContext(ctx, jobRevisionAdd,
+		arg.ID,
+		arg.JobID,
+		arg.Title,
+		arg.Description,
+		arg.Budget,
+		arg.Duration,
+		arg.ExpiresAt,
+		arg.CreatedBy,
+	)
+	var i JobRevision
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.Version,
+		&i.Title,
+		&i.Description,
+		&i.Budget,
+		&i.Duration,
+		&i.ExpiresAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const jobRevisionsListByJob = `-- name: JobRevisionsListByJob :many
+select
+      r.id, r.job_id, r.version, r.title, r.description, r.budget, r.duration, r.expires_at, r.created_by, r.created_at
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS created_by_display_name
+from job_revisions r
+join persons p on p.id = r.created_by
+where r.job_id = $1::varchar
+order by r.version desc
+`
+
+type JobRevisionsListByJobRow struct {
+	ID                   string
+	JobID                string
+	Version              int32
+	Title                string
+	Description          string
+	Budget               sql.NullString
+	Duration             sql.NullInt32
+	ExpiresAt            sql.NullTime
+	CreatedBy            string
+	CreatedAt            time.Time
+	CreatedByDisplayName string
+}
+
+func (q *Queries) JobRevisionsListByJob(ctx context.Context, jobID string) ([]JobRevisionsListByJobRow, error) {
+	rows, err := q.db.QueryContext(ctx, jobRevisionsListByJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobRevisionsListByJobRow
+	for rows.Next() {
+		var i JobRevisionsListByJobRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.Version,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.ExpiresAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.CreatedByDisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobRevisionsPurge = `-- name: JobRevisionsPurge :exec
+DELETE FROM job_revisions
+`
+
+// Handle with care!
+func (q *Queries) JobRevisionsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, jobRevisionsPurge)
+	return err
+}
@@ -13,10 +13,10 @@ import (
 
 const jobAdd = `-- name: JobAdd :one
 insert into jobs (
-    id, title, description, budget, duration, created_by
+    id, title, description, budget, duration, created_by, expires_at
 ) values (
-    $1, $2, $3, $4, $5, $6
-) returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility
+    $1, $2, $3, $4, $5, $6, $7
+) returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at
 `
 
 type JobAddParams struct {
@@ -26,6 +26,7 @@ type JobAddParams struct {
 	Budget      sql.NullString
 	Duration    sql.NullInt32
 	CreatedBy   string
+	ExpiresAt   sql.NullTime
 }
 
 func (q *Queries) JobAdd(ctx context.Context, arg JobAddParams) (Job, error) {
@@ -36,6 +37,7 @@ func (q *Queries) JobAdd(ctx context.Context, arg JobAddParams) (Job, error) {
 		arg.Budget,
 		arg.Duration,
 		arg.CreatedBy,
+		arg.ExpiresAt,
 	)
 	var i Job
 	err := row.Scan(
@@ -50,6 +52,11 @@ func (q *Queries) JobAdd(ctx context.Context, arg JobAddParams) (Job, error) {
 		&i.BlockedAt,
 		&i.SuspendedAt,
 		&i.Visibility,
+		&i.ExpiresAt,
+		&i.ClosedAt,
+		&i.PublishedAt,
+		&i.PublishAt,
+		&i.AlertsMatchedAt,
 	)
 	return i, err
 }
@@ -63,11 +70,22 @@ func (q *Queries) JobBlock(ctx context.Context, id string) error {
 	return err
 }
 
+const jobCloseByApplication = `-- name: JobCloseByApplication :exec
+update jobs
+set closed_at = now()
+where id = (select a.job_id from applications a where a.id = $1::varchar) and closed_at is null
+`
+
+func (q *Queries) JobCloseByApplication(ctx context.Context, applicationID string) error {
+	_, err := q.db.ExecContext(ctx, jobCloseByApplication, applicationID)
+	return err
+}
+
 const jobFind = `-- name: JobFind :one
-select id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility from jobs where id = $1::varchar
+select id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at from jobs where id = $1::varchar
 `
 
-// It is used only for testing purposes.
+// Returns the job in any state including blocked one
 func (q *Queries) JobFind(ctx context.Context, id string) (Job, error) {
 	row := q.db.QueryRowContext(ctx, jobFind, id)
 	var i Job
@@ -83,6 +101,11 @@ func (q *Queries) JobFind(ctx context.Context, id string) (Job, error) {
 		&i.BlockedAt,
 		&i.SuspendedAt,
 		&i.Visibility,
+		&i.ExpiresAt,
+		&i.ClosedAt,
+		&i.PublishedAt,
+		&i.PublishAt,
+		&i.AlertsMatchedAt,
 	)
 	return i, err
 }
@@ -98,7 +121,20 @@ select
     ,j.created_by
     ,j.updated_at
     ,j.suspended_at
-    ,(select count(*) from applications a where a.job_id = j.id) as application_count
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
     ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
     ,p.ethereum_address AS customer_ethereum_address
     from jobs j
@@ -116,6 +152,12 @@ type JobGetRow struct {
 	CreatedBy               string
 	UpdatedAt               time.Time
 	SuspendedAt             sql.NullTime
+	Visibility              string
+	ExpiresAt               sql.NullTime
+	ClosedAt                sql.NullTime
+	PublishedAt             sql.NullTime
+	PublishAt               sql.NullTime
+	Status                  string
 	ApplicationCount        int64
 	CustomerDisplayName     string
 	CustomerEthereumAddress string
@@ -134,6 +176,97 @@ func (q *Queries) JobGet(ctx context.Context, id string) (JobGetRow, error) {
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.SuspendedAt,
+		&i.Visibility,
+		&i.ExpiresAt,
+		&i.ClosedAt,
+		&i.PublishedAt,
+		&i.PublishAt,
+		&i.Status,
+		&i.ApplicationCount,
+		&i.CustomerDisplayName,
+		&i.CustomerEthereumAddress,
+	)
+	return i, err
+}
+
+const jobGetBlocked = `-- name: JobGetBlocked :one
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from jobs j
+    join persons p on p.id = j.created_by
+    where j.id = $1::varchar and j.created_by = $2::varchar and j.blocked_at is not null
+`
+
+type JobGetBlockedParams struct {
+	ID        string
+	CreatedBy string
+}
+
+type JobGetBlockedRow struct {
+	ID                      string
+	Title                   string
+	Description             string
+	Budget                  sql.NullString
+	Duration                sql.NullInt32
+	CreatedAt               time.Time
+	CreatedBy               string
+	UpdatedAt               time.Time
+	SuspendedAt             sql.NullTime
+	Visibility              string
+	ExpiresAt               sql.NullTime
+	ClosedAt                sql.NullTime
+	PublishedAt             sql.NullTime
+	PublishAt               sql.NullTime
+	Status                  string
+	ApplicationCount        int64
+	CustomerDisplayName     string
+	CustomerEthereumAddress string
+}
+
+// The same as JobGet, but returns only the blocked job of the owner, so the owner sees its status
+func (q *Queries) JobGetBlocked(ctx context.Context, arg JobGetBlockedParams) (JobGetBlockedRow, error) {
+	row := q.db.QueryRowContext(ctx, jobGetBlocked, arg.ID, arg.CreatedBy)
+	var i JobGetBlockedRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Budget,
+		&i.Duration,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.SuspendedAt,
+		&i.Visibility,
+		&i.ExpiresAt,
+		&i.ClosedAt,
+		&i.PublishedAt,
+		&i.PublishAt,
+		&i.Status,
 		&i.ApplicationCount,
 		&i.CustomerDisplayName,
 		&i.CustomerEthereumAddress,
@@ -150,6 +283,20 @@ func (q *Queries) JobHide(ctx context.Context, id string) error {
 	return err
 }
 
+const jobMakeDraft = `-- name: JobMakeDraft :exec
+update jobs set published_at = null, publish_at = $1 where id = $2::varchar
+`
+
+type JobMakeDraftParams struct {
+	PublishAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) JobMakeDraft(ctx context.Context, arg JobMakeDraftParams) error {
+	_, err := q.db.ExecContext(ctx, jobMakeDraft, arg.PublishAt, arg.ID)
+	return err
+}
+
 const jobPatch = `-- name: JobPatch :one
 update jobs
 set
@@ -157,19 +304,26 @@ set
     description = $2::varchar,
     budget = $3::decimal,
     duration = $4::int,
+    expires_at = case
+        when $5::boolean then null
+        when $6::boolean then $7::timestamp
+        else expires_at end,
     updated_at = now()
 where
-    id = $5::varchar and $6::varchar = created_by
-returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility
+    id = $8::varchar and $9::varchar = created_by
+returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at
 `
 
 type JobPatchParams struct {
-	Title       string
-	Description string
-	Budget      string
-	Duration    int32
-	ID          string
-	Actor       string
+	Title           string
+	Description     string
+	Budget          string
+	Duration        int32
+	ClearExpiresAt  bool
+	ExpiresAtChange bool
+	ExpiresAt       time.Time
+	ID              string
+	Actor           string
 }
 
 func (q *Queries) JobPatch(ctx context.Context, arg JobPatchParams) (Job, error) {
@@ -178,6 +332,9 @@ func (q *Queries) JobPatch(ctx context.Context, arg JobPatchParams) (Job, error)
 		arg.Description,
 		arg.Budget,
 		arg.Duration,
+		arg.ClearExpiresAt,
+		arg.ExpiresAtChange,
+		arg.ExpiresAt,
 		arg.ID,
 		arg.Actor,
 	)
@@ -194,10 +351,54 @@ func (q *Queries) JobPatch(ctx context.Context, arg JobPatchParams) (Job, error)
 		&i.BlockedAt,
 		&i.SuspendedAt,
 		&i.Visibility,
+		&i.ExpiresAt,
+		&i.ClosedAt,
+		&i.PublishedAt,
+		&i.PublishAt,
+		&i.AlertsMatchedAt,
 	)
 	return i, err
 }
 
+const jobPublish = `-- name: JobPublish :exec
+update jobs
+set
+    published_at = case when $1::timestamp <= now() then now() else null end,
+    publish_at = case when $1::timestamp <= now() then null else $1::timestamp end,
+    updated_at = now()
+where id = $2::varchar and published_at is null
+`
+
+type JobPublishParams struct {
+	PublishAt time.Time
+	ID        string
+}
+
+func (q *Queries) JobPublish(ctx context.Context, arg JobPublishParams) error {
+	_, err := q.db.ExecContext(ctx, jobPublish, arg.PublishAt, arg.ID)
+	return err
+}
+
+const jobReopen = `-- name: JobReopen :exec
+update jobs
+set
+    closed_at = null,
+    suspended_at = null,
+    expires_at = $1,
+    updated_at = now()
+where id = $2::varchar
+`
+
+type JobReopenParams struct {
+	ExpiresAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) JobReopen(ctx context.Context, arg JobReopenParams) error {
+	_, err := q.db.ExecContext(ctx, jobReopen, arg.ExpiresAt, arg.ID)
+	return err
+}
+
 const jobResume = `-- name: JobResume :exec
 update jobs set suspended_at = null where id = $1::varchar
 `
@@ -207,6 +408,20 @@ func (q *Queries) JobResume(ctx context.Context, id string) error {
 	return err
 }
 
+const jobSetVisibility = `-- name: JobSetVisibility :exec
+update jobs set visibility = $1::varchar where id = $2::varchar
+`
+
+type JobSetVisibilityParams struct {
+	Visibility string
+	ID         string
+}
+
+func (q *Queries) JobSetVisibility(ctx context.Context, arg JobSetVisibilityParams) error {
+	_, err := q.db.ExecContext(ctx, jobSetVisibility, arg.Visibility, arg.ID)
+	return err
+}
+
 const jobSuspend = `-- name: JobSuspend :exec
 update jobs set suspended_at = now() where id = $1::varchar
 `
@@ -216,6 +431,63 @@ func (q *Queries) JobSuspend(ctx context.Context, id string) error {
 	return err
 }
 
+const jobUnblock = `-- name: JobUnblock :exec
+update jobs set blocked_at = null where id = $1::varchar
+`
+
+func (q *Queries) JobUnblock(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, jobUnblock, id)
+	return err
+}
+
+const jobsClaimForAlerts = `-- name: JobsClaimForAlerts :many
+update jobs
+set alerts_matched_at = now()
+where alerts_matched_at is null and published_at is not null
+returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at
+`
+
+// Marks published jobs which have not been matched against saved searches yet
+func (q *Queries) JobsClaimForAlerts(ctx context.Context) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, jobsClaimForAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.BlockedAt,
+			&i.SuspendedAt,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.ClosedAt,
+			&i.PublishedAt,
+			&i.PublishAt,
+			&i.AlertsMatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const jobsList = `-- name: JobsList :many
 select
      j.id
@@ -226,15 +498,32 @@ select
     ,j.created_at
     ,j.created_by
     ,j.updated_at
-    ,(select count(*) from applications a where a.job_id = j.id) as application_count
+    ,j.expires_at
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
     ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
     ,p.ethereum_address AS customer_ethereum_address
     from jobs j
     join persons p on p.id = j.created_by
-    where j.blocked_at is null and j.suspended_at is null and j.visibility = 'public'
+    where j.blocked_at is null and j.suspended_at is null and j.closed_at is null
+        and j.published_at is not null
+        and (j.expires_at is null or j.expires_at > now())
+        and j.visibility = 'public'
+        and ($1::varchar = ''
+            or strpos(lower(j.title), lower($1::varchar)) > 0
+            or strpos(lower(j.description), lower($1::varchar)) > 0)
+        and ($2::decimal = 0 or j.budget >= $2::decimal)
+        and ($3::decimal = 0 or j.budget <= $3::decimal)
     order by j.updated_at desc
+    limit nullif($4::int, 0)
 `
 
+type JobsListParams struct {
+	Keyword   string
+	BudgetMin string
+	BudgetMax string
+	Lim       int32
+}
+
 type JobsListRow struct {
 	ID                      string
 	Title                   string
@@ -244,13 +533,20 @@ type JobsListRow struct {
 	CreatedAt               time.Time
 	CreatedBy               string
 	UpdatedAt               time.Time
+	ExpiresAt               sql.NullTime
 	ApplicationCount        int64
 	CustomerDisplayName     string
 	CustomerEthereumAddress string
 }
 
-func (q *Queries) JobsList(ctx context.Context) ([]JobsListRow, error) {
-	rows, err := q.db.QueryContext(ctx, jobsList)
+// Open public jobs matching the filter, empty keyword and zero budget limits mean no filtering, zero lim means no limit
+func (q *Queries) JobsList(ctx context.Context, arg JobsListParams) ([]JobsListRow, error) {
+	rows, err := q.db.QueryContext(ctx, jobsList,
+		arg.Keyword,
+		arg.BudgetMin,
+		arg.BudgetMax,
+		arg.Lim,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +563,104 @@ func (q *Queries) JobsList(ctx context.Context) ([]JobsListRow, error) {
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.ApplicationCount,
+			&i.CustomerDisplayName,
+			&i.CustomerEthereumAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobsListByOwner = `-- name: JobsListByOwner :many
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from jobs j
+    join persons p on p.id = j.created_by
+    where j.created_by = $1::varchar
+    order by j.created_at desc
+`
+
+type JobsListByOwnerRow struct {
+	ID                      string
+	Title                   string
+	Description             string
+	Budget                  sql.NullString
+	Duration                sql.NullInt32
+	CreatedAt               time.Time
+	CreatedBy               string
+	UpdatedAt               time.Time
+	SuspendedAt             sql.NullTime
+	Visibility              string
+	ExpiresAt               sql.NullTime
+	ClosedAt                sql.NullTime
+	PublishedAt             sql.NullTime
+	PublishAt               sql.NullTime
+	Status                  string
+	ApplicationCount        int64
+	CustomerDisplayName     string
+	CustomerEthereumAddress string
+}
+
+// Owner's jobs in every state. Columns must be the same as in JobGet
+func (q *Queries) JobsListByOwner(ctx context.Context, createdBy string) ([]JobsListByOwnerRow, error) {
+	rows, err := q.db.QueryContext(ctx, jobsListByOwner, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobsListByOwnerRow
+	for rows.Next() {
+		var i JobsListByOwnerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.SuspendedAt,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.ClosedAt,
+			&i.PublishedAt,
+			&i.PublishAt,
+			&i.Status,
 			&i.ApplicationCount,
 			&i.CustomerDisplayName,
 			&i.CustomerEthereumAddress,
@@ -284,6 +678,54 @@ func (q *Queries) JobsList(ctx context.Context) ([]JobsListRow, error) {
 	return items, nil
 }
 
+const jobsPublishScheduled = `-- name: JobsPublishScheduled :many
+update jobs
+set published_at = now(), publish_at = null, updated_at = now()
+where published_at is null and publish_at <= now() and blocked_at is null
+returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at
+`
+
+// Publishes all drafts which publication moment has come
+func (q *Queries) JobsPublishScheduled(ctx context.Context) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, jobsPublishScheduled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.BlockedAt,
+			&i.SuspendedAt,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.ClosedAt,
+			&i.PublishedAt,
+			&i.PublishAt,
+			&i.AlertsMatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const jobsPurge = `-- name: JobsPurge :exec
 DELETE FROM jobs
 `
@@ -293,3 +735,52 @@ func (q *Queries) JobsPurge(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, jobsPurge)
 	return err
 }
+
+const jobsSuspendExpired = `-- name: JobsSuspendExpired :many
+update jobs
+set suspended_at = now()
+where expires_at <= now() and suspended_at is null and closed_at is null and blocked_at is null
+    and published_at is not null
+returning id, title, description, budget, duration, created_at, updated_at, created_by, blocked_at, suspended_at, visibility, expires_at, closed_at, published_at, publish_at, alerts_matched_at
+`
+
+// Suspends all open jobs which expiration moment has come
+func (q *Queries) JobsSuspendExpired(ctx context.Context) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, jobsSuspendExpired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.BlockedAt,
+			&i.SuspendedAt,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.ClosedAt,
+			&i.PublishedAt,
+			&i.PublishAt,
+			&i.AlertsMatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
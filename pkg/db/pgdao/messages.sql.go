@@ -7,6 +7,8 @@ package pgdao
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -15,7 +17,7 @@ insert into messages (
     id, chat_id, created_by, text
 ) values (
     $1, $2, $3, $4
-) returning id, chat_id, created_at, created_by, text
+) returning id, chat_id, created_at, created_by, text, edited_at, deleted_at, kind, payload
 `
 
 type MessageAddParams struct {
@@ -39,13 +41,249 @@ func (q *Queries) MessageAdd(ctx context.Context, arg MessageAddParams) (Message
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const messageAddSystem = `-- name: MessageAddSystem :one
+insert into messages (
+    id, chat_id, created_by, text, kind, payload
+) values (
+    $1, $2, $3, $4, 'system', $5
+) returning id, chat_id, created_at, created_by, text, edited_at, deleted_at, kind, payload
+`
+
+type MessageAddSystemParams struct {
+	ID        string
+	ChatID    string
+	CreatedBy string
+	Text      string
+	Payload   json.RawMessage
+}
+
+// The text is a human readable description of the event for clients which do not know the event
+func (q *Queries) MessageAddSystem(ctx context.Context, arg MessageAddSystemParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, messageAddSystem,
+		arg.ID,
+		arg.ChatID,
+		arg.CreatedBy,
+		arg.Text,
+		arg.Payload,
+	)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const messageDelete = `-- name: MessageDelete :one
+update messages set
+    "text" = '',
+    deleted_at = now()
+where id = $1::varchar
+returning id, chat_id, created_at, created_by, text, edited_at, deleted_at, kind, payload
+`
+
+func (q *Queries) MessageDelete(ctx context.Context, id string) (Message, error) {
+	row := q.db.QueryRowContext(ctx, messageDelete, id)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const messageGet = `-- name: MessageGet :one
+select m.id, m.chat_id, m.created_at, m.created_by, m.text, m.edited_at, m.deleted_at, m.kind, m.payload from messages m
+where m.id = $1::varchar
+`
+
+func (q *Queries) MessageGet(ctx context.Context, id string) (Message, error) {
+	row := q.db.QueryRowContext(ctx, messageGet, id)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const messageGetWithAuthor = `-- name: MessageGetWithAuthor :one
+select
+     m.id, m.chat_id, m.created_at, m.created_by, m.text, m.edited_at, m.deleted_at, m.kind, m.payload
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.id = $1::varchar
+`
+
+type MessageGetWithAuthorRow struct {
+	ID          string
+	ChatID      string
+	CreatedAt   time.Time
+	CreatedBy   string
+	Text        string
+	EditedAt    sql.NullTime
+	DeletedAt   sql.NullTime
+	Kind        string
+	Payload     json.RawMessage
+	DisplayName string
+}
+
+func (q *Queries) MessageGetWithAuthor(ctx context.Context, id string) (MessageGetWithAuthorRow, error) {
+	row := q.db.QueryRowContext(ctx, messageGetWithAuthor, id)
+	var i MessageGetWithAuthorRow
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
+		&i.DisplayName,
+	)
+	return i, err
+}
+
+const messageRevisionAdd = `-- name: MessageRevisionAdd :one
+insert into message_revisions (
+    id, message_id, version, "text"
+) values (
+    $1,
+    $2,
+    (select coalesce(max(r.version), 0) + 1 from message_revisions r where r.message_id = $2::varchar),
+    $3
+) returning id, message_id, version, text, created_at
+`
+
+type MessageRevisionAddParams struct {
+	ID        string
+	MessageID string
+	Text      string
+}
+
+func (q *Queries) MessageRevisionAdd(ctx context.Context, arg MessageRevisionAddParams) (MessageRevision, error) {
+	row := q.db.QueryRowContext(ctx, messageRevisionAdd, arg.ID, arg.MessageID, arg.Text)
+	var i MessageRevision
+	err := row.Scan(
+		&i.ID,
+		&i.MessageID,
+		&i.Version,
+		&i.Text,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const messageRevisionsListByMessage = `-- name: MessageRevisionsListByMessage :many
+select r.id, r.message_id, r.version, r.text, r.created_at from message_revisions r
+where r.message_id = $1::varchar
+order by r.version desc
+`
+
+func (q *Queries) MessageRevisionsListByMessage(ctx context.Context, messageID string) ([]MessageRevision, error) {
+	rows, err := q.db.QueryContext(ctx, messageRevisionsListByMessage, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessageRevision
+	for rows.Next() {
+		var i MessageRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Version,
+			&i.Text,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messageRevisionsPurge = `-- name: MessageRevisionsPurge :exec
+DELETE FROM message_revisions
+`
+
+// Handle with care!
+func (q *Queries) MessageRevisionsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, messageRevisionsPurge)
+	return err
+}
+
+const messageUpdateText = `-- name: MessageUpdateText :one
+update messages set
+    "text" = $1,
+    edited_at = now()
+where id = $2::varchar
+returning id, chat_id, created_at, created_by, text, edited_at, deleted_at, kind, payload
+`
+
+type MessageUpdateTextParams struct {
+	Text string
+	ID   string
+}
+
+func (q *Queries) MessageUpdateText(ctx context.Context, arg MessageUpdateTextParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, messageUpdateText, arg.Text, arg.ID)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Text,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.Kind,
+		&i.Payload,
 	)
 	return i, err
 }
 
 const messagesListByChat = `-- name: MessagesListByChat :many
 select 
-     m.id, m.chat_id, m.created_at, m.created_by, m.text
+     m.id, m.chat_id, m.created_at, m.created_by, m.text, m.edited_at, m.deleted_at, m.kind, m.payload
     ,p.display_name
 from messages m 
     join persons p on m.created_by = p.id
@@ -59,6 +297,10 @@ type MessagesListByChatRow struct {
 	CreatedAt   time.Time
 	CreatedBy   string
 	Text        string
+	EditedAt    sql.NullTime
+	DeletedAt   sql.NullTime
+	Kind        string
+	Payload     json.RawMessage
 	DisplayName string
 }
 
@@ -77,6 +319,172 @@ func (q *Queries) MessagesListByChat(ctx context.Context, chatID string) ([]Mess
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.Text,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.Kind,
+			&i.Payload,
+			&i.DisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messagesListByChatBackward = `-- name: MessagesListByChatBackward :many
+select
+     m.id, m.chat_id, m.created_at, m.created_by, m.text, m.edited_at, m.deleted_at, m.kind, m.payload
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.chat_id = $1::varchar
+    and (not $2::boolean or m.created_at < $3::timestamp or (m.created_at = $3::timestamp and m.id < $4::varchar))
+    and (not $5::boolean or m.created_at > $6::timestamp or (m.created_at = $6::timestamp and m.id > $7::varchar))
+order by m.created_at desc, m.id desc
+limit $8::int
+`
+
+type MessagesListByChatBackwardParams struct {
+	ChatID    string
+	HasBefore bool
+	BeforeAt  time.Time
+	BeforeID  string
+	HasAfter  bool
+	AfterAt   time.Time
+	AfterID   string
+	Lim       int32
+}
+
+type MessagesListByChatBackwardRow struct {
+	ID          string
+	ChatID      string
+	CreatedAt   time.Time
+	CreatedBy   string
+	Text        string
+	EditedAt    sql.NullTime
+	DeletedAt   sql.NullTime
+	Kind        string
+	Payload     json.RawMessage
+	DisplayName string
+}
+
+// Returns messages of the chat newest first, before and after bounds are applied if they are set
+func (q *Queries) MessagesListByChatBackward(ctx context.Context, arg MessagesListByChatBackwardParams) ([]MessagesListByChatBackwardRow, error) {
+	rows, err := q.db.QueryContext(ctx, messagesListByChatBackward,
+		arg.ChatID,
+		arg.HasBefore,
+		arg.BeforeAt,
+		arg.BeforeID,
+		arg.HasAfter,
+		arg.AfterAt,
+		arg.AfterID,
+		arg.Lim,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessagesListByChatBackwardRow
+	for rows.Next() {
+		var i MessagesListByChatBackwardRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Text,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.Kind,
+			&i.Payload,
+			&i.DisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messagesListByChatForward = `-- name: MessagesListByChatForward :many
+select
+     m.id, m.chat_id, m.created_at, m.created_by, m.text, m.edited_at, m.deleted_at, m.kind, m.payload
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.chat_id = $1::varchar
+    and (not $2::boolean or m.created_at < $3::timestamp or (m.created_at = $3::timestamp and m.id < $4::varchar))
+    and (not $5::boolean or m.created_at > $6::timestamp or (m.created_at = $6::timestamp and m.id > $7::varchar))
+order by m.created_at asc, m.id asc
+limit $8::int
+`
+
+type MessagesListByChatForwardParams struct {
+	ChatID    string
+	HasBefore bool
+	BeforeAt  time.Time
+	BeforeID  string
+	HasAfter  bool
+	AfterAt   time.Time
+	AfterID   string
+	Lim       int32
+}
+
+type MessagesListByChatForwardRow struct {
+	ID          string
+	ChatID      string
+	CreatedAt   time.Time
+	CreatedBy   string
+	Text        string
+	EditedAt    sql.NullTime
+	DeletedAt   sql.NullTime
+	Kind        string
+	Payload     json.RawMessage
+	DisplayName string
+}
+
+// Returns messages of the chat oldest first, before and after bounds are applied if they are set
+func (q *Queries) MessagesListByChatForward(ctx context.Context, arg MessagesListByChatForwardParams) ([]MessagesListByChatForwardRow, error) {
+	rows, err := q.db.QueryContext(ctx, messagesListByChatForward,
+		arg.ChatID,
+		arg.HasBefore,
+		arg.BeforeAt,
+		arg.BeforeID,
+		arg.HasAfter,
+		arg.AfterAt,
+		arg.AfterID,
+		arg.Lim,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessagesListByChatForwardRow
+	for rows.Next() {
+		var i MessagesListByChatForwardRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Text,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.Kind,
+			&i.Payload,
 			&i.DisplayName,
 		); err != nil {
 			return nil, err
@@ -101,3 +509,165 @@ func (q *Queries) MessagesPurge(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, messagesPurge)
 	return err
 }
+
+const messagesSearch = `-- name: MessagesSearch :many
+select
+     m.id
+    ,m.chat_id
+    ,m.created_at
+    ,m.created_by
+    ,m.text
+    ,m.kind
+    ,m.payload
+    ,m.edited_at
+    ,p.display_name
+    ,c.topic
+    ,coalesce(j.id, '')::varchar as job_id
+    ,coalesce(j.title, '')::varchar as job_title
+    ,ts_headline('english',
+        replace(replace(replace(m.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+        q.query,
+        'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::varchar as snippet
+from messages m
+    join chats_participants cp on cp.chat_id = m.chat_id and cp.person_id = $1::varchar
+    join chats c on c.id = m.chat_id
+    join persons p on p.id = m.created_by
+    cross join websearch_to_tsquery('english', $2::varchar) q(query)
+    left join applications a on split_part(c.topic, ':', 2) = 'application' and a.id = split_part(c.topic, ':', 3)
+    left join contracts ct on split_part(c.topic, ':', 2) = 'contract' and ct.id = split_part(c.topic, ':', 3)
+    left join applications ca on ca.id = ct.application_id
+    left join job_invitations i on split_part(c.topic, ':', 2) = 'invitation' and i.id = split_part(c.topic, ':', 3)
+    left join jobs j on j.id = coalesce(a.job_id, ca.job_id, i.job_id)
+where m.deleted_at is null
+    and to_tsvector('english', m.text) @@ q.query
+order by m.created_at desc, m.id desc
+limit $3::int
+`
+
+type MessagesSearchParams struct {
+	ParticipantID string
+	Query         string
+	Lim           int32
+}
+
+type MessagesSearchRow struct {
+	ID          string
+	ChatID      string
+	CreatedAt   time.Time
+	CreatedBy   string
+	Text        string
+	Kind        string
+	Payload     json.RawMessage
+	EditedAt    sql.NullTime
+	DisplayName string
+	Topic       string
+	JobID       string
+	JobTitle    string
+	Snippet     string
+}
+
+// Full-text search over messages of chats of the participant, the newest first.
+// The snippet is HTML-escaped text of the message with matches marked by <mark> tag.
+func (q *Queries) MessagesSearch(ctx context.Context, arg MessagesSearchParams) ([]MessagesSearchRow, error) {
+	rows, err := q.db.QueryContext(ctx, messagesSearch, arg.ParticipantID, arg.Query, arg.Lim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessagesSearchRow
+	for rows.Next() {
+		var i MessagesSearchRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Text,
+			&i.Kind,
+			&i.Payload,
+			&i.EditedAt,
+			&i.DisplayName,
+			&i.Topic,
+			&i.JobID,
+			&i.JobTitle,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messagesUnreadForDigest = `-- name: MessagesUnreadForDigest :many
+select
+     m.id
+    ,m.chat_id
+    ,m.created_at
+    ,m.text
+    ,m.kind
+    ,m.payload
+    ,p.display_name
+    ,c.topic
+from chats_participants cp
+    join persons me on me.id = cp.person_id
+    join messages m on m.chat_id = cp.chat_id
+    join chats c on c.id = m.chat_id
+    join persons p on p.id = m.created_by
+where cp.person_id = $1::varchar
+    and m.created_by <> cp.person_id
+    and m.deleted_at is null
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and (me.email_digest_sent_at is null or m.created_at > me.email_digest_sent_at)
+order by m.chat_id, m.created_at, m.id
+`
+
+type MessagesUnreadForDigestRow struct {
+	ID          string
+	ChatID      string
+	CreatedAt   time.Time
+	Text        string
+	Kind        string
+	Payload     json.RawMessage
+	DisplayName string
+	Topic       string
+}
+
+// Unread messages of other participants in chats of the person created after the previous digest, oldest first
+func (q *Queries) MessagesUnreadForDigest(ctx context.Context, participantID string) ([]MessagesUnreadForDigestRow, error) {
+	rows, err := q.db.QueryContext(ctx, messagesUnreadForDigest, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessagesUnreadForDigestRow
+	for rows.Next() {
+		var i MessagesUnreadForDigestRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.CreatedAt,
+			&i.Text,
+			&i.Kind,
+			&i.Payload,
+			&i.DisplayName,
+			&i.Topic,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -10,6 +10,56 @@ import (
 	"time"
 )
 
+// Reports of users about spam and abuse, it is a moderation queue for admins
+type AbuseReport struct {
+	// PK
+	ID string
+	// Kind of the reported entity: job, person or message
+	TargetKind string
+	// ID of the reported entity
+	TargetID string
+	// Reason of the report: spam, fraud, abuse or other
+	Reason string
+	// Details from the reporter
+	Comment string
+	// Person who reported
+	CreatedBy string
+	// Creation timestamp
+	CreatedAt time.Time
+	// Status of the report: open, resolved or dismissed
+	Status string
+	// Admin who reviewed the report
+	ResolvedBy sql.NullString
+	// Review timestamp
+	ResolvedAt sql.NullTime
+	// Admin comment on the review
+	Resolution string
+}
+
+// Personal API keys of persons for integrations, a key gives access only to routes of its scopes
+type ApiKey struct {
+	// PK
+	ID string
+	// Owner of the key, requests with the key are made on behalf of the person
+	PersonID string
+	// Name of the key given by the owner
+	Name string
+	// SHA-256 hex of the key, the key itself is not stored
+	TokenHash string
+	// The beginning of the key to recognize it in the list
+	TokenPrefix string
+	// Scopes granted to the key, like jobs:write
+	Scopes []string
+	// IP addresses and CIDR ranges the key is accepted from, empty means any address
+	IpAllowlist []string
+	// Creation timestamp
+	CreatedAt time.Time
+	// When the key was used the last time
+	LastUsedAt sql.NullTime
+	// The key is not valid after this moment, null means the key does not expire
+	ExpiresAt sql.NullTime
+}
+
 // Applications for job offers
 type Application struct {
 	// PK
@@ -26,6 +76,88 @@ type Application struct {
 	JobID string
 	// Potential performer
 	ApplicantID string
+	// When the applicant withdrew the application. The applicant is able to apply again after withdrawal.
+	WithdrawnAt sql.NullTime
+	// Stage of the application in the pipeline of the customer: new, shortlisted, interviewing, rejected or hired
+	Status string
+	// Optional reason explained to the applicant when the application is rejected
+	RejectionReason string
+	// When the customer changed the status last time
+	StatusChangedAt sql.NullTime
+}
+
+// Answers of the applicant to screening questions of the job
+type ApplicationAnswer struct {
+	// Application the answer belongs to
+	ApplicationID string
+	// Answered question
+	QuestionID string
+	// Answer to a text question
+	Text string
+	// Chosen options of a single_choice or multi_choice question
+	Options []string
+	// Creation timestamp
+	CreatedAt time.Time
+}
+
+// History of application edits. Every revision is a snapshot of the application terms
+type ApplicationRevision struct {
+	// PK
+	ID string
+	// Application the revision belongs to
+	ApplicationID string
+	// Sequential number of the revision within the application, starting from 1
+	Version int32
+	// Applicant's comment in this revision
+	Comment string
+	// Proposed price in this revision
+	Price string
+	// Revision timestamp
+	CreatedAt time.Time
+}
+
+// Files attached to jobs, applications and chat messages
+type Attachment struct {
+	// PK
+	ID string
+	// Person who uploaded the file
+	CreatedBy string
+	// Upload timestamp
+	CreatedAt time.Time
+	// Original file name
+	Name string
+	// MIME type detected by the content
+	ContentType string
+	// File size in bytes
+	Size int64
+	// Key of the file content in the storage backend
+	StorageKey string
+	// Job the file is attached to
+	JobID sql.NullString
+	// Application the file is attached to
+	ApplicationID sql.NullString
+	// Chat message the file is attached to
+	MessageID sql.NullString
+	// Key of the preview of the image in the storage backend, null if the file is not an image
+	ThumbnailKey sql.NullString
+}
+
+// Record of privileged actions: who did what and why
+type AuditLog struct {
+	// PK
+	ID string
+	// Person who performed the action
+	ActorID string
+	// Action name like block or unblock
+	Action string
+	// Kind of the affected entity: job, person etc.
+	TargetKind string
+	// ID of the affected entity
+	TargetID string
+	// Why the action was performed
+	Reason string
+	// Action timestamp
+	CreatedAt time.Time
 }
 
 // Chats where users have conversations
@@ -38,12 +170,24 @@ type Chat struct {
 	CreatedAt time.Time
 }
 
+// Short-lived single-use tickets authenticating WebSocket handshakes of chat events, so the access token is not passed in the URL
+type ChatTicket struct {
+	// SHA-256 hex of the ticket, the ticket itself is not stored
+	TokenHash string
+	// Person who requested the ticket
+	PersonID string
+	// The ticket is not valid after this moment
+	ExpiresAt time.Time
+}
+
 // Participants in chats
 type ChatsParticipant struct {
 	// Chat where user joined
 	ChatID string
 	// User
 	PersonID string
+	// Messages of other participants created before this moment are read by the participant
+	LastReadAt sql.NullTime
 }
 
 // Contracts table
@@ -80,6 +224,24 @@ type Contract struct {
 	ContractAddress string
 }
 
+// Temporary access tokens which let admins act as other persons for support
+type Impersonation struct {
+	// PK
+	ID string
+	// SHA-256 hex of the access token of the impersonation, the token itself is not stored
+	TokenHash string
+	// Admin who impersonates
+	AdminID string
+	// Person who is impersonated
+	PersonID string
+	// Why the admin needs to act as the person
+	Reason string
+	// Creation timestamp
+	CreatedAt time.Time
+	// The token is not valid after this moment
+	ExpiresAt time.Time
+}
+
 // Job offer table
 type Job struct {
 	// PK
@@ -103,6 +265,82 @@ type Job struct {
 	SuspendedAt sql.NullTime
 	// Job visibility. Like public and hidden.
 	Visibility string
+	// Moment after which the job stops accepting applications and becomes suspended.
+	ExpiresAt sql.NullTime
+	// Moment when the job was closed. The job is closed automatically when a contract for it becomes funded.
+	ClosedAt sql.NullTime
+	// Moment when the job was published. Job is a draft until it is published.
+	PublishedAt sql.NullTime
+	// Moment when the draft should be published automatically.
+	PublishAt sql.NullTime
+	// When the job was matched against saved searches, the job is waiting for matching if null
+	AlertsMatchedAt sql.NullTime
+}
+
+// Invitations sent by customers to persons to apply for their jobs
+type JobInvitation struct {
+	// PK
+	ID string
+	// Job the person is invited to
+	JobID string
+	// Invited person
+	PersonID string
+	// Customer who sent the invitation
+	CreatedBy string
+	// Creation timestamp
+	CreatedAt time.Time
+	// Personal message from the customer
+	Message string
+	// Invitation status: pending, accepted or declined
+	Status string
+	// When the invited person accepted or declined the invitation
+	RespondedAt sql.NullTime
+	// Application created when the invitation was accepted
+	ApplicationID sql.NullString
+}
+
+// Screening questions which applicants answer when they apply for the job
+type JobQuestion struct {
+	// PK
+	ID string
+	// Job the question belongs to
+	JobID string
+	// Order of the question in the job starting from 1
+	Position int32
+	// Kind of the answer: text, single_choice or multi_choice
+	Kind string
+	// Text of the question
+	Text string
+	// Options to choose from for single_choice and multi_choice questions
+	Options []string
+	// Whether the applicant has to answer the question
+	Required bool
+	// Creation timestamp
+	CreatedAt time.Time
+}
+
+// History of job edits. Every revision is a snapshot of the job terms
+type JobRevision struct {
+	// PK
+	ID string
+	// Job the revision belongs to
+	JobID string
+	// Sequential number of the revision within the job, starting from 1
+	Version int32
+	// Job title in this revision
+	Title string
+	// Job description in this revision
+	Description string
+	// Job budget in this revision
+	Budget sql.NullString
+	// Job duration in this revision
+	Duration sql.NullInt32
+	// Job expiration timestamp in this revision
+	ExpiresAt sql.NullTime
+	// Person who made the revision
+	CreatedBy string
+	// Revision timestamp
+	CreatedAt time.Time
 }
 
 // Messages were sent in chats by users
@@ -117,6 +355,28 @@ type Message struct {
 	CreatedBy string
 	// It is a message text, in fact
 	Text string
+	// When the author edited the message last time
+	EditedAt sql.NullTime
+	// When the author deleted the message, the text of deleted message is kept in revisions only
+	DeletedAt sql.NullTime
+	// Kind of the message: user writes text, system describes an event with payload. System message can not be edited or deleted
+	Kind string
+	// Structured description of the event for system messages, like {"event":"contract_status_changed","contract_id":"...","old_status":"created","new_status":"accepted"}
+	Payload json.RawMessage
+}
+
+// Previous versions of edited and deleted messages kept for moderation
+type MessageRevision struct {
+	// PK
+	ID string
+	// Message the revision belongs to
+	MessageID string
+	// Sequential number of the revision within the message, starting from 1
+	Version int32
+	// Text of the message before the change
+	Text string
+	// When the text was replaced
+	CreatedAt time.Time
 }
 
 // Person who can pay, get or earn money
@@ -139,8 +399,104 @@ type Person struct {
 	EthereumAddress string
 	// Person's resources list. They may be links to social networks, portfolio, messenger IDs etc
 	Resources json.RawMessage
-	// Person's personal access token for Bearer authentication schema
-	AccessToken sql.NullString
 	// Does user have admin privileges?
 	IsAdmin bool
+	// Person is blocked and unable to log in if this field is not null
+	BlockedAt sql.NullTime
+	// Balance of connects which are spent on applications
+	Connects int32
+	// When the balance was topped up to the weekly allowance last time
+	ConnectsRefilledAt sql.NullTime
+	// How often the email digest of unread messages is sent: never, hourly, daily or weekly. Persons opt in to digests
+	EmailDigest string
+	// When the last email digest was sent, messages created before are not included into the next one
+	EmailDigestSentAt sql.NullTime
+}
+
+// Persons blocked by other persons, blocked person is unable to contact the blocking one
+type PersonBlock struct {
+	// Person who blocked
+	PersonID string
+	// Blocked person
+	BlockedID string
+	// Creation timestamp
+	CreatedAt time.Time
+}
+
+// In-app notifications addressed to a specific person
+type PersonNotification struct {
+	// PK
+	ID string
+	// Recipient
+	PersonID string
+	// Notification kind. Like job_invitation
+	Kind string
+	// Human readable notification text
+	Text string
+	// URN of the entity the notification is about
+	Link string
+	// Creation timestamp
+	CreatedAt time.Time
+	// When the recipient has read the notification
+	ReadAt sql.NullTime
+}
+
+// Jobs bookmarked by persons
+type SavedJob struct {
+	// Person who saved the job
+	PersonID string
+	// Saved job
+	JobID string
+	// Creation timestamp
+	CreatedAt time.Time
+}
+
+// Search criteria saved by persons to get alerts about new jobs
+type SavedSearch struct {
+	// PK
+	ID string
+	// Owner of the search
+	PersonID string
+	// Name of the search for the owner
+	Title string
+	// Text which the job title or description has to contain, any job matches if empty
+	Keyword string
+	// Minimal job budget, not limited if null
+	BudgetMin sql.NullString
+	// Maximal job budget, not limited if null
+	BudgetMax sql.NullString
+	// Creation timestamp
+	CreatedAt time.Time
+	// Modification timestamp
+	UpdatedAt time.Time
+}
+
+// Logged in sessions of persons, a person may have many sessions on different devices
+type Session struct {
+	// PK
+	ID string
+	// Person who logged in
+	PersonID string
+	// SHA-256 hex of the access token, the token itself is not stored
+	TokenHash string
+	// Creation timestamp
+	CreatedAt time.Time
+	// When the session was used the last time
+	LastUsedAt time.Time
+	// The session is not valid after this moment, it is prolonged on use
+	ExpiresAt time.Time
+	// User agent of the client which started the session
+	UserAgent string
+	// IP address of the client which started the session
+	Ip string
+}
+
+// Secrets of unsubscribe links in emails, every email has its own link
+type UnsubscribeToken struct {
+	// SHA-256 hex of the token, the token itself is not stored
+	TokenHash string
+	// Person who receives the email
+	PersonID string
+	// When the email was sent, links of too old emails stop working
+	CreatedAt time.Time
 }
@@ -0,0 +1,123 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: notifications.sql
+
+package pgdao
+
+import (
+	"context"
+)
+
+const personNotificationAdd = `-- name: PersonNotificationAdd :one
+insert into person_notifications (
+    id, person_id, kind, "text", link
+) values (
+    $1, $2, $3, $4, $5
+) returning id, person_id, kind, text, link, created_at, read_at
+`
+
+type PersonNotificationAddParams struct {
+	ID       string
+	PersonID string
+	Kind     string
+	Text     string
+	Link     string
+}
+
+func (q *Queries) PersonNotificationAdd(ctx context.Context, arg PersonNotificationAddParams) (PersonNotification, error) {
+	row := q.db.QueryRowContext(ctx, personNotificationAdd,
+		arg.ID,
+		arg.PersonID,
+		arg.Kind,
+		arg.Text,
+		arg.Link,
+	)
+	var i PersonNotification
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Kind,
+		&i.Text,
+		&i.Link,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const personNotificationMarkRead = `-- name: PersonNotificationMarkRead :one
+update person_notifications
+set
+    read_at = coalesce(read_at, now())
+where
+    id = $1::varchar and person_id = $2::varchar
+returning id, person_id, kind, text, link, created_at, read_at
+`
+
+type PersonNotificationMarkReadParams struct {
+	ID       string
+	PersonID string
+}
+
+func (q *Queries) PersonNotificationMarkRead(ctx context.Context, arg PersonNotificationMarkReadParams) (PersonNotification, error) {
+	row := q.db.QueryRowContext(ctx, personNotificationMarkRead, arg.ID, arg.PersonID)
+	var i PersonNotification
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Kind,
+		&i.Text,
+		&i.Link,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const personNotificationsListByPerson = `-- name: PersonNotificationsListByPerson :many
+select n.id, n.person_id, n.kind, n.text, n.link, n.created_at, n.read_at from person_notifications n
+where n.person_id = $1::varchar
+order by n.created_at desc
+`
+
+func (q *Queries) PersonNotificationsListByPerson(ctx context.Context, personID string) ([]PersonNotification, error) {
+	rows, err := q.db.QueryContext(ctx, personNotificationsListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PersonNotification
+	for rows.Next() {
+		var i PersonNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.Kind,
+			&i.Text,
+			&i.Link,
+			&i.CreatedAt,
+			&i.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const personNotificationsPurge = `-- name: PersonNotificationsPurge :exec
+DELETE FROM person_notifications
+`
+
+// Handle with care!
+func (q *Queries) PersonNotificationsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, personNotificationsPurge)
+	return err
+}
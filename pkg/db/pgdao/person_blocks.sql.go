@@ -0,0 +1,145 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: person_blocks.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+)
+
+const chatBlockedForPerson = `-- name: ChatBlockedForPerson :one
+select exists (
+    select 1 from chats_participants cp
+        join person_blocks b on b.person_id = cp.person_id
+    where cp.chat_id = $1::varchar and b.blocked_id = $2::varchar
+)::boolean as blocked
+`
+
+type ChatBlockedForPersonParams struct {
+	ChatID   string
+	PersonID string
+}
+
+// Returns true if any other participant of the chat has blocked the person
+func (q *Queries) ChatBlockedForPerson(ctx context.Context, arg ChatBlockedForPersonParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, chatBlockedForPerson, arg.ChatID, arg.PersonID)
+	var blocked bool
+	err := row.Scan(&blocked)
+	return blocked, err
+}
+
+const personBlockAdd = `-- name: PersonBlockAdd :exec
+insert into person_blocks (
+    person_id, blocked_id
+) values (
+    $1, $2
+) on conflict do nothing
+`
+
+type PersonBlockAddParams struct {
+	PersonID  string
+	BlockedID string
+}
+
+func (q *Queries) PersonBlockAdd(ctx context.Context, arg PersonBlockAddParams) error {
+	_, err := q.db.ExecContext(ctx, personBlockAdd, arg.PersonID, arg.BlockedID)
+	return err
+}
+
+const personBlockDelete = `-- name: PersonBlockDelete :execrows
+delete from person_blocks where person_id = $1::varchar and blocked_id = $2::varchar
+`
+
+type PersonBlockDeleteParams struct {
+	PersonID  string
+	BlockedID string
+}
+
+func (q *Queries) PersonBlockDelete(ctx context.Context, arg PersonBlockDeleteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, personBlockDelete, arg.PersonID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const personBlocked = `-- name: PersonBlocked :one
+select exists (
+    select 1 from person_blocks b
+    where b.person_id = $1::varchar and b.blocked_id = $2::varchar
+)::boolean as blocked
+`
+
+type PersonBlockedParams struct {
+	PersonID  string
+	BlockedID string
+}
+
+// Returns true if the person has blocked another one
+func (q *Queries) PersonBlocked(ctx context.Context, arg PersonBlockedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, personBlocked, arg.PersonID, arg.BlockedID)
+	var blocked bool
+	err := row.Scan(&blocked)
+	return blocked, err
+}
+
+const personBlocksListByPerson = `-- name: PersonBlocksListByPerson :many
+select
+     p.id
+    ,p.display_name
+    ,p.ethereum_address
+    ,b.created_at
+from person_blocks b
+    join persons p on p.id = b.blocked_id
+where b.person_id = $1::varchar
+order by b.created_at desc, p.id
+`
+
+type PersonBlocksListByPersonRow struct {
+	ID              string
+	DisplayName     string
+	EthereumAddress string
+	CreatedAt       time.Time
+}
+
+// Persons blocked by the person from the last blocked
+func (q *Queries) PersonBlocksListByPerson(ctx context.Context, personID string) ([]PersonBlocksListByPersonRow, error) {
+	rows, err := q.db.QueryContext(ctx, personBlocksListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PersonBlocksListByPersonRow
+	for rows.Next() {
+		var i PersonBlocksListByPersonRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisplayName,
+			&i.EthereumAddress,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const personBlocksPurge = `-- name: PersonBlocksPurge :exec
+DELETE FROM person_blocks
+`
+
+// Handle with care!
+func (q *Queries) PersonBlocksPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, personBlocksPurge)
+	return err
+}
@@ -13,11 +13,11 @@ import (
 
 const personAdd = `-- name: PersonAdd :one
 insert into persons (
-    id, realm, login, password_hash, display_name, email, access_token, ethereum_address
+    id, realm, login, password_hash, display_name, email, ethereum_address
 ) values (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7
 )
-returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin
+returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at
 `
 
 type PersonAddParams struct {
@@ -27,7 +27,6 @@ type PersonAddParams struct {
 	PasswordHash    string
 	DisplayName     string
 	Email           string
-	AccessToken     sql.NullString
 	EthereumAddress string
 }
 
@@ -39,7 +38,6 @@ func (q *Queries) PersonAdd(ctx context.Context, arg PersonAddParams) (Person, e
 		arg.PasswordHash,
 		arg.DisplayName,
 		arg.Email,
-		arg.AccessToken,
 		arg.EthereumAddress,
 	)
 	var i Person
@@ -53,43 +51,52 @@ func (q *Queries) PersonAdd(ctx context.Context, arg PersonAddParams) (Person, e
 		&i.Email,
 		&i.EthereumAddress,
 		&i.Resources,
-		&i.AccessToken,
 		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
 	)
 	return i, err
 }
 
-const personGet = `-- name: PersonGet :one
-select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin from persons
-	where id = $1::varchar
+const personBlock = `-- name: PersonBlock :exec
+update persons set blocked_at = now() where id = $1::varchar
 `
 
-func (q *Queries) PersonGet(ctx context.Context, id string) (Person, error) {
-	row := q.db.QueryRowContext(ctx, personGet, id)
-	var i Person
-	err := row.Scan(
-		&i.ID,
-		&i.Realm,
-		&i.Login,
-		&i.PasswordHash,
-		&i.DisplayName,
-		&i.CreatedAt,
-		&i.Email,
-		&i.EthereumAddress,
-		&i.Resources,
-		&i.AccessToken,
-		&i.IsAdmin,
-	)
-	return i, err
+func (q *Queries) PersonBlock(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, personBlock, id)
+	return err
+}
+
+const personClaimDigest = `-- name: PersonClaimDigest :execrows
+update persons set email_digest_sent_at = now()
+where id = $1::varchar and email_digest_sent_at is not distinct from $2
+`
+
+type PersonClaimDigestParams struct {
+	ID     string
+	SentAt sql.NullTime
+}
+
+// Unread messages created before are not included into the next digest.
+// Nothing is updated when the digest has been claimed by someone else after the person was read.
+func (q *Queries) PersonClaimDigest(ctx context.Context, arg PersonClaimDigestParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, personClaimDigest, arg.ID, arg.SentAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const personGetByAccessToken = `-- name: PersonGetByAccessToken :one
-select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin from persons
-	where access_token = $1::varchar
+const personGet = `-- name: PersonGet :one
+select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at from persons
+	where id = $1::varchar
 `
 
-func (q *Queries) PersonGetByAccessToken(ctx context.Context, accessToken string) (Person, error) {
-	row := q.db.QueryRowContext(ctx, personGetByAccessToken, accessToken)
+func (q *Queries) PersonGet(ctx context.Context, id string) (Person, error) {
+	row := q.db.QueryRowContext(ctx, personGet, id)
 	var i Person
 	err := row.Scan(
 		&i.ID,
@@ -101,14 +108,18 @@ func (q *Queries) PersonGetByAccessToken(ctx context.Context, accessToken string
 		&i.Email,
 		&i.EthereumAddress,
 		&i.Resources,
-		&i.AccessToken,
 		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
 	)
 	return i, err
 }
 
 const personGetByLogin = `-- name: PersonGetByLogin :one
-select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin from persons p
+select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at from persons p
 	where p.login = $1::varchar and p.realm = $2::varchar
 `
 
@@ -130,8 +141,12 @@ func (q *Queries) PersonGetByLogin(ctx context.Context, arg PersonGetByLoginPara
 		&i.Email,
 		&i.EthereumAddress,
 		&i.Resources,
-		&i.AccessToken,
 		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
 	)
 	return i, err
 }
@@ -150,7 +165,7 @@ set
 
 where
     id = $7::varchar
-returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin
+returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at
 `
 
 type PersonPatchParams struct {
@@ -184,30 +199,47 @@ func (q *Queries) PersonPatch(ctx context.Context, arg PersonPatchParams) (Perso
 		&i.Email,
 		&i.EthereumAddress,
 		&i.Resources,
-		&i.AccessToken,
 		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
 	)
 	return i, err
 }
 
-const personSetAccessToken = `-- name: PersonSetAccessToken :exec
-update persons
-set
-    access_token = $1::varchar
-where
-    id = $2::varchar
-returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin
+const personSetEmailDigest = `-- name: PersonSetEmailDigest :one
+update persons set email_digest = $1::varchar where id = $2::varchar
+returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at
 `
 
-type PersonSetAccessTokenParams struct {
-	AccessToken string
+type PersonSetEmailDigestParams struct {
+	EmailDigest string
 	ID          string
 }
 
-// Sets the person's access token
-func (q *Queries) PersonSetAccessToken(ctx context.Context, arg PersonSetAccessTokenParams) error {
-	_, err := q.db.ExecContext(ctx, personSetAccessToken, arg.AccessToken, arg.ID)
-	return err
+func (q *Queries) PersonSetEmailDigest(ctx context.Context, arg PersonSetEmailDigestParams) (Person, error) {
+	row := q.db.QueryRowContext(ctx, personSetEmailDigest, arg.EmailDigest, arg.ID)
+	var i Person
+	err := row.Scan(
+		&i.ID,
+		&i.Realm,
+		&i.Login,
+		&i.PasswordHash,
+		&i.DisplayName,
+		&i.CreatedAt,
+		&i.Email,
+		&i.EthereumAddress,
+		&i.Resources,
+		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
+	)
+	return i, err
 }
 
 const personSetEthereumAddress = `-- name: PersonSetEthereumAddress :exec
@@ -282,8 +314,106 @@ func (q *Queries) PersonSetResources(ctx context.Context, arg PersonSetResources
 	return err
 }
 
+const personUnblock = `-- name: PersonUnblock :exec
+update persons set blocked_at = null where id = $1::varchar
+`
+
+func (q *Queries) PersonUnblock(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, personUnblock, id)
+	return err
+}
+
+const personUnsubscribe = `-- name: PersonUnsubscribe :one
+update persons set email_digest = 'never'
+where id = (select t.person_id from unsubscribe_tokens t where t.token_hash = $1::varchar)
+returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at
+`
+
+func (q *Queries) PersonUnsubscribe(ctx context.Context, tokenHash string) (Person, error) {
+	row := q.db.QueryRowContext(ctx, personUnsubscribe, tokenHash)
+	var i Person
+	err := row.Scan(
+		&i.ID,
+		&i.Realm,
+		&i.Login,
+		&i.PasswordHash,
+		&i.DisplayName,
+		&i.CreatedAt,
+		&i.Email,
+		&i.EthereumAddress,
+		&i.Resources,
+		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
+	)
+	return i, err
+}
+
+const personsDueForDigest = `-- name: PersonsDueForDigest :many
+select p.id, p.realm, p.login, p.password_hash, p.display_name, p.created_at, p.email, p.ethereum_address, p.resources, p.is_admin, p.blocked_at, p.connects, p.connects_refilled_at, p.email_digest, p.email_digest_sent_at from persons p
+where p.email <> ''
+    and p.blocked_at is null
+    and p.email_digest <> 'never'
+    and (p.email_digest_sent_at is null or p.email_digest_sent_at <= now() - case p.email_digest
+        when 'hourly' then interval '1 hour'
+        when 'daily' then interval '1 day'
+        else interval '7 days' end)
+    and exists (
+        select 1 from chats_participants cp
+        join messages m on m.chat_id = cp.chat_id
+            and m.created_by <> cp.person_id
+            and m.deleted_at is null
+            and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+            and (p.email_digest_sent_at is null or m.created_at > p.email_digest_sent_at)
+        where cp.person_id = p.id)
+order by p.id
+`
+
+// Persons with email whose digest period has passed and who have unread messages after the previous digest
+func (q *Queries) PersonsDueForDigest(ctx context.Context) ([]Person, error) {
+	rows, err := q.db.QueryContext(ctx, personsDueForDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Person
+	for rows.Next() {
+		var i Person
+		if err := rows.Scan(
+			&i.ID,
+			&i.Realm,
+			&i.Login,
+			&i.PasswordHash,
+			&i.DisplayName,
+			&i.CreatedAt,
+			&i.Email,
+			&i.EthereumAddress,
+			&i.Resources,
+			&i.IsAdmin,
+			&i.BlockedAt,
+			&i.Connects,
+			&i.ConnectsRefilledAt,
+			&i.EmailDigest,
+			&i.EmailDigestSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const personsList = `-- name: PersonsList :many
-select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin from persons
+select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at from persons
 `
 
 func (q *Queries) PersonsList(ctx context.Context) ([]Person, error) {
@@ -305,8 +435,12 @@ func (q *Queries) PersonsList(ctx context.Context) ([]Person, error) {
 			&i.Email,
 			&i.EthereumAddress,
 			&i.Resources,
-			&i.AccessToken,
 			&i.IsAdmin,
+			&i.BlockedAt,
+			&i.Connects,
+			&i.ConnectsRefilledAt,
+			&i.EmailDigest,
+			&i.EmailDigestSentAt,
 		); err != nil {
 			return nil, err
 		}
@@ -330,3 +464,107 @@ func (q *Queries) PersonsPurge(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, personsPurge)
 	return err
 }
+
+const personsSearch = `-- name: PersonsSearch :many
+select id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at from persons p
+where $1::varchar = ''
+    or p.login ilike '%' || $1::varchar || '%'
+    or p.display_name ilike '%' || $1::varchar || '%'
+    or p.email ilike '%' || $1::varchar || '%'
+    or p.id = $1::varchar
+    or lower(p.ethereum_address) = lower($1::varchar)
+order by p.created_at desc, p.id
+limit $3::int offset $2::int
+`
+
+type PersonsSearchParams struct {
+	Query string
+	Off   int32
+	Lim   int32
+}
+
+// Empty query means all persons. The newest persons go first.
+func (q *Queries) PersonsSearch(ctx context.Context, arg PersonsSearchParams) ([]Person, error) {
+	rows, err := q.db.QueryContext(ctx, personsSearch, arg.Query, arg.Off, arg.Lim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Person
+	for rows.Next() {
+		var i Person
+		if err := rows.Scan(
+			&i.ID,
+			&i.Realm,
+			&i.Login,
+			&i.PasswordHash,
+			&i.DisplayName,
+			&i.CreatedAt,
+			&i.Email,
+			&i.EthereumAddress,
+			&i.Resources,
+			&i.IsAdmin,
+			&i.BlockedAt,
+			&i.Connects,
+			&i.ConnectsRefilledAt,
+			&i.EmailDigest,
+			&i.EmailDigestSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unsubscribeTokenAdd = `-- name: UnsubscribeTokenAdd :exec
+insert into unsubscribe_tokens (token_hash, person_id) values ($1::varchar, $2::varchar)
+`
+
+type UnsubscribeTokenAddParams struct {
+	TokenHash string
+	PersonID  string
+}
+
+func (q *Queries) UnsubscribeTokenAdd(ctx context.Context, arg UnsubscribeTokenAddParams) error {
+	_, err := q.db.ExecContext(ctx, unsubscribeTokenAdd, arg.TokenHash, arg.PersonID)
+	return err
+}
+
+const unsubscribeTokenGet = `-- name: UnsubscribeTokenGet :one
+select token_hash, person_id, created_at from unsubscribe_tokens where token_hash = $1::varchar
+`
+
+func (q *Queries) UnsubscribeTokenGet(ctx context.Context, tokenHash string) (UnsubscribeToken, error) {
+	row := q.db.QueryRowContext(ctx, unsubscribeTokenGet, tokenHash)
+	var i UnsubscribeToken
+	err := row.Scan(&i.TokenHash, &i.PersonID, &i.CreatedAt)
+	return i, err
+}
+
+const unsubscribeTokensDeleteOld = `-- name: UnsubscribeTokensDeleteOld :exec
+delete from unsubscribe_tokens
+where person_id = $1::varchar and created_at < now() - interval '1 year'
+`
+
+// Unsubscribe links of emails sent more than a year ago stop working
+func (q *Queries) UnsubscribeTokensDeleteOld(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, unsubscribeTokensDeleteOld, personID)
+	return err
+}
+
+const unsubscribeTokensPurge = `-- name: UnsubscribeTokensPurge :exec
+DELETE FROM unsubscribe_tokens
+`
+
+// Handle with care!
+func (q *Queries) UnsubscribeTokensPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, unsubscribeTokensPurge)
+	return err
+}
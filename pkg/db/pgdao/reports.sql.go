@@ -0,0 +1,229 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: reports.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const reportAdd = `-- name: ReportAdd :one
+insert into abuse_reports (
+    id, target_kind, target_id, reason, "comment", created_by
+) values (
+    $1, $2, $3, $4, $5, $6
+) returning id, target_kind, target_id, reason, comment, created_by, created_at, status, resolved_by, resolved_at, resolution
+`
+
+type ReportAddParams struct {
+	ID         string
+	TargetKind string
+	TargetID   string
+	Reason     string
+	Comment    string
+	CreatedBy  string
+}
+
+func (q *Queries) ReportAdd(ctx context.Context, arg ReportAddParams) (AbuseReport, error) {
+	row := q.db.QueryRowContext(ctx, reportAdd,
+		arg.ID,
+		arg.TargetKind,
+		arg.TargetID,
+		arg.Reason,
+		arg.Comment,
+		arg.CreatedBy,
+	)
+	var i AbuseReport
+	err := row.Scan(
+		&i.ID,
+		&i.TargetKind,
+		&i.TargetID,
+		&i.Reason,
+		&i.Comment,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Status,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.Resolution,
+	)
+	return i, err
+}
+
+const reportGet = `-- name: ReportGet :one
+select
+      r.id, r.target_kind, r.target_id, r.reason, r.comment, r.created_by, r.created_at, r.status, r.resolved_by, r.resolved_at, r.resolution
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS reporter_display_name
+from abuse_reports r
+join persons p on p.id = r.created_by
+where r.id = $1::varchar
+`
+
+type ReportGetRow struct {
+	ID                  string
+	TargetKind          string
+	TargetID            string
+	Reason              string
+	Comment             string
+	CreatedBy           string
+	CreatedAt           time.Time
+	Status              string
+	ResolvedBy          sql.NullString
+	ResolvedAt          sql.NullTime
+	Resolution          string
+	ReporterDisplayName string
+}
+
+func (q *Queries) ReportGet(ctx context.Context, id string) (ReportGetRow, error) {
+	row := q.db.QueryRowContext(ctx, reportGet, id)
+	var i ReportGetRow
+	err := row.Scan(
+		&i.ID,
+		&i.TargetKind,
+		&i.TargetID,
+		&i.Reason,
+		&i.Comment,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Status,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.Resolution,
+		&i.ReporterDisplayName,
+	)
+	return i, err
+}
+
+const reportResolve = `-- name: ReportResolve :exec
+update abuse_reports
+set
+    status = $1::varchar,
+    resolved_by = $2::varchar,
+    resolved_at = now(),
+    resolution = $3::varchar
+where id = $4::varchar and status = 'open'
+`
+
+type ReportResolveParams struct {
+	Status     string
+	ResolvedBy string
+	Resolution string
+	ID         string
+}
+
+func (q *Queries) ReportResolve(ctx context.Context, arg ReportResolveParams) error {
+	_, err := q.db.ExecContext(ctx, reportResolve,
+		arg.Status,
+		arg.ResolvedBy,
+		arg.Resolution,
+		arg.ID,
+	)
+	return err
+}
+
+const reportsList = `-- name: ReportsList :many
+select
+      r.id, r.target_kind, r.target_id, r.reason, r.comment, r.created_by, r.created_at, r.status, r.resolved_by, r.resolved_at, r.resolution
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS reporter_display_name
+from abuse_reports r
+join persons p on p.id = r.created_by
+where $1::varchar = '' or r.status = $1::varchar
+order by r.status = 'open' desc, r.created_at asc
+`
+
+type ReportsListRow struct {
+	ID                  string
+	TargetKind          string
+	TargetID            string
+	Reason              string
+	Comment             string
+	CreatedBy           string
+	CreatedAt           time.Time
+	Status              string
+	ResolvedBy          sql.NullString
+	ResolvedAt          sql.NullTime
+	Resolution          string
+	ReporterDisplayName string
+}
+
+// Empty status means reports in any status. Open reports go first, the oldest first.
+func (q *Queries) ReportsList(ctx context.Context, status string) ([]ReportsListRow, error) {
+	rows, err := q.db.QueryContext(ctx, reportsList, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReportsListRow
+	for rows.Next() {
+		var i ReportsListRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TargetKind,
+			&i.TargetID,
+			&i.Reason,
+			&i.Comment,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Status,
+			&i.ResolvedBy,
+			&i.ResolvedAt,
+			&i.Resolution,
+			&i.ReporterDisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reportsPurge = `-- name: ReportsPurge :exec
+DELETE FROM abuse_reports
+`
+
+// Handle with care!
+func (q *Queries) ReportsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, reportsPurge)
+	return err
+}
+
+const reportsResolveByTarget = `-- name: ReportsResolveByTarget :execrows
+update abuse_reports
+set
+    status = 'resolved',
+    resolved_by = $1::varchar,
+    resolved_at = now(),
+    resolution = $2::varchar
+where target_kind = $3::varchar and target_id = $4::varchar and status = 'open'
+`
+
+type ReportsResolveByTargetParams struct {
+	ResolvedBy string
+	Resolution string
+	TargetKind string
+	TargetID   string
+}
+
+// Resolves all open reports about the target, it is used when the target is blocked
+func (q *Queries) ReportsResolveByTarget(ctx context.Context, arg ReportsResolveByTargetParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reportsResolveByTarget,
+		arg.ResolvedBy,
+		arg.Resolution,
+		arg.TargetKind,
+		arg.TargetID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
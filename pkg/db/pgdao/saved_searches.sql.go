@@ -0,0 +1,362 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: saved_searches.sql
+
+package pgdao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const savedJobAdd = `-- name: SavedJobAdd :exec
+insert into saved_jobs (
+    person_id, job_id
+) values (
+    $1, $2
+) on conflict do nothing
+`
+
+type SavedJobAddParams struct {
+	PersonID string
+	JobID    string
+}
+
+func (q *Queries) SavedJobAdd(ctx context.Context, arg SavedJobAddParams) error {
+	_, err := q.db.ExecContext(ctx, savedJobAdd, arg.PersonID, arg.JobID)
+	return err
+}
+
+const savedJobDelete = `-- name: SavedJobDelete :execrows
+delete from saved_jobs where person_id = $1::varchar and job_id = $2::varchar
+`
+
+type SavedJobDeleteParams struct {
+	PersonID string
+	JobID    string
+}
+
+func (q *Queries) SavedJobDelete(ctx context.Context, arg SavedJobDeleteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, savedJobDelete, arg.PersonID, arg.JobID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const savedJobsListByPerson = `-- name: SavedJobsListByPerson :many
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from saved_jobs s
+    join jobs j on j.id = s.job_id
+    join persons p on p.id = j.created_by
+    where s.person_id = $1::varchar and j.blocked_at is null
+    order by s.created_at desc
+`
+
+type SavedJobsListByPersonRow struct {
+	ID                      string
+	Title                   string
+	Description             string
+	Budget                  sql.NullString
+	Duration                sql.NullInt32
+	CreatedAt               time.Time
+	CreatedBy               string
+	UpdatedAt               time.Time
+	SuspendedAt             sql.NullTime
+	Visibility              string
+	ExpiresAt               sql.NullTime
+	ClosedAt                sql.NullTime
+	PublishedAt             sql.NullTime
+	PublishAt               sql.NullTime
+	Status                  string
+	ApplicationCount        int64
+	CustomerDisplayName     string
+	CustomerEthereumAddress string
+}
+
+// Saved jobs of the person from the last saved. Columns must be the same as in JobGet
+func (q *Queries) SavedJobsListByPerson(ctx context.Context, personID string) ([]SavedJobsListByPersonRow, error) {
+	rows, err := q.db.QueryContext(ctx, savedJobsListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedJobsListByPersonRow
+	for rows.Next() {
+		var i SavedJobsListByPersonRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Budget,
+			&i.Duration,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.SuspendedAt,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.ClosedAt,
+			&i.PublishedAt,
+			&i.PublishAt,
+			&i.Status,
+			&i.ApplicationCount,
+			&i.CustomerDisplayName,
+			&i.CustomerEthereumAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const savedJobsPurge = `-- name: SavedJobsPurge :exec
+DELETE FROM saved_jobs
+`
+
+// Handle with care!
+func (q *Queries) SavedJobsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, savedJobsPurge)
+	return err
+}
+
+const savedSearchAdd = `-- name: SavedSearchAdd :one
+insert into saved_searches (
+    id, person_id, title, keyword, budget_min, budget_max
+) values (
+    $1, $2, $3, $4, $5, $6
+) returning id, person_id, title, keyword, budget_min, budget_max, created_at, updated_at
+`
+
+type SavedSearchAddParams struct {
+	ID        string
+	PersonID  string
+	Title     string
+	Keyword   string
+	BudgetMin sql.NullString
+	BudgetMax sql.NullString
+}
+
+func (q *Queries) SavedSearchAdd(ctx context.Context, arg SavedSearchAddParams) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, savedSearchAdd,
+		arg.ID,
+		arg.PersonID,
+		arg.Title,
+		arg.Keyword,
+		arg.BudgetMin,
+		arg.BudgetMax,
+	)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Title,
+		&i.Keyword,
+		&i.BudgetMin,
+		&i.BudgetMax,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const savedSearchDelete = `-- name: SavedSearchDelete :exec
+delete from saved_searches where id = $1::varchar
+`
+
+func (q *Queries) SavedSearchDelete(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, savedSearchDelete, id)
+	return err
+}
+
+const savedSearchGet = `-- name: SavedSearchGet :one
+select id, person_id, title, keyword, budget_min, budget_max, created_at, updated_at from saved_searches where id = $1::varchar
+`
+
+func (q *Queries) SavedSearchGet(ctx context.Context, id string) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, savedSearchGet, id)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Title,
+		&i.Keyword,
+		&i.BudgetMin,
+		&i.BudgetMax,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const savedSearchUpdate = `-- name: SavedSearchUpdate :one
+update saved_searches
+set
+    title = $1::varchar,
+    keyword = $2::varchar,
+    budget_min = $3,
+    budget_max = $4,
+    updated_at = now()
+where id = $5::varchar
+returning id, person_id, title, keyword, budget_min, budget_max, created_at, updated_at
+`
+
+type SavedSearchUpdateParams struct {
+	Title     string
+	Keyword   string
+	BudgetMin sql.NullString
+	BudgetMax sql.NullString
+	ID        string
+}
+
+func (q *Queries) SavedSearchUpdate(ctx context.Context, arg SavedSearchUpdateParams) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, savedSearchUpdate,
+		arg.Title,
+		arg.Keyword,
+		arg.BudgetMin,
+		arg.BudgetMax,
+		arg.ID,
+	)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Title,
+		&i.Keyword,
+		&i.BudgetMin,
+		&i.BudgetMax,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const savedSearchesListByPerson = `-- name: SavedSearchesListByPerson :many
+select id, person_id, title, keyword, budget_min, budget_max, created_at, updated_at from saved_searches where person_id = $1::varchar order by created_at desc
+`
+
+func (q *Queries) SavedSearchesListByPerson(ctx context.Context, personID string) ([]SavedSearch, error) {
+	rows, err := q.db.QueryContext(ctx, savedSearchesListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedSearch
+	for rows.Next() {
+		var i SavedSearch
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.Title,
+			&i.Keyword,
+			&i.BudgetMin,
+			&i.BudgetMax,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const savedSearchesMatchJob = `-- name: SavedSearchesMatchJob :many
+select s.id, s.person_id, s.title, s.keyword, s.budget_min, s.budget_max, s.created_at, s.updated_at from saved_searches s
+    join persons p on p.id = s.person_id
+    join jobs j on j.id = $1::varchar
+    where s.person_id <> j.created_by and p.blocked_at is null
+        and j.blocked_at is null and j.suspended_at is null and j.closed_at is null
+        and j.published_at is not null
+        and (j.expires_at is null or j.expires_at > now())
+        and j.visibility = 'public'
+        and (s.keyword = ''
+            or strpos(lower(j.title), lower(s.keyword)) > 0
+            or strpos(lower(j.description), lower(s.keyword)) > 0)
+        and (s.budget_min is null or j.budget >= s.budget_min)
+        and (s.budget_max is null or j.budget <= s.budget_max)
+    order by s.created_at
+`
+
+// Searches of other active persons which the open public job matches
+func (q *Queries) SavedSearchesMatchJob(ctx context.Context, jobID string) ([]SavedSearch, error) {
+	rows, err := q.db.QueryContext(ctx, savedSearchesMatchJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedSearch
+	for rows.Next() {
+		var i SavedSearch
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.Title,
+			&i.Keyword,
+			&i.BudgetMin,
+			&i.BudgetMax,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const savedSearchesPurge = `-- name: SavedSearchesPurge :exec
+DELETE FROM saved_searches
+`
+
+// Handle with care!
+func (q *Queries) SavedSearchesPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, savedSearchesPurge)
+	return err
+}
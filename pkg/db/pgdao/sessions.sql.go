@@ -0,0 +1,180 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.13.0
+// source: sessions.sql
+
+package pgdao
+
+import (
+	"context"
+	"time"
+)
+
+const sessionAdd = `-- name: SessionAdd :one
+insert into sessions (
+    id, person_id, token_hash, expires_at, user_agent, ip
+) values (
+    $1, $2, $3, $4, $5, $6
+) returning id, person_id, token_hash, created_at, last_used_at, expires_at, user_agent, ip
+`
+
+type SessionAddParams struct {
+	ID        string
+	PersonID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UserAgent string
+	Ip        string
+}
+
+func (q *Queries) SessionAdd(ctx context.Context, arg SessionAddParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, sessionAdd,
+		arg.ID,
+		arg.PersonID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.UserAgent,
+		arg.Ip,
+	)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.Ip,
+	)
+	return i, err
+}
+
+const sessionDelete = `-- name: SessionDelete :execrows
+delete from sessions
+where id = $1::varchar and person_id = $2::varchar
+`
+
+type SessionDeleteParams struct {
+	ID       string
+	PersonID string
+}
+
+func (q *Queries) SessionDelete(ctx context.Context, arg SessionDeleteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, sessionDelete, arg.ID, arg.PersonID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const sessionGetByTokenHash = `-- name: SessionGetByTokenHash :one
+select s.id, s.person_id, s.token_hash, s.created_at, s.last_used_at, s.expires_at, s.user_agent, s.ip from sessions s
+where s.token_hash = $1::varchar and s.expires_at > now()
+`
+
+// Returns only not expired session
+func (q *Queries) SessionGetByTokenHash(ctx context.Context, tokenHash string) (Session, error) {
+	row := q.db.QueryRowContext(ctx, sessionGetByTokenHash, tokenHash)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.Ip,
+	)
+	return i, err
+}
+
+const sessionTouch = `-- name: SessionTouch :exec
+update sessions
+set
+    last_used_at = now()
+    , expires_at = $1::timestamp
+where
+    id = $2::varchar
+    and last_used_at < now() - interval '1 minute'
+`
+
+type SessionTouchParams struct {
+	ExpiresAt time.Time
+	ID        string
+}
+
+// Prolongs the session, it is written not more often than once a minute
+func (q *Queries) SessionTouch(ctx context.Context, arg SessionTouchParams) error {
+	_, err := q.db.ExecContext(ctx, sessionTouch, arg.ExpiresAt, arg.ID)
+	return err
+}
+
+const sessionsDeleteByPerson = `-- name: SessionsDeleteByPerson :exec
+delete from sessions where person_id = $1::varchar
+`
+
+func (q *Queries) SessionsDeleteByPerson(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, sessionsDeleteByPerson, personID)
+	return err
+}
+
+const sessionsDeleteExpired = `-- name: SessionsDeleteExpired :exec
+delete from sessions
+where person_id = $1::varchar and expires_at <= now()
+`
+
+func (q *Queries) SessionsDeleteExpired(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, sessionsDeleteExpired, personID)
+	return err
+}
+
+const sessionsListByPerson = `-- name: SessionsListByPerson :many
+select s.id, s.person_id, s.token_hash, s.created_at, s.last_used_at, s.expires_at, s.user_agent, s.ip from sessions s
+where s.person_id = $1::varchar and s.expires_at > now()
+order by s.last_used_at desc, s.id
+`
+
+// Recently used sessions go first
+func (q *Queries) SessionsListByPerson(ctx context.Context, personID string) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, sessionsListByPerson, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.TokenHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+			&i.UserAgent,
+			&i.Ip,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sessionsPurge = `-- name: SessionsPurge :exec
+DELETE FROM sessions
+`
+
+// Handle with care!
+func (q *Queries) SessionsPurge(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, sessionsPurge)
+	return err
+}
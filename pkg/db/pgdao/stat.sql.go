@@ -73,7 +73,9 @@ func (q *Queries) StatsGetContractsVolume(ctx context.Context) (string, error) {
 const statsGetOpenedJobsCount = `-- name: StatsGetOpenedJobsCount :one
 select count(id) AS count
 from jobs
-where suspended_at is null and blocked_at is null
+where suspended_at is null and blocked_at is null and closed_at is null
+    and published_at is not null
+    and (expires_at is null or expires_at > now())
 `
 
 func (q *Queries) StatsGetOpenedJobsCount(ctx context.Context) (int64, error) {
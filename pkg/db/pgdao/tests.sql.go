@@ -7,17 +7,16 @@ package pgdao
 
 import (
 	"context"
-	"database/sql"
 	"time"
 )
 
 const testsPersonCreate = `-- name: TestsPersonCreate :one
 insert into persons (
-    id, realm, login, password_hash, display_name, email, access_token, ethereum_address, is_admin, created_at
+    id, realm, login, password_hash, display_name, email, ethereum_address, is_admin, created_at
 ) values (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, access_token, is_admin
+returning id, realm, login, password_hash, display_name, created_at, email, ethereum_address, resources, is_admin, blocked_at, connects, connects_refilled_at, email_digest, email_digest_sent_at
 `
 
 type TestsPersonCreateParams struct {
@@ -27,7 +26,6 @@ type TestsPersonCreateParams struct {
 	PasswordHash    string
 	DisplayName     string
 	Email           string
-	AccessToken     sql.NullString
 	EthereumAddress string
 	IsAdmin         bool
 	CreatedAt       time.Time
@@ -41,7 +39,6 @@ func (q *Queries) TestsPersonCreate(ctx context.Context, arg TestsPersonCreatePa
 		arg.PasswordHash,
 		arg.DisplayName,
 		arg.Email,
-		arg.AccessToken,
 		arg.EthereumAddress,
 		arg.IsAdmin,
 		arg.CreatedAt,
@@ -57,8 +54,12 @@ func (q *Queries) TestsPersonCreate(ctx context.Context, arg TestsPersonCreatePa
 		&i.Email,
 		&i.EthereumAddress,
 		&i.Resources,
-		&i.AccessToken,
 		&i.IsAdmin,
+		&i.BlockedAt,
+		&i.Connects,
+		&i.ConnectsRefilledAt,
+		&i.EmailDigest,
+		&i.EmailDigestSentAt,
 	)
 	return i, err
 }
@@ -17,6 +17,50 @@ func NewID() string {
 func PurgeDB(ctx context.Context, db DBTX) error {
 	queries := New(db)
 
+	if e := queries.SavedSearchesPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.SavedJobsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.PersonBlocksPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.APIKeysPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.SessionsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.ChatTicketsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.UnsubscribeTokensPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.ImpersonationsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.AuditPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.ReportsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.MessageRevisionsPurge(ctx); e != nil {
+		return e
+	}
+
 	if e := queries.MessagesPurge(ctx); e != nil {
 		return e
 	}
@@ -25,14 +69,42 @@ func PurgeDB(ctx context.Context, db DBTX) error {
 		return e
 	}
 
+	if e := queries.AttachmentsPurge(ctx); e != nil {
+		return e
+	}
+
 	if e := queries.ContractsPurge(ctx); e != nil {
 		return e
 	}
 
+	if e := queries.InvitationsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.ApplicationAnswersPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.ApplicationRevisionsPurge(ctx); e != nil {
+		return e
+	}
+
 	if e := queries.ApplicationsPurge(ctx); e != nil {
 		return e
 	}
 
+	if e := queries.PersonNotificationsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.JobQuestionsPurge(ctx); e != nil {
+		return e
+	}
+
+	if e := queries.JobRevisionsPurge(ctx); e != nil {
+		return e
+	}
+
 	if e := queries.JobsPurge(ctx); e != nil {
 		return e
 	}
@@ -0,0 +1,37 @@
+-- name: APIKeyAdd :one
+insert into api_keys (
+    id, person_id, "name", token_hash, token_prefix, scopes, ip_allowlist, expires_at
+) values (
+    @id, @person_id, @name, @token_hash, @token_prefix, @scopes, @ip_allowlist, @expires_at
+) returning *;
+
+-- name: APIKeyGetByTokenHash :one
+-- Returns only not expired key
+select k.* from api_keys k
+where k.token_hash = @token_hash::varchar and (k.expires_at is null or k.expires_at > now());
+
+-- name: APIKeyTouch :exec
+-- It is written not more often than once a minute
+update api_keys
+set
+    last_used_at = now()
+where
+    id = @id::varchar
+    and (last_used_at is null or last_used_at < now() - interval '1 minute');
+
+-- name: APIKeysListByPerson :many
+-- The newest keys go first
+select k.* from api_keys k
+where k.person_id = @person_id::varchar
+order by k.created_at desc, k.id;
+
+-- name: APIKeyDelete :execrows
+delete from api_keys
+where id = @id::varchar and person_id = @person_id::varchar;
+
+-- name: APIKeysDeleteByPerson :exec
+delete from api_keys where person_id = @person_id::varchar;
+
+-- name: APIKeysPurge :exec
+-- Handle with care!
+DELETE FROM api_keys;
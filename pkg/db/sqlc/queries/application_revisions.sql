@@ -0,0 +1,19 @@
+-- name: ApplicationRevisionAdd :one
+insert into application_revisions (
+    id, application_id, version, "comment", price
+) values (
+    @id,
+    @application_id,
+    (select coalesce(max(r.version), 0) + 1 from application_revisions r where r.application_id = @application_id::varchar),
+    @comment,
+    @price
+) returning *;
+
+-- name: ApplicationRevisionsListByApplication :many
+select r.* from application_revisions r
+where r.application_id = @application_id::varchar
+order by r.version desc;
+
+-- name: ApplicationRevisionsPurge :exec
+-- Handle with care!
+DELETE FROM application_revisions;
@@ -11,6 +11,7 @@ select a.*
 	, j.title AS job_title
 	, j.budget AS job_budget
 	, j.description AS job_description
+	, j.created_by AS customer_id
 	, c.id AS contract_id
 	, c.status AS contract_status
 	, (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS applicant_display_name
@@ -31,6 +32,7 @@ select a.*
 	join persons p on p.id = a.applicant_id
 	left join contracts c on c.application_id  = a.id and c.performer_id = a.applicant_id
 	where a.job_id = @job_id::varchar
+		and (@status::varchar = '' or (a.status = @status::varchar and a.withdrawn_at is null))
 	order by a.created_at desc;
 
 -- name: ApplicationFindByJobAndApplicant :one
@@ -46,7 +48,7 @@ select a.*
 	join persons p on p.id = a.applicant_id
 	join jobs j on j.id = a.job_id
 	left join contracts c on c.application_id  = a.id and c.performer_id = a.applicant_id
-	where a.job_id = @job_id::varchar and a.applicant_id = @applicant_id::varchar
+	where a.job_id = @job_id::varchar and a.applicant_id = @applicant_id::varchar and a.withdrawn_at is null
 	limit 1;
 
 -- name: ApplicationsGetByApplicant :many
@@ -66,6 +68,42 @@ select a.*
 	where a.applicant_id = @applicant_id::varchar
 	order by a.created_at desc;
 
+-- name: ApplicationUpdate :one
+update applications
+set
+    "comment" = @comment::text,
+    price = @price::decimal,
+    updated_at = now()
+where id = @id::varchar
+returning *;
+
+-- name: ApplicationWithdraw :exec
+update applications set withdrawn_at = now(), updated_at = now() where id = @id::varchar;
+
+-- name: ApplicationSetStatus :one
+update applications
+set
+    status = @status::varchar,
+    rejection_reason = @rejection_reason::text,
+    status_changed_at = now()
+where id = @id::varchar
+returning *;
+
+-- name: ApplicationStatusCountsByJob :many
+-- Counts of active applications of the job per status
+select a.status, count(*) as count
+from applications a
+where a.job_id = @job_id::varchar and a.withdrawn_at is null
+group by a.status;
+
+-- name: ApplicationStatusCountsByOwner :many
+-- Counts of active applications per job and status for all jobs of the owner
+select a.job_id, a.status, count(*) as count
+from applications a
+join jobs j on j.id = a.job_id
+where j.created_by = @created_by::varchar and a.withdrawn_at is null
+group by a.job_id, a.status;
+
 -- name: ApplicationsPurge :exec
 -- Handle with care!
 DELETE FROM applications;
@@ -0,0 +1,53 @@
+-- name: AttachmentAdd :one
+insert into attachments (
+    id, created_by, "name", content_type, size, storage_key, thumbnail_key
+) values (
+    @id, @created_by, @name, @content_type, @size, @storage_key, @thumbnail_key
+) returning *;
+
+-- name: AttachmentGet :one
+select a.* from attachments a
+where a.id = @id::varchar;
+
+-- name: AttachmentsListByJob :many
+select a.* from attachments a
+where a.job_id = @job_id::varchar
+order by a.created_at;
+
+-- name: AttachmentsListByApplication :many
+select a.* from attachments a
+where a.application_id = @application_id::varchar
+order by a.created_at;
+
+-- name: AttachmentsBindToJob :execrows
+-- Binds only unbound attachments of the person
+update attachments
+set job_id = @job_id::varchar
+where id = any(@ids::varchar[]) and created_by = @created_by::varchar
+    and job_id is null and application_id is null and message_id is null;
+
+-- name: AttachmentsBindToApplication :execrows
+-- Binds only unbound attachments of the person
+update attachments
+set application_id = @application_id::varchar
+where id = any(@ids::varchar[]) and created_by = @created_by::varchar
+    and job_id is null and application_id is null and message_id is null;
+
+-- name: AttachmentsListByMessages :many
+select a.* from attachments a
+where a.message_id = any(@message_ids::varchar[])
+order by a.created_at;
+
+-- name: AttachmentsBindToMessage :execrows
+-- Binds only unbound attachments of the person
+update attachments
+set message_id = @message_id::varchar
+where id = any(@ids::varchar[]) and created_by = @created_by::varchar
+    and job_id is null and application_id is null and message_id is null;
+
+-- name: AttachmentDelete :exec
+delete from attachments where id = @id::varchar;
+
+-- name: AttachmentsPurge :exec
+-- Handle with care!
+DELETE FROM attachments;
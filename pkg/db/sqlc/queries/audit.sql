@@ -0,0 +1,21 @@
+-- name: AuditAdd :one
+insert into audit_log (
+    id, actor_id, "action", target_kind, target_id, reason
+) values (
+    @id, @actor_id, @action, @target_kind, @target_id, @reason
+) returning *;
+
+-- name: AuditList :many
+-- Empty target_kind or target_id means any. The newest records go first.
+select
+      a.*
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS actor_display_name
+from audit_log a
+join persons p on p.id = a.actor_id
+where (@target_kind::varchar = '' or a.target_kind = @target_kind::varchar)
+    and (@target_id::varchar = '' or a.target_id = @target_id::varchar)
+order by a.created_at desc;
+
+-- name: AuditPurge :exec
+-- Handle with care!
+DELETE FROM audit_log;
@@ -0,0 +1,24 @@
+-- name: ChatTicketAdd :exec
+insert into chat_tickets (
+    token_hash, person_id, expires_at
+) values (
+    @token_hash, @person_id, @expires_at
+);
+
+-- name: ChatTicketUse :one
+-- Removes the ticket, so it can not be used again, and returns the person if the ticket is not expired and the person is not blocked
+delete from chat_tickets t
+using persons p
+where
+    t.token_hash = @token_hash::varchar
+    and t.expires_at > now()
+    and p.id = t.person_id
+    and p.blocked_at is null
+returning t.person_id;
+
+-- name: ChatTicketsDeleteExpired :exec
+delete from chat_tickets where expires_at <= now();
+
+-- name: ChatTicketsPurge :exec
+-- Handle with care!
+DELETE FROM chat_tickets;
@@ -65,7 +65,67 @@ left join contracts c on a.id = c.application_id
 where a.id = @application_id::varchar
 ;
 
+-- name: ChatGetDetailsByContractID :one
+select
+      c.id as contract_id
+    , c.application_id
+    , a.job_id
+    , c.title as contract_title
+from contracts c
+join applications a on a.id = c.application_id
+where c.id = @contract_id::varchar
+;
+
+-- name: ChatGetDetailsByInvitationID :one
+select
+      i.id as invitation_id
+    , j.id as job_id
+    , j.title as job_title
+    , i.application_id as application_id
+from job_invitations i
+join jobs j on i.job_id = j.id
+where i.id = @invitation_id::varchar
+;
+
 
 -- name: ChatsPurge :exec
 -- Handle with care!
 DELETE FROM chats;
+
+-- name: ChatParticipantsList :many
+select cp.person_id from chats_participants cp
+where cp.chat_id = @chat_id::varchar;
+
+-- name: ChatParticipantMarkRead :one
+-- The read marker never moves backward, all messages up to now are read if read_all is true
+update chats_participants
+set last_read_at = greatest(last_read_at, case when @read_all::boolean then now() else @read_at::timestamp end)
+where chat_id = @chat_id::varchar and person_id = @person_id::varchar
+returning *;
+
+-- name: ChatParticipantsReadMarkers :many
+select cp.person_id, cp.last_read_at from chats_participants cp
+where cp.chat_id = @chat_id::varchar and cp.last_read_at is not null;
+
+-- name: ChatsUnreadCountsByParticipant :many
+select cp.chat_id, count(m.id) as unread_count
+from chats_participants cp
+join messages m on m.chat_id = cp.chat_id
+    and m.created_by <> cp.person_id
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and m.deleted_at is null
+where cp.person_id = @participant_id::varchar
+group by cp.chat_id;
+
+-- name: MessagesUnreadCountByParticipant :one
+select count(m.id) as unread_count
+from chats_participants cp
+join messages m on m.chat_id = cp.chat_id
+    and m.created_by <> cp.person_id
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and m.deleted_at is null
+where cp.person_id = @participant_id::varchar;
+
+-- name: ChatEventNotify :exec
+-- Notifies all replicas about the chat event, new messages are notified by the trigger
+select pg_notify('chat_events', @payload::text);
@@ -0,0 +1,23 @@
+-- name: PersonConnectsRefill :exec
+-- Tops up the balance to the allowance once a week, the balance above the allowance is kept
+update persons
+set
+    connects = greatest(connects, @allowance::int),
+    connects_refilled_at = now()
+where id = @id::varchar
+    and (connects_refilled_at is null or connects_refilled_at <= now() - interval '7 days');
+
+-- name: PersonConnectsGet :one
+select p.connects, p.connects_refilled_at from persons p
+where p.id = @id::varchar;
+
+-- name: PersonConnectsSpend :execrows
+update persons
+set connects = connects - @cost::int
+where id = @id::varchar and connects >= @cost::int;
+
+-- name: PersonConnectsAdjust :one
+update persons
+set connects = greatest(connects + @delta::int, 0)
+where id = @id::varchar
+returning connects, connects_refilled_at;
@@ -0,0 +1,20 @@
+-- name: ImpersonationAdd :one
+insert into impersonations (
+    id, token_hash, admin_id, person_id, reason, expires_at
+) values (
+    @id, @token_hash, @admin_id, @person_id, @reason, @expires_at
+) returning *;
+
+-- name: ImpersonationGetByTokenHash :one
+-- Returns only not expired impersonation
+select i.* from impersonations i
+where i.token_hash = @token_hash::varchar and i.expires_at > now();
+
+-- name: ImpersonationsEndByPerson :exec
+-- Expires all active impersonations of the person, they are kept for the audit
+update impersonations set expires_at = now()
+where person_id = @person_id::varchar and expires_at > now();
+
+-- name: ImpersonationsPurge :exec
+-- Handle with care!
+DELETE FROM impersonations;
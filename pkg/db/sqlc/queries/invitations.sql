@@ -0,0 +1,62 @@
+-- name: InvitationAdd :one
+insert into job_invitations (
+    id, job_id, person_id, created_by, "message"
+) values (
+    @id, @job_id, @person_id, @created_by, @message
+) returning *;
+
+-- name: InvitationGet :one
+select
+      i.*
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.id = @id::varchar;
+
+-- name: InvitationFindByJobAndPerson :one
+select i.* from job_invitations i
+where i.job_id = @job_id::varchar and i.person_id = @person_id::varchar;
+
+-- name: InvitationsListByJob :many
+select
+      i.*
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.job_id = @job_id::varchar
+order by i.created_at desc;
+
+-- name: InvitationsListByPerson :many
+select
+      i.*
+    , j.title as job_title
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS person_display_name
+    , (CASE WHEN c.display_name = '' THEN c.login ELSE c.display_name END)::varchar AS customer_display_name
+from job_invitations i
+join jobs j on j.id = i.job_id
+join persons p on p.id = i.person_id
+join persons c on c.id = i.created_by
+where i.person_id = @person_id::varchar and j.blocked_at is null
+order by i.created_at desc;
+
+-- name: InvitationRespond :one
+update job_invitations
+set
+    status = @status::varchar,
+    application_id = @application_id,
+    responded_at = now()
+where
+    id = @id::varchar and status = 'pending'
+returning *;
+
+-- name: InvitationsPurge :exec
+-- Handle with care!
+DELETE FROM job_invitations;
@@ -0,0 +1,51 @@
+-- name: JobQuestionAdd :one
+insert into job_questions (
+    id, job_id, "position", kind, "text", "options", required
+) values (
+    @id, @job_id, @position, @kind, @text, @options::varchar[], @required
+) returning *;
+
+-- name: JobQuestionsListByJob :many
+select q.* from job_questions q
+where q.job_id = @job_id::varchar
+order by q."position";
+
+-- name: JobQuestionsDeleteByJob :exec
+delete from job_questions where job_id = @job_id::varchar;
+
+-- name: ApplicationAnswerAdd :exec
+insert into application_answers (
+    application_id, question_id, "text", "options"
+) values (
+    @application_id, @question_id, @text, @options::varchar[]
+);
+
+-- name: ApplicationAnswersListByApplication :many
+select aa.*
+	, q.text as question_text
+	, q.kind as question_kind
+	from application_answers aa
+	join job_questions q on q.id = aa.question_id
+	where aa.application_id = @application_id::varchar
+	order by q."position";
+
+-- name: ApplicationAnswersListByJob :many
+select aa.*
+	, q.text as question_text
+	, q.kind as question_kind
+	from application_answers aa
+	join job_questions q on q.id = aa.question_id
+	where q.job_id = @job_id::varchar
+	order by aa.application_id, q."position";
+
+-- name: ApplicationsCountByJob :one
+select count(*) from applications a
+where a.job_id = @job_id::varchar;
+
+-- name: ApplicationAnswersPurge :exec
+-- Handle with care!
+DELETE FROM application_answers;
+
+-- name: JobQuestionsPurge :exec
+-- Handle with care!
+DELETE FROM job_questions;
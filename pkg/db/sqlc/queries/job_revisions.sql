@@ -0,0 +1,27 @@
+-- name: JobRevisionAdd :one
+insert into job_revisions (
+    id, job_id, version, title, description, budget, duration, expires_at, created_by
+) values (
+    @id,
+    @job_id,
+    (select coalesce(max(r.version), 0) + 1 from job_revisions r where r.job_id = @job_id::varchar),
+    @title,
+    @description,
+    @budget,
+    @duration,
+    @expires_at,
+    @created_by
+) returning *;
+
+-- name: JobRevisionsListByJob :many
+select
+      r.*
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS created_by_display_name
+from job_revisions r
+join persons p on p.id = r.created_by
+where r.job_id = @job_id::varchar
+order by r.version desc;
+
+-- name: JobRevisionsPurge :exec
+-- Handle with care!
+DELETE FROM job_revisions;
@@ -1,4 +1,5 @@
 -- name: JobsList :many
+-- Open public jobs matching the filter, empty keyword and zero budget limits mean no filtering, zero lim means no limit
 select
      j.id
     ,j.title
@@ -8,13 +9,23 @@ select
     ,j.created_at
     ,j.created_by
     ,j.updated_at
-    ,(select count(*) from applications a where a.job_id = j.id) as application_count
+    ,j.expires_at
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
     ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
     ,p.ethereum_address AS customer_ethereum_address
     from jobs j
     join persons p on p.id = j.created_by
-    where j.blocked_at is null and j.suspended_at is null and j.visibility = 'public'
-    order by j.updated_at desc;
+    where j.blocked_at is null and j.suspended_at is null and j.closed_at is null
+        and j.published_at is not null
+        and (j.expires_at is null or j.expires_at > now())
+        and j.visibility = 'public'
+        and (@keyword::varchar = ''
+            or strpos(lower(j.title), lower(@keyword::varchar)) > 0
+            or strpos(lower(j.description), lower(@keyword::varchar)) > 0)
+        and (@budget_min::decimal = 0 or j.budget >= @budget_min::decimal)
+        and (@budget_max::decimal = 0 or j.budget <= @budget_max::decimal)
+    order by j.updated_at desc
+    limit nullif(@lim::int, 0);
 
 -- name: JobGet :one
 select
@@ -27,22 +38,100 @@ select
     ,j.created_by
     ,j.updated_at
     ,j.suspended_at
-    ,(select count(*) from applications a where a.job_id = j.id) as application_count
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
     ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
     ,p.ethereum_address AS customer_ethereum_address
     from jobs j
     join persons p on p.id = j.created_by
     where j.id = @id::varchar and j.blocked_at is null;
 
+-- name: JobGetBlocked :one
+-- The same as JobGet, but returns only the blocked job of the owner, so the owner sees its status
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from jobs j
+    join persons p on p.id = j.created_by
+    where j.id = @id::varchar and j.created_by = @created_by::varchar and j.blocked_at is not null;
+
+-- name: JobsListByOwner :many
+-- Owner's jobs in every state. Columns must be the same as in JobGet
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from jobs j
+    join persons p on p.id = j.created_by
+    where j.created_by = @created_by::varchar
+    order by j.created_at desc;
+
 -- name: JobFind :one
--- It is used only for testing purposes.
+-- Returns the job in any state including blocked one
 select * from jobs where id = @id::varchar;
 
 -- name: JobAdd :one
 insert into jobs (
-    id, title, description, budget, duration, created_by
+    id, title, description, budget, duration, created_by, expires_at
 ) values (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) returning *;
 
 -- name: JobPatch :one
@@ -52,6 +141,10 @@ set
     description = @description::varchar,
     budget = @budget::decimal,
     duration = @duration::int,
+    expires_at = case
+        when @clear_expires_at::boolean then null
+        when @expires_at_change::boolean then @expires_at::timestamp
+        else expires_at end,
     updated_at = now()
 where
     id = @id::varchar and @actor::varchar = created_by
@@ -60,6 +153,9 @@ returning *;
 -- name: JobBlock :exec
 update jobs set blocked_at = now() where id = @id::varchar;
 
+-- name: JobUnblock :exec
+update jobs set blocked_at = null where id = @id::varchar;
+
 -- name: JobSuspend :exec
 update jobs set suspended_at = now() where id = @id::varchar;
 
@@ -71,4 +167,54 @@ update jobs set suspended_at = null where id = @id::varchar;
 DELETE FROM jobs;
 
 -- name: JobHide :exec
-update jobs set visibility = 'hidden' where id = @id::varchar;
\ No newline at end of file
+update jobs set visibility = 'hidden' where id = @id::varchar;
+
+-- name: JobSetVisibility :exec
+update jobs set visibility = @visibility::varchar where id = @id::varchar;
+
+-- name: JobsSuspendExpired :many
+-- Suspends all open jobs which expiration moment has come
+update jobs
+set suspended_at = now()
+where expires_at <= now() and suspended_at is null and closed_at is null and blocked_at is null
+    and published_at is not null
+returning *;
+
+-- name: JobCloseByApplication :exec
+update jobs
+set closed_at = now()
+where id = (select a.job_id from applications a where a.id = @application_id::varchar) and closed_at is null;
+
+-- name: JobReopen :exec
+update jobs
+set
+    closed_at = null,
+    suspended_at = null,
+    expires_at = @expires_at,
+    updated_at = now()
+where id = @id::varchar;
+
+-- name: JobMakeDraft :exec
+update jobs set published_at = null, publish_at = @publish_at where id = @id::varchar;
+
+-- name: JobPublish :exec
+update jobs
+set
+    published_at = case when @publish_at::timestamp <= now() then now() else null end,
+    publish_at = case when @publish_at::timestamp <= now() then null else @publish_at::timestamp end,
+    updated_at = now()
+where id = @id::varchar and published_at is null;
+
+-- name: JobsPublishScheduled :many
+-- Publishes all drafts which publication moment has come
+update jobs
+set published_at = now(), publish_at = null, updated_at = now()
+where published_at is null and publish_at <= now() and blocked_at is null
+returning *;
+
+-- name: JobsClaimForAlerts :many
+-- Marks published jobs which have not been matched against saved searches yet
+update jobs
+set alerts_matched_at = now()
+where alerts_matched_at is null and published_at is not null
+returning *;
@@ -7,6 +7,102 @@ from messages m
 where m.chat_id = @chat_id::varchar
 order by m.created_at asc;
 
+-- name: MessagesListByChatBackward :many
+-- Returns messages of the chat newest first, before and after bounds are applied if they are set
+select
+     m.*
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.chat_id = @chat_id::varchar
+    and (not @has_before::boolean or m.created_at < @before_at::timestamp or (m.created_at = @before_at::timestamp and m.id < @before_id::varchar))
+    and (not @has_after::boolean or m.created_at > @after_at::timestamp or (m.created_at = @after_at::timestamp and m.id > @after_id::varchar))
+order by m.created_at desc, m.id desc
+limit @lim::int;
+
+-- name: MessagesListByChatForward :many
+-- Returns messages of the chat oldest first, before and after bounds are applied if they are set
+select
+     m.*
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.chat_id = @chat_id::varchar
+    and (not @has_before::boolean or m.created_at < @before_at::timestamp or (m.created_at = @before_at::timestamp and m.id < @before_id::varchar))
+    and (not @has_after::boolean or m.created_at > @after_at::timestamp or (m.created_at = @after_at::timestamp and m.id > @after_id::varchar))
+order by m.created_at asc, m.id asc
+limit @lim::int;
+
+-- name: MessagesSearch :many
+-- Full-text search over messages of chats of the participant, the newest first.
+-- The snippet is HTML-escaped text of the message with matches marked by <mark> tag.
+select
+     m.id
+    ,m.chat_id
+    ,m.created_at
+    ,m.created_by
+    ,m.text
+    ,m.kind
+    ,m.payload
+    ,m.edited_at
+    ,p.display_name
+    ,c.topic
+    ,coalesce(j.id, '')::varchar as job_id
+    ,coalesce(j.title, '')::varchar as job_title
+    ,ts_headline('english',
+        replace(replace(replace(m.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+        q.query,
+        'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::varchar as snippet
+from messages m
+    join chats_participants cp on cp.chat_id = m.chat_id and cp.person_id = @participant_id::varchar
+    join chats c on c.id = m.chat_id
+    join persons p on p.id = m.created_by
+    cross join websearch_to_tsquery('english', @query::varchar) q(query)
+    left join applications a on split_part(c.topic, ':', 2) = 'application' and a.id = split_part(c.topic, ':', 3)
+    left join contracts ct on split_part(c.topic, ':', 2) = 'contract' and ct.id = split_part(c.topic, ':', 3)
+    left join applications ca on ca.id = ct.application_id
+    left join job_invitations i on split_part(c.topic, ':', 2) = 'invitation' and i.id = split_part(c.topic, ':', 3)
+    left join jobs j on j.id = coalesce(a.job_id, ca.job_id, i.job_id)
+where m.deleted_at is null
+    and to_tsvector('english', m.text) @@ q.query
+order by m.created_at desc, m.id desc
+limit @lim::int;
+
+-- name: MessagesUnreadForDigest :many
+-- Unread messages of other participants in chats of the person created after the previous digest, oldest first
+select
+     m.id
+    ,m.chat_id
+    ,m.created_at
+    ,m.text
+    ,m.kind
+    ,m.payload
+    ,p.display_name
+    ,c.topic
+from chats_participants cp
+    join persons me on me.id = cp.person_id
+    join messages m on m.chat_id = cp.chat_id
+    join chats c on c.id = m.chat_id
+    join persons p on p.id = m.created_by
+where cp.person_id = @participant_id::varchar
+    and m.created_by <> cp.person_id
+    and m.deleted_at is null
+    and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+    and (me.email_digest_sent_at is null or m.created_at > me.email_digest_sent_at)
+order by m.chat_id, m.created_at, m.id;
+
+-- name: MessageGet :one
+select m.* from messages m
+where m.id = @id::varchar;
+
+-- name: MessageGetWithAuthor :one
+select
+     m.*
+    ,p.display_name
+from messages m
+    join persons p on m.created_by = p.id
+where m.id = @id::varchar;
+
 -- name: MessageAdd :one
 insert into messages (
     id, chat_id, created_by, text
@@ -14,6 +110,47 @@ insert into messages (
     @id, @chat_id, @created_by, @text
 ) returning *;
 
+-- name: MessageAddSystem :one
+-- The text is a human readable description of the event for clients which do not know the event
+insert into messages (
+    id, chat_id, created_by, text, kind, payload
+) values (
+    @id, @chat_id, @created_by, @text, 'system', @payload
+) returning *;
+
+-- name: MessageUpdateText :one
+update messages set
+    "text" = @text,
+    edited_at = now()
+where id = @id::varchar
+returning *;
+
+-- name: MessageDelete :one
+update messages set
+    "text" = '',
+    deleted_at = now()
+where id = @id::varchar
+returning *;
+
+-- name: MessageRevisionAdd :one
+insert into message_revisions (
+    id, message_id, version, "text"
+) values (
+    @id,
+    @message_id,
+    (select coalesce(max(r.version), 0) + 1 from message_revisions r where r.message_id = @message_id::varchar),
+    @text
+) returning *;
+
+-- name: MessageRevisionsListByMessage :many
+select r.* from message_revisions r
+where r.message_id = @message_id::varchar
+order by r.version desc;
+
+-- name: MessageRevisionsPurge :exec
+-- Handle with care!
+DELETE FROM message_revisions;
+
 -- name: MessagesPurge :exec
 -- Handle with care!
 DELETE FROM messages;
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- name: PersonNotificationAdd :one
+insert into person_notifications (
+    id, person_id, kind, "text", link
+) values (
+    @id, @person_id, @kind, @text, @link
+) returning *;
+
+-- name: PersonNotificationsListByPerson :many
+select n.* from person_notifications n
+where n.person_id = @person_id::varchar
+order by n.created_at desc;
+
+-- name: PersonNotificationMarkRead :one
+update person_notifications
+set
+    read_at = coalesce(read_at, now())
+where
+    id = @id::varchar and person_id = @person_id::varchar
+returning *;
+
+-- name: PersonNotificationsPurge :exec
+-- Handle with care!
+DELETE FROM person_notifications;
@@ -0,0 +1,40 @@
+-- name: PersonBlockAdd :exec
+insert into person_blocks (
+    person_id, blocked_id
+) values (
+    @person_id, @blocked_id
+) on conflict do nothing;
+
+-- name: PersonBlockDelete :execrows
+delete from person_blocks where person_id = @person_id::varchar and blocked_id = @blocked_id::varchar;
+
+-- name: PersonBlocksListByPerson :many
+-- Persons blocked by the person from the last blocked
+select
+     p.id
+    ,p.display_name
+    ,p.ethereum_address
+    ,b.created_at
+from person_blocks b
+    join persons p on p.id = b.blocked_id
+where b.person_id = @person_id::varchar
+order by b.created_at desc, p.id;
+
+-- name: PersonBlocked :one
+-- Returns true if the person has blocked another one
+select exists (
+    select 1 from person_blocks b
+    where b.person_id = @person_id::varchar and b.blocked_id = @blocked_id::varchar
+)::boolean as blocked;
+
+-- name: ChatBlockedForPerson :one
+-- Returns true if any other participant of the chat has blocked the person
+select exists (
+    select 1 from chats_participants cp
+        join person_blocks b on b.person_id = cp.person_id
+    where cp.chat_id = @chat_id::varchar and b.blocked_id = @person_id::varchar
+)::boolean as blocked;
+
+-- name: PersonBlocksPurge :exec
+-- Handle with care!
+DELETE FROM person_blocks;
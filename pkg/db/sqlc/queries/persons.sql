@@ -1,8 +1,8 @@
 -- name: PersonAdd :one
 insert into persons (
-    id, realm, login, password_hash, display_name, email, access_token, ethereum_address
+    id, realm, login, password_hash, display_name, email, ethereum_address
 ) values (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7
 )
 returning *;
 
@@ -17,6 +17,18 @@ select * from persons p
 -- name: PersonsList :many
 select * from persons;
 
+-- name: PersonsSearch :many
+-- Empty query means all persons. The newest persons go first.
+select * from persons p
+where @query::varchar = ''
+    or p.login ilike '%' || @query::varchar || '%'
+    or p.display_name ilike '%' || @query::varchar || '%'
+    or p.email ilike '%' || @query::varchar || '%'
+    or p.id = @query::varchar
+    or lower(p.ethereum_address) = lower(@query::varchar)
+order by p.created_at desc, p.id
+limit @lim::int offset @off::int;
+
 -- name: PersonSetPassword :exec
 update persons
 set
@@ -65,18 +77,61 @@ where
     id = @id::varchar
 returning *;
 
--- name: PersonSetAccessToken :exec
--- Sets the person's access token
-update persons
-set
-    access_token = @access_token::varchar
-where
-    id = @id::varchar
+-- name: PersonsDueForDigest :many
+-- Persons with email whose digest period has passed and who have unread messages after the previous digest
+select p.* from persons p
+where p.email <> ''
+    and p.blocked_at is null
+    and p.email_digest <> 'never'
+    and (p.email_digest_sent_at is null or p.email_digest_sent_at <= now() - case p.email_digest
+        when 'hourly' then interval '1 hour'
+        when 'daily' then interval '1 day'
+        else interval '7 days' end)
+    and exists (
+        select 1 from chats_participants cp
+        join messages m on m.chat_id = cp.chat_id
+            and m.created_by <> cp.person_id
+            and m.deleted_at is null
+            and (cp.last_read_at is null or m.created_at > cp.last_read_at)
+            and (p.email_digest_sent_at is null or m.created_at > p.email_digest_sent_at)
+        where cp.person_id = p.id)
+order by p.id;
+
+-- name: PersonClaimDigest :execrows
+-- Unread messages created before are not included into the next digest.
+-- Nothing is updated when the digest has been claimed by someone else after the person was read.
+update persons set email_digest_sent_at = now()
+where id = @id::varchar and email_digest_sent_at is not distinct from @sent_at;
+
+-- name: PersonSetEmailDigest :one
+update persons set email_digest = @email_digest::varchar where id = @id::varchar
 returning *;
 
--- name: PersonGetByAccessToken :one
-select * from persons
-	where access_token = @access_token::varchar;
+-- name: UnsubscribeTokenAdd :exec
+insert into unsubscribe_tokens (token_hash, person_id) values (@token_hash::varchar, @person_id::varchar);
+
+-- name: UnsubscribeTokensDeleteOld :exec
+-- Unsubscribe links of emails sent more than a year ago stop working
+delete from unsubscribe_tokens
+where person_id = @person_id::varchar and created_at < now() - interval '1 year';
+
+-- name: UnsubscribeTokensPurge :exec
+-- Handle with care!
+DELETE FROM unsubscribe_tokens;
+
+-- name: UnsubscribeTokenGet :one
+select * from unsubscribe_tokens where token_hash = @token_hash::varchar;
+
+-- name: PersonUnsubscribe :one
+update persons set email_digest = 'never'
+where id = (select t.person_id from unsubscribe_tokens t where t.token_hash = @token_hash::varchar)
+returning *;
+
+-- name: PersonBlock :exec
+update persons set blocked_at = now() where id = @id::varchar;
+
+-- name: PersonUnblock :exec
+update persons set blocked_at = null where id = @id::varchar;
 
 -- name: PersonsPurge :exec
 -- Handle with care!
@@ -0,0 +1,47 @@
+-- name: ReportAdd :one
+insert into abuse_reports (
+    id, target_kind, target_id, reason, "comment", created_by
+) values (
+    @id, @target_kind, @target_id, @reason, @comment, @created_by
+) returning *;
+
+-- name: ReportGet :one
+select
+      r.*
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS reporter_display_name
+from abuse_reports r
+join persons p on p.id = r.created_by
+where r.id = @id::varchar;
+
+-- name: ReportsList :many
+-- Empty status means reports in any status. Open reports go first, the oldest first.
+select
+      r.*
+    , (CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS reporter_display_name
+from abuse_reports r
+join persons p on p.id = r.created_by
+where @status::varchar = '' or r.status = @status::varchar
+order by r.status = 'open' desc, r.created_at asc;
+
+-- name: ReportResolve :exec
+update abuse_reports
+set
+    status = @status::varchar,
+    resolved_by = @resolved_by::varchar,
+    resolved_at = now(),
+    resolution = @resolution::varchar
+where id = @id::varchar and status = 'open';
+
+-- name: ReportsResolveByTarget :execrows
+-- Resolves all open reports about the target, it is used when the target is blocked
+update abuse_reports
+set
+    status = 'resolved',
+    resolved_by = @resolved_by::varchar,
+    resolved_at = now(),
+    resolution = @resolution::varchar
+where target_kind = @target_kind::varchar and target_id = @target_id::varchar and status = 'open';
+
+-- name: ReportsPurge :exec
+-- Handle with care!
+DELETE FROM abuse_reports;
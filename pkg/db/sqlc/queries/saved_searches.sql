@@ -0,0 +1,95 @@
+-- name: SavedJobAdd :exec
+insert into saved_jobs (
+    person_id, job_id
+) values (
+    @person_id, @job_id
+) on conflict do nothing;
+
+-- name: SavedJobDelete :execrows
+delete from saved_jobs where person_id = @person_id::varchar and job_id = @job_id::varchar;
+
+-- name: SavedJobsListByPerson :many
+-- Saved jobs of the person from the last saved. Columns must be the same as in JobGet
+select
+    j.id
+    ,j.title
+    ,j.description
+    ,j.budget
+    ,j.duration
+    ,j.created_at
+    ,j.created_by
+    ,j.updated_at
+    ,j.suspended_at
+    ,j.visibility
+    ,j.expires_at
+    ,j.closed_at
+    ,j.published_at
+    ,j.publish_at
+    ,(case
+        when j.blocked_at is not null then 'blocked'
+        when j.published_at is null then 'draft'
+        when j.closed_at is not null then 'closed'
+        when j.expires_at is not null and j.expires_at <= now() then 'expired'
+        when j.suspended_at is not null then 'suspended'
+        else 'open'
+    end)::varchar as status
+    ,(select count(*) from applications a where a.job_id = j.id and a.withdrawn_at is null) as application_count
+    ,(CASE WHEN p.display_name = '' THEN p.login ELSE p.display_name END)::varchar AS customer_display_name
+    ,p.ethereum_address AS customer_ethereum_address
+    from saved_jobs s
+    join jobs j on j.id = s.job_id
+    join persons p on p.id = j.created_by
+    where s.person_id = @person_id::varchar and j.blocked_at is null
+    order by s.created_at desc;
+
+-- name: SavedJobsPurge :exec
+-- Handle with care!
+DELETE FROM saved_jobs;
+
+-- name: SavedSearchAdd :one
+insert into saved_searches (
+    id, person_id, title, keyword, budget_min, budget_max
+) values (
+    @id, @person_id, @title, @keyword, @budget_min, @budget_max
+) returning *;
+
+-- name: SavedSearchGet :one
+select * from saved_searches where id = @id::varchar;
+
+-- name: SavedSearchesListByPerson :many
+select * from saved_searches where person_id = @person_id::varchar order by created_at desc;
+
+-- name: SavedSearchUpdate :one
+update saved_searches
+set
+    title = @title::varchar,
+    keyword = @keyword::varchar,
+    budget_min = @budget_min,
+    budget_max = @budget_max,
+    updated_at = now()
+where id = @id::varchar
+returning *;
+
+-- name: SavedSearchDelete :exec
+delete from saved_searches where id = @id::varchar;
+
+-- name: SavedSearchesMatchJob :many
+-- Searches of other active persons which the open public job matches
+select s.* from saved_searches s
+    join persons p on p.id = s.person_id
+    join jobs j on j.id = @job_id::varchar
+    where s.person_id <> j.created_by and p.blocked_at is null
+        and j.blocked_at is null and j.suspended_at is null and j.closed_at is null
+        and j.published_at is not null
+        and (j.expires_at is null or j.expires_at > now())
+        and j.visibility = 'public'
+        and (s.keyword = ''
+            or strpos(lower(j.title), lower(s.keyword)) > 0
+            or strpos(lower(j.description), lower(s.keyword)) > 0)
+        and (s.budget_min is null or j.budget >= s.budget_min)
+        and (s.budget_max is null or j.budget <= s.budget_max)
+    order by s.created_at;
+
+-- name: SavedSearchesPurge :exec
+-- Handle with care!
+DELETE FROM saved_searches;
@@ -0,0 +1,42 @@
+-- name: SessionAdd :one
+insert into sessions (
+    id, person_id, token_hash, expires_at, user_agent, ip
+) values (
+    @id, @person_id, @token_hash, @expires_at, @user_agent, @ip
+) returning *;
+
+-- name: SessionGetByTokenHash :one
+-- Returns only not expired session
+select s.* from sessions s
+where s.token_hash = @token_hash::varchar and s.expires_at > now();
+
+-- name: SessionTouch :exec
+-- Prolongs the session, it is written not more often than once a minute
+update sessions
+set
+    last_used_at = now()
+    , expires_at = @expires_at::timestamp
+where
+    id = @id::varchar
+    and last_used_at < now() - interval '1 minute';
+
+-- name: SessionsListByPerson :many
+-- Recently used sessions go first
+select s.* from sessions s
+where s.person_id = @person_id::varchar and s.expires_at > now()
+order by s.last_used_at desc, s.id;
+
+-- name: SessionDelete :execrows
+delete from sessions
+where id = @id::varchar and person_id = @person_id::varchar;
+
+-- name: SessionsDeleteByPerson :exec
+delete from sessions where person_id = @person_id::varchar;
+
+-- name: SessionsDeleteExpired :exec
+delete from sessions
+where person_id = @person_id::varchar and expires_at <= now();
+
+-- name: SessionsPurge :exec
+-- Handle with care!
+DELETE FROM sessions;
@@ -11,7 +11,9 @@ from persons;
 -- name: StatsGetOpenedJobsCount :one
 select count(id) AS count
 from jobs
-where suspended_at is null and blocked_at is null;
+where suspended_at is null and blocked_at is null and closed_at is null
+    and published_at is not null
+    and (expires_at is null or expires_at > now());
 
 -- name: StatsGetContractsCount :one
 select count(id) AS count
@@ -20,14 +20,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/applications": {
+        "/admin/audit": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns applications",
+                "description": "Returns records of privileged actions from the newest to the oldest. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,16 +35,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "application"
+                    "admin"
+                ],
+                "summary": "List audit log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "job",
+                            "person",
+                            "message",
+                            "contract"
+                        ],
+                        "type": "string",
+                        "description": "Kind of the affected entity",
+                        "name": "target_kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the affected entity",
+                        "name": "target_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List of applications",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.ApplicationDTO"
+                                "$ref": "#/definitions/model.AuditRecordDTO"
                             }
                         }
                     },
@@ -54,6 +74,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -75,14 +101,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/applications/{id}": {
-            "get": {
+        "/admin/contracts/{id}/chat/join": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns an application by ID",
+                "description": "Adds the admin to participants of the contract chat to settle a dispute between the customer and the performer.\nThe reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,13 +116,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "application"
+                    "admin",
+                    "contract"
                 ],
-                "summary": "Get an application",
+                "summary": "Join the chat of the contract",
                 "parameters": [
+                    {
+                        "description": "Join params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.blockParams"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Application ID",
+                        "description": "Contract ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -106,7 +142,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ApplicationDTO"
+                            "$ref": "#/definitions/model.Chat"
+                        }
+                    },
+                    "400": {
+                        "description": "admin is already a participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "401": {
@@ -115,8 +157,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "404": {
-                        "description": "application not found",
+                        "description": "contract not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "reason is required",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -142,14 +196,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/applications/{id}/chat": {
+        "/admin/messages/{id}/revisions": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Performer or customer is getting chat for this application",
+                "description": "Returns previous texts of the edited or deleted chat message from the newest to the oldest.\nThe access is audited. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,14 +211,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "application",
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Get chat for an application",
+                "summary": "List previous versions of the message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Application ID",
+                        "description": "message ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -174,7 +227,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Chat"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MessageRevisionDTO"
+                            }
                         }
                     },
                     "401": {
@@ -183,8 +239,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "404": {
-                        "description": "application not found or user has no permissions for view chat",
+                        "description": "message not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -210,14 +272,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chats": {
+        "/admin/persons": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "User is requesting all available chats sorted by last message create time in reverse order",
+                "description": "Returns persons matching the query by login, display name, email, ID or ethereum address. The newest persons go first.\nTo execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,33 +287,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
+                ],
+                "summary": "List persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of persons, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of persons to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Returns list of chats for the current user",
                 "responses": {
                     "200": {
-                        "description": "chat will be returned with all messages",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.ChatDTO"
+                                "$ref": "#/definitions/model.Person"
                             }
                         }
                     },
                     "401": {
-                        "description": "user is not authorized",
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "403": {
-                        "description": "user is not conversation participant",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "404": {
-                        "description": "chat does not exist",
+                    "422": {
+                        "description": "validation failed",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -277,14 +359,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chats/{chat_id}": {
+        "/admin/persons/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "A chat participant is requesting chat description with all messages",
+                "description": "Returns the person with admin and blocking flags. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -292,39 +374,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Returns a fully chat description",
+                "summary": "Get a person",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "chat id",
-                        "name": "chat_id",
+                        "description": "Person ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "chat will be returned with all messages",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Chat"
+                            "$ref": "#/definitions/model.Person"
                         }
                     },
                     "401": {
-                        "description": "user is not authorized",
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "403": {
-                        "description": "user is not conversation participant",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "chat does not exist",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -350,14 +432,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chats/{chat_id}/messages": {
-            "post": {
+        "/admin/persons/{id}/applications": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "A chat participant sending message to the chat",
+                "description": "Returns all applications of the person. The action is audited. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -365,54 +447,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Post a new message to the chat",
+                "summary": "List applications of a person",
                 "parameters": [
-                    {
-                        "description": "New message",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.newMessage"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "chat id",
-                        "name": "chat_id",
+                        "description": "Person ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ApplicationDTO"
+                            }
                         }
                     },
                     "401": {
-                        "description": "user is not authorized",
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "403": {
-                        "description": "user is not conversation participant",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "chat does not exist",
-                        "schema": {
-                            "$ref": "#/definitions/model.BackendError"
-                        }
-                    },
-                    "422": {
-                        "description": "message text exceeds maximum text length",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -438,14 +508,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts": {
-            "get": {
+        "/admin/persons/{id}/block": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns list of contracts where the current user is performer or customer",
+                "description": "Blocks the person. Blocked person is unable to log in and to use the access token.\nThe reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -453,17 +523,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin",
+                    "person"
+                ],
+                "summary": "Block a person",
+                "parameters": [
+                    {
+                        "description": "Block params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.blockParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List contracts",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "person is already blocked",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ContractDTO"
-                            }
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "401": {
@@ -472,6 +561,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "reason is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -491,14 +598,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/persons/{id}/connects": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creates a new contract based on existent application.",
+                "description": "Adds connects to the balance of the person or takes them away with negative delta. The balance does not go below zero.\nThe action is audited. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,35 +615,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Create a new contract",
+                "summary": "Adjust connects balance",
                 "parameters": [
                     {
-                        "description": "Contract Params",
-                        "name": "job",
+                        "description": "Adjustment params",
+                        "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.createContractParams"
+                            "$ref": "#/definitions/controller.adjustConnectsParams"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "$ref": "#/definitions/model.ConnectsDTO"
                         }
                     },
-                    "400": {
-                        "description": "invalid format",
+                    "401": {
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "409": {
-                        "description": "duplication",
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -566,14 +688,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}": {
+        "/admin/persons/{id}/contracts": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns contract with specified id. This operation is allowed only for performer or customer.",
+                "description": "Returns all contracts where the person is a customer or a performer. The action is audited.\nTo execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,13 +703,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Get contract",
+                "summary": "List contracts of a person",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -597,7 +719,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContractDTO"
+                            }
                         }
                     },
                     "401": {
@@ -606,8 +731,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -633,14 +764,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/accept": {
+        "/admin/persons/{id}/grant-admin": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Performer is accepting contract",
+                "description": "Grants admin privileges to the person. The action is audited. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -648,13 +779,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Accept contract",
+                "summary": "Grant admin privileges",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Contract ID",
+                        "description": "Action params",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.adminActionParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -664,29 +803,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "$ref": "#/definitions/model.Person"
                         }
                     },
-                    "401": {
-                        "description": "user not authorized",
+                    "400": {
+                        "description": "person is already admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "403": {
-                        "description": "insufficient rights",
+                    "401": {
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "404": {
-                        "description": "contract not found or user not authorized to view contract",
+                    "403": {
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "422": {
-                        "description": "validation failed",
+                    "404": {
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -712,14 +851,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/approve": {
+        "/admin/persons/{id}/impersonate": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Customer is approving performer's working results",
+                "description": "Issues a temporary token to act as the person for support. The reason is mandatory.\nIssuing of the token and every request made with it are audited. Admins and blocked persons can not be impersonated.\nTo execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -727,13 +866,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Approve working results and allow to withdraw money from Smart Contract",
+                "summary": "Impersonate a person",
                 "parameters": [
+                    {
+                        "description": "Action params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.adminActionParams"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -743,7 +891,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "$ref": "#/definitions/model.UserContext"
+                        }
+                    },
+                    "400": {
+                        "description": "person can not be impersonated",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "401": {
@@ -753,19 +907,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "422": {
-                        "description": "insufficient funds on the contract in the blockchain",
+                        "description": "reason is required",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -791,14 +945,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/complete": {
-            "post": {
+        "/admin/persons/{id}/jobs": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Performer is completing contract",
+                "description": "Returns all jobs of the person in every state. The action is audited. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -806,13 +960,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Complete contract",
+                "summary": "List jobs of a person",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -822,7 +976,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobCardDTO"
+                            }
                         }
                     },
                     "401": {
@@ -832,13 +989,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -864,14 +1021,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/deploy": {
+        "/admin/persons/{id}/reset-token": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Customer has deployed contract to the blockchain",
+                "description": "Ends all sessions and impersonations of the person and revokes API keys, so the person has to log in again on every device.\nThe action is audited.\nTo execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -879,13 +1036,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Deploy contract",
+                "summary": "Reset access token",
                 "parameters": [
+                    {
+                        "description": "Action params",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.adminActionParams"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -893,16 +1058,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
-                        }
-                    },
-                    "400": {
-                        "description": "invalid format",
-                        "schema": {
-                            "$ref": "#/definitions/model.BackendError"
-                        }
+                        "description": "OK"
                     },
                     "401": {
                         "description": "user not authorized",
@@ -911,19 +1067,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
-                        "schema": {
-                            "$ref": "#/definitions/model.BackendError"
-                        }
-                    },
-                    "422": {
-                        "description": "validation failed",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -949,14 +1099,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/fund": {
+        "/admin/persons/{id}/revoke-admin": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Customer is funded contract in the blockchain",
+                "description": "Revokes admin privileges from the person. Admin is unable to revoke own privileges. The action is audited.\nTo execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -964,13 +1114,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin"
                 ],
-                "summary": "Fund contract",
+                "summary": "Revoke admin privileges",
                 "parameters": [
+                    {
+                        "description": "Action params",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.adminActionParams"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -980,11 +1138,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
+                            "$ref": "#/definitions/model.Person"
                         }
                     },
                     "400": {
-                        "description": "invalid format",
+                        "description": "person is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -996,19 +1154,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
-                        "schema": {
-                            "$ref": "#/definitions/model.BackendError"
-                        }
-                    },
-                    "422": {
-                        "description": "validation failed",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1034,14 +1186,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/sign": {
+        "/admin/persons/{id}/unblock": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Performer is signing contract",
+                "description": "Unblocks previously blocked person. The reason is mandatory, it is kept in the audit log. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1049,13 +1201,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "admin",
+                    "person"
                 ],
-                "summary": "Sign contract",
+                "summary": "Unblock a person",
                 "parameters": [
+                    {
+                        "description": "Unblock params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.blockParams"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Contract ID",
+                        "description": "Person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1063,13 +1225,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.ContractDTO"
-                        }
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "invalid format",
+                        "description": "person is not blocked",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1081,19 +1240,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "contract not found or user not authorized to view contract",
+                        "description": "person not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "422": {
-                        "description": "validation failed",
+                        "description": "reason is required",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1119,14 +1278,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs": {
+        "/admin/reports": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns list of jobs",
+                "description": "Returns the moderation queue. Open reports go first, the oldest first. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1134,82 +1293,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "job"
+                    "report",
+                    "admin"
+                ],
+                "summary": "List reports",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "resolved",
+                            "dismissed"
+                        ],
+                        "type": "string",
+                        "description": "Report status",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List jobs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.JobDTO"
+                                "$ref": "#/definitions/model.ReportDTO"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/echo.HTTPError"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Creates a new job",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "job"
-                ],
-                "summary": "Create a new job",
-                "parameters": [
-                    {
-                        "description": "Job Params",
-                        "name": "job",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.createJobParams"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    "401": {
+                        "description": "user not authorized",
                         "schema": {
-                            "$ref": "#/definitions/model.JobDTO"
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "400": {
-                        "description": "invalid format",
+                    "403": {
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "422": {
-                        "description": "validation failed",
+                        "description": "invalid status",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1235,14 +1359,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}": {
+        "/admin/reports/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns job by id",
+                "description": "Returns a report by ID. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1250,13 +1374,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "job"
+                    "report",
+                    "admin"
                 ],
-                "summary": "Get job by id",
+                "summary": "Get a report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Report ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1266,11 +1391,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.JobCardDTO"
+                            "$ref": "#/definitions/model.ReportDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "report not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1294,14 +1431,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/reports/{id}/dismiss": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Updates job",
+                "description": "Dismisses the open report without any action. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1309,22 +1448,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "job"
+                    "report",
+                    "admin"
                 ],
-                "summary": "Update job",
+                "summary": "Dismiss a report",
                 "parameters": [
                     {
-                        "description": "Job params",
-                        "name": "job",
+                        "description": "Dismiss params",
+                        "name": "resolution",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.updateJobParams"
+                            "$ref": "#/definitions/controller.dismissReportParams"
                         }
                     },
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Report ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1334,11 +1473,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.JobDTO"
+                            "$ref": "#/definitions/model.ReportDTO"
                         }
                     },
                     "400": {
-                        "description": "invalid format",
+                        "description": "report is already resolved",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1349,14 +1488,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "404": {
-                        "description": "job not found or current user is not creator",
+                    "403": {
+                        "description": "user is not admin",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "422": {
-                        "description": "validation failed",
+                    "404": {
+                        "description": "report not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1382,14 +1521,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}/block": {
+        "/admin/reports/{id}/resolve": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Blocks existent job to hide it from public access. To execute this action, user must have admin privileges.",
+                "description": "Resolves the open report. If block is true, the reported job or person is blocked, the author is blocked for the reported message.\nAll other open reports about the blocked entity are resolved as well. To execute this action, user must have admin privileges.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,13 +1536,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "job"
+                    "report",
+                    "admin"
                 ],
-                "summary": "Block a job",
+                "summary": "Resolve a report",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Job ID",
+                        "description": "Resolution params",
+                        "name": "resolution",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.resolveReportParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Report ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1411,7 +1560,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "report is already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
                     },
                     "401": {
                         "description": "user not authorized",
@@ -1425,66 +1583,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/echo.HTTPError"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/jobs/{id}/resume": {
-            "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Resumes existent job to continue receiving applications",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "job"
-                ],
-                "summary": "Resume a job",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "401": {
-                        "description": "user not authorized",
+                    "404": {
+                        "description": "report not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "403": {
-                        "description": "user is not an owner",
+                    "422": {
+                        "description": "resolution is required",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1510,14 +1616,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}/suspend": {
-            "post": {
+        "/applications": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Suspends existent job to stop receiving applications",
+                "description": "Returns applications",
                 "consumes": [
                     "application/json"
                 ],
@@ -1525,30 +1631,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "job"
-                ],
-                "summary": "Suspend a job",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "application"
                 ],
+                "summary": "List of applications",
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "401": {
-                        "description": "user not authorized",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.BackendError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ApplicationDTO"
+                            }
                         }
                     },
-                    "403": {
-                        "description": "user is not an owner",
+                    "401": {
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1574,14 +1671,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{job_id}/application": {
+        "/applications/{application_id}/attachments": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns an application",
+                "description": "Returns files attached to the application. Only the applicant and the job owner can list them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1589,14 +1686,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
+                    "attachment",
                     "application"
                 ],
-                "summary": "Get an application for specific job and applicant",
+                "summary": "List application attachments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "job_id",
+                        "description": "Application ID",
+                        "name": "application_id",
                         "in": "path",
                         "required": true
                     }
@@ -1605,7 +1703,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ApplicationDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AttachmentDTO"
+                            }
                         }
                     },
                     "401": {
@@ -1615,7 +1716,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "application not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1641,14 +1742,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{job_id}/applications": {
+        "/applications/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns applications list for the job by job_id",
+                "description": "Returns an application by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1656,15 +1757,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "application",
-                    "job"
+                    "application"
                 ],
-                "summary": "List applications for the job",
+                "summary": "Get an application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "job_id",
+                        "description": "Application ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1673,10 +1773,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ApplicationDTO"
-                            }
+                            "$ref": "#/definitions/model.ApplicationDTO"
                         }
                     },
                     "401": {
@@ -1686,7 +1783,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "application not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1711,13 +1808,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Applicant creates a new application for a job",
+                "description": "Applicant changes comment and price of the application until a contract is created.\nEvery change is saved as a new revision and posted to the application chat.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1725,43 +1822,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "application",
-                    "job"
+                    "application"
                 ],
-                "summary": "Creates a new application for a job",
+                "summary": "Update an application",
                 "parameters": [
                     {
-                        "description": "New application request",
+                        "description": "Application terms",
                         "name": "application",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.createApplicationParams"
+                            "$ref": "#/definitions/controller.updateApplicationParams"
                         }
                     },
                     {
                         "type": "string",
-                        "description": "Job ID to apply",
-                        "name": "job_id",
+                        "description": "Application ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.ApplicationDTO"
                         }
                     },
+                    "400": {
+                        "description": "application is withdrawn or has a contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "401": {
                         "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not an applicant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "404": {
-                        "description": "job with specified ID is not found",
+                        "description": "application not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1793,9 +1901,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Create user security token for supplied conditionals",
+        "/applications/{id}/chat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Performer or customer is getting chat for this application",
                 "consumes": [
                     "application/json"
                 ],
@@ -1803,29 +1916,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "application",
+                    "chat"
                 ],
-                "summary": "Login",
+                "summary": "Get chat for an application",
                 "parameters": [
                     {
-                        "description": "Login request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.loginParams"
-                        }
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserContext"
+                            "$ref": "#/definitions/model.Chat"
                         }
                     },
-                    "422": {
-                        "description": "unable to login (login or password is not valid)",
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "application not found or user has no permissions for view chat",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -1851,14 +1969,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
+        "/applications/{id}/revisions": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns information about current authenticated user",
+                "description": "Returns the edit history of the application from the newest revision to the oldest one. It is available for the applicant and the job owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1866,14 +1984,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "application"
+                ],
+                "summary": "List application revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Returns current user information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserContext"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ApplicationRevisionDTO"
+                            }
                         }
                     },
                     "401": {
@@ -1882,6 +2012,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is neither an applicant nor a job owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "application not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1903,14 +2045,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/password": {
-            "put": {
+        "/applications/{id}/status": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Change password for current authenticated user",
+                "description": "Job owner moves the application through the pipeline. Rejection reason is allowed for rejected status only.\nThe applicant gets a notification on each change.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1918,34 +2060,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "application"
                 ],
-                "summary": "Change password for current authenticated user",
+                "summary": "Change application status",
                 "parameters": [
                     {
-                        "description": "Change password request",
-                        "name": "body",
+                        "description": "New status",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.newPasswordParams"
+                            "$ref": "#/definitions/controller.applicationStatusParams"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserContext"
+                            "$ref": "#/definitions/model.ApplicationDTO"
                         }
                     },
-                    "401": {
-                        "description": "user not authorized or old password is incorrect",
+                    "400": {
+                        "description": "application is withdrawn or already in the status",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "422": {
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not a job owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "application not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
                         "description": "validation failed",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
@@ -1972,14 +2139,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/persons/{id}": {
-            "get": {
+        "/applications/{id}/withdraw": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Returns person description by id",
+                "description": "Applicant withdraws the application until a contract is created. The applicant is able to apply for the job again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1987,13 +2154,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "application"
                 ],
-                "summary": "Get person description by id",
+                "summary": "Withdraw an application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Person ID",
+                        "description": "Application ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2003,7 +2170,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Person"
+                            "$ref": "#/definitions/model.ApplicationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "application is withdrawn or has a contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "401": {
@@ -2012,8 +2185,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
+                    "403": {
+                        "description": "user is not an applicant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
                     "404": {
-                        "description": "person not found",
+                        "description": "application not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -2037,69 +2216,108 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/attachments": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Updates existent person. User must be authenticated as this person.",
+                "description": "Uploads a file. Use its ID in attachment_ids when creating a job, an application or a chat message to attach the file.\nUntil then the file is available only for the uploader. A thumbnail is made for PNG, JPEG and GIF images.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "attachment"
                 ],
-                "summary": "Update existent person",
+                "summary": "Upload a file",
                 "parameters": [
                     {
-                        "description": "Update person details",
-                        "name": "job",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.updatePerson"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Person ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Person"
+                            "$ref": "#/definitions/model.AttachmentDTO"
                         }
                     },
-                    "400": {
-                        "description": "invalid format",
+                    "401": {
+                        "description": "user not authorized",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "401": {
-                        "description": "user not authorized",
+                    "422": {
+                        "description": "file is too long, file type is not allowed or file is infected",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
-                    "403": {
-                        "description": "insufficient rights",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.BackendError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/attachments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns content of the attached file. Job attachments are available for everyone who can see the job,\napplication attachments are available for the applicant and the job owner only,\nmessage attachments are available for participants of the chat only.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Download a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
                         }
                     },
                     "404": {
-                        "description": "person not found",
+                        "description": "attachment not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -2123,16 +2341,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/persons/{id}/resources": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Updates resources for existent person. User must be authenticated as this person.",
+                "description": "Deletes the file uploaded by the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2140,22 +2356,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "attachment"
                 ],
-                "summary": "Set resources for person",
+                "summary": "Delete a file",
                 "parameters": [
-                    {
-                        "description": "Person resources in JSON",
-                        "name": "job",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Person ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2165,12 +2372,6 @@ const docTemplate = `{
                     "200": {
                         "description": "OK"
                     },
-                    "400": {
-                        "description": "invalid format (body should be formatted as JSON)",
-                        "schema": {
-                            "$ref": "#/definitions/model.BackendError"
-                        }
-                    },
                     "401": {
                         "description": "user not authorized",
                         "schema": {
@@ -2178,13 +2379,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "insufficient rights",
+                        "description": "user is not an uploader",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "404": {
-                        "description": "person not found",
+                        "description": "attachment not found",
                         "schema": {
                             "$ref": "#/definitions/model.BackendError"
                         }
@@ -2210,53 +2411,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup": {
-            "post": {
-                "description": "Register a new user with specified description",
-                "consumes": [
-                    "application/json"
+        "/attachments/{id}/thumbnail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
                 ],
+                "description": "Returns a preview of the attached image which fits into 256x256 pixels.\nThe thumbnail is available for those who can download the file.",
                 "produces": [
-                    "application/json"
+                    "image/png",
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "auth"
+                    "attachment"
                 ],
-                "summary": "Register a new user",
+                "summary": "Download a thumbnail",
                 "parameters": [
                     {
-                        "description": "Register new user",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Person"
-                        }
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserContext"
+                            "type": "file"
                         }
                     },
-                    "422": {
-                        "description": "input object is invalid",
+                    "404": {
+                        "description": "attachment not found or it has no thumbnail",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/echo.HTTPError"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "500": {
@@ -2280,21 +2470,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/stats": {
+        "/chats": {
             "get": {
-                "description": "Get stats",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "User is requesting all available chats sorted by last message create time in reverse order.\nEvery chat contains the count of messages which are not read by the user.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "chat"
                 ],
-                "summary": "Get stats",
+                "summary": "Returns list of chats for the current user",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "chat will be returned with all messages",
                         "schema": {
-                            "$ref": "#/definitions/model.Stats"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ChatDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
                         }
                     },
                     "500": {
@@ -2317,59 +2536,6428 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.createApplicationParams": {
-            "type": "object",
-            "required": [
-                "comment",
-                "price"
-            ],
-            "properties": {
-                "comment": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                }
-            }
         },
-        "controller.createContractParams": {
-            "type": "object",
-            "required": [
-                "application_id",
-                "description",
-                "price",
-                "title"
-            ],
-            "properties": {
-                "application_id": {
+        "/chats/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Streams events of all chats of the current user as a fallback for clients without WebSocket.\nThe event name is the kind of the event (message, edit, delete, typing or read), the data is the JSON object of the event.\nTyping indicators are sent with POST /chats/{chat_id}/typing.\nThe Authorization header is required, browsers need an EventSource implementation supporting headers.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Chat events over Server-Sent Events",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Full-text search over messages of all chats of the current user, the newest messages go first.\nThe query supports web search syntax: \"quoted phrase\", OR and -excluded words. Deleted messages are not found.\nThe snippet contains HTML-escaped fragments of the message where matches are wrapped into \u003cmark\u003e tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Search chat messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of messages, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MessageSearchHitDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "empty query or invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upgrades the connection to WebSocket and pushes events of all chats of the current user as JSON objects:\nnew messages including system ones (kind is message), edited and deleted messages (kind is edit or delete),\ntyping indicators of other participants (kind is typing)\nand read markers of participants (kind is read).\nThe client may send {\"kind\":\"typing\",\"chat_id\":\"...\"} to notify other participants that the user is typing.\nBrowsers can not set headers for WebSocket, so they pass the ticket issued by POST /chats/ws/ticket instead.",
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Chat events over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket from POST /chats/ws/ticket, if Authorization header is not set",
+                        "name": "ticket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized or the ticket is used or expired",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/ws/ticket": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Issues a single-use ticket for the WebSocket handshake of GET /chats/ws, it expires in 30 seconds.\nBrowsers can not set headers for WebSocket, so the ticket is passed in the URL instead of the access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Issue a WebSocket ticket",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatTicketDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/{chat_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "A chat participant is requesting chat description with the most recent messages.\nhas_older is set if there are older messages, they are available with GET /chats/{chat_id}/messages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Returns a fully chat description",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "chat will be returned with the most recent messages",
+                        "schema": {
+                            "$ref": "#/definitions/model.Chat"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/{chat_id}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "A chat participant is requesting messages of the chat in order of creation.\nThe most recent messages are returned without cursors.\nMessages right before the message with the before ID are returned to scroll back,\nmessages right after the message with the after ID are returned to catch up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Returns a page of chat messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the message, older messages are returned",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the message, newer messages are returned",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of messages, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MessagePageDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist or user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid limit or the message of the cursor does not belong to the chat",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "A chat participant sending message to the chat. Previously uploaded files may be attached to the message,\nthe text may be omitted then. Attached files are available for participants of the chat only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Post a new message to the chat",
+                "parameters": [
+                    {
+                        "description": "New message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.newMessage"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "message text exceeds maximum text length or attachments are unknown",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/{chat_id}/messages/{message_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "The author replaces the text of the message within the edit window after posting.\nThe previous text is kept for moderation. System messages can not be edited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Edit own message",
+                "parameters": [
+                    {
+                        "description": "New text of the message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.newMessage"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "message id",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "message is a system one, is deleted or the edit window has passed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not the author of the message",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "message does not exist or user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "message text is empty or exceeds maximum text length",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "The author deletes the message within the edit window after posting.\nThe message remains in the chat with deleted_at and empty text, the text is kept for moderation.\nSystem messages can not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Delete own message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "message id",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "message is a system one, is already deleted or the edit window has passed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not the author of the message",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "message does not exist or user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/{chat_id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Marks messages of the chat as read by the current user up to the message with message_id including it.\nAll messages are marked as read if message_id is not specified. The read marker never moves backward.\nOther participants get a read event, so they know their messages are seen.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Mark chat messages as read",
+                "parameters": [
+                    {
+                        "description": "Read params",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.markReadParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist or user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "message does not belong to the chat",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/chats/{chat_id}/typing": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Notifies other participants of the chat that the current user is typing. It is intended for clients using Server-Sent Events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Notify about typing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chat id",
+                        "name": "chat_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "chat does not exist or user is not conversation participant",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns list of contracts where the current user is performer or customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "List contracts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContractDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creates a new contract based on existent application.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Create a new contract",
+                "parameters": [
+                    {
+                        "description": "Contract Params",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.createContractParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "409": {
+                        "description": "duplication",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns contract with specified id. This operation is allowed only for performer or customer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Performer is accepting contract",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Accept contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Customer is approving performer's working results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Approve working results and allow to withdraw money from Smart Contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "insufficient funds on the contract in the blockchain",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/chat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns the chat of the contract with the latest messages. Contract status changes are posted into this chat.\nThis operation is allowed only for performer, customer or administrator joined the chat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get contract chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Chat"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view the chat",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Performer is completing contract",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Complete contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/deploy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Customer has deployed contract to the blockchain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Deploy contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/fund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Customer is funded contract in the blockchain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Fund contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/sign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Performer is signing contract",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Sign contract",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContractDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "contract not found or user not authorized to view contract",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/email-digest/unsubscribe": {
+            "get": {
+                "description": "Checks the token from the link in the email without unsubscribing, so the web page is able to ask for confirmation.\nAuthorization is not required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Check unsubscribe token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the unsubscribe link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "unknown token",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "token is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stops email digests for the person with the token from the link in the email. Authorization is not required.\nIt is used for one-click unsubscribe by mail clients (RFC 8058) and by the confirmation web page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Unsubscribe from email digests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the unsubscribe link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "unknown token",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "token is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns invitations addressed to the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation"
+                ],
+                "summary": "List invitations of the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InvitationDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns an invitation by ID. It is available for the job owner and the invited person only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation"
+                ],
+                "summary": "Get an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Invited person accepts the invitation. A new application for the job is created with supplied comment, price, attachments and answers to screening questions.\nThe application costs connects like a direct one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation",
+                    "application"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "description": "Application params",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.acceptInvitationParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invitation is already responded",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an invited person",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "409": {
+                        "description": "application already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed or not enough connects",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Invited person declines the invitation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation"
+                ],
+                "summary": "Decline an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invitation is already responded",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an invited person",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns list of open public jobs from the last updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "List jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text which the job title or description has to contain",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimal job budget",
+                        "name": "budget_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximal job budget",
+                        "name": "budget_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobDTO"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creates a new job. If expires_at is specified, the job stops receiving applications after this moment.\nPreviously uploaded files can be attached with attachment_ids.\nIf draft is true or publish_at is specified, the job is saved as a draft which is visible for the owner only.\nThe draft with publish_at is published automatically at this moment.\nScreening questions can be specified with questions, applicants have to answer required ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Create a new job",
+                "parameters": [
+                    {
+                        "description": "Job Params",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.createJobParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/feed.atom": {
+            "get": {
+                "description": "Returns the last updated open public jobs as an Atom feed. It supports the same filter as the job list\nand conditional requests with If-None-Match and If-Modified-Since headers.",
+                "produces": [
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Atom feed of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text which the job title or description has to contain",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimal job budget",
+                        "name": "budget_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximal job budget",
+                        "name": "budget_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/feed.json": {
+            "get": {
+                "description": "Returns the last updated open public jobs as a JSON Feed 1.1. It supports the same filter as the job list\nand conditional requests with If-None-Match and If-Modified-Since headers.",
+                "produces": [
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "JSON Feed of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text which the job title or description has to contain",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimal job budget",
+                        "name": "budget_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximal job budget",
+                        "name": "budget_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/feed.rss": {
+            "get": {
+                "description": "Returns the last updated open public jobs as an RSS 2.0 feed. It supports the same filter as the job list\nand conditional requests with If-None-Match and If-Modified-Since headers.",
+                "produces": [
+                    "application/rss+xml"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "RSS feed of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text which the job title or description has to contain",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimal job budget",
+                        "name": "budget_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximal job budget",
+                        "name": "budget_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/mine": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns all jobs of the current user in every state: drafts, open, suspended, expired, closed and blocked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "List jobs of the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobCardDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns job by id. Private job is available only for its owner and invited persons. Draft is available only for its owner. Blocked job is available only for its owner with blocked status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Get job by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobCardDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updates job. Every change of the job terms is saved as a new revision. Applicants are notified in application chats when the budget or description changes.\nThe expiration is kept without expires_at, clear_expires_at removes it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Update job",
+                "parameters": [
+                    {
+                        "description": "Job params",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.updateJobParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found or current user is not creator",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/block": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Blocks existent job to hide it from public access. To execute this action, user must have admin privileges.\nThe reason is mandatory, it is kept in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Block a job",
+                "parameters": [
+                    {
+                        "description": "Block params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.blockParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found or already blocked",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "reason is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Publishes the draft immediately or schedules its publication, if publish_at is in the future",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Publish a draft",
+                "parameters": [
+                    {
+                        "description": "Publish params",
+                        "name": "job",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.publishJobParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobCardDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "job is already published",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/questions": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Replaces screening questions of the job. Questions can be changed only until the job gets the first application.\nOptions are required for single_choice and multi_choice questions. An empty list removes all questions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Set job screening questions",
+                "parameters": [
+                    {
+                        "description": "Questions",
+                        "name": "questions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.setJobQuestionsParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobQuestionDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format or the job already has applications",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not the job owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Reopens closed or expired job to continue receiving applications. New expiration moment may be specified, otherwise the job does not expire.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Reopen a job",
+                "parameters": [
+                    {
+                        "description": "Reopen params",
+                        "name": "job",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.reopenJobParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JobCardDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "job is not closed or expired",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Resumes existent job to continue receiving applications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Resume a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns the edit history of the job from the newest revision to the oldest one. It is available for everyone who can see the job.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "List job revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobRevisionDTO"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Suspends existent job to stop receiving applications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Suspend a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/unblock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Unblocks previously blocked job. To execute this action, user must have admin privileges.\nThe reason is mandatory, it is kept in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "Unblock a job",
+                "parameters": [
+                    {
+                        "description": "Unblock params",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.blockParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "job is not blocked",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "reason is required",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{job_id}/application": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns an application",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "application"
+                ],
+                "summary": "Get an application for specific job and applicant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApplicationDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{job_id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns applications list for the job by job_id. Only active applications in the status are returned if the status is specified.\nEvery application contains answers to all screening questions of the job in the same order to compare applicants side by side.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "application",
+                    "job"
+                ],
+                "summary": "List applications for the job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "shortlisted",
+                            "interviewing",
+                            "rejected",
+                            "hired"
+                        ],
+                        "type": "string",
+                        "description": "Application status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ApplicationDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Applicant creates a new application for a job. Previously uploaded files can be attached with attachment_ids.\nScreening questions of the job are answered with answers: text for text questions and options for choice questions.\nRequired questions must be answered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "application",
+                    "job"
+                ],
+                "summary": "Creates a new application for a job",
+                "parameters": [
+                    {
+                        "description": "New application request",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.createApplicationParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID to apply",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApplicationDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job with specified ID is not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{job_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns files attached to the job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment",
+                    "job"
+                ],
+                "summary": "List job attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AttachmentDTO"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{job_id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns invitations to the job. Only the job owner can list them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation",
+                    "job"
+                ],
+                "summary": "List invitations to the job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InvitationDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner of the job",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Customer invites a person by ID or login to apply for the job. The invited person gets a notification and a chat with the customer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitation",
+                    "job"
+                ],
+                "summary": "Invite a person to the job",
+                "parameters": [
+                    {
+                        "description": "Invitation params (person_id or login is required)",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.createInvitationParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "inappropriate action",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner of the job or is blocked by the person",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job or person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "409": {
+                        "description": "person is already invited or applied",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Create user security token for supplied conditionals\nEvery login starts a new session, so the user may be logged in on many devices at once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.loginParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContext"
+                        }
+                    },
+                    "422": {
+                        "description": "unable to login (login or password is not valid)",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ends the session of the request, other sessions of the current user stay alive",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "impersonation is not a session",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns information about current authenticated user\nThe remaining balance of connects spent on applications is returned if connects are limited.\nThe total count of unread messages in all chats of the user is returned too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Returns current user information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContext"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns personal API keys of the current user from the newest. Keys themselves are not returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.APIKeyDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creates a personal API key for integrations. The key is returned only in this response, it is not stored.\nRequests with the key in Bearer authorization are made on behalf of the current user, but only routes of the scopes are accessible.\nThe key is accepted only from addresses of the allowlist if it is not empty. The key does not expire without expires_at.\nAPI keys are not able to manage API keys and sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Key params",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.apiKeyParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIKeyDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Deletes the API key of the current user, requests with the key are not authorized anymore",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/blocked": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns persons blocked by the current user from the last blocked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "person"
+                ],
+                "summary": "List blocked persons",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BlockedPersonDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/blocked/{person_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Blocks the person for the current user. Blocked person is unable to post into shared chats,\nto apply to jobs of the current user, and the current user is unable to offer contracts to the blocked person.\nBlocking of the already blocked person has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "person"
+                ],
+                "summary": "Block a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "person_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "unable to block yourself",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Removes the person from persons blocked by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "person"
+                ],
+                "summary": "Unblock a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "person_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person is not blocked",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/email-digest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns how often the current user receives email digests of unread chat messages and contract status changes.\nDigests are off (never) until the user chooses a frequency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "chat"
+                ],
+                "summary": "Get email digest settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.EmailDigestSettingsDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Changes how often the current user receives email digests. Digests are not sent with never frequency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "chat"
+                ],
+                "summary": "Update email digest settings",
+                "parameters": [
+                    {
+                        "description": "Digest params",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.emailDigestParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.EmailDigestSettingsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "unknown frequency",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns in-app notifications addressed to the current user from the newest to the oldest",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "notification"
+                ],
+                "summary": "List notifications of the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PersonNotification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Marks the notification of the current user as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "notification"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PersonNotification"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/saved-jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns jobs saved by the current user from the last saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "List saved jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.JobCardDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/saved-jobs/{job_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Bookmarks the job for the current user. Saving of the already saved job has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Save a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Removes the job from bookmarks of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Unsave a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "job is not saved",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/saved-searches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns search criteria saved by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SavedSearchDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Saves search criteria. The user gets alerts about new jobs which contain the keyword in the title or description\nand which budget is in the range. Zero budget limit means no limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Save a search",
+                "parameters": [
+                    {
+                        "description": "Search criteria",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.savedSearchParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedSearchDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/saved-searches/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns the search criteria saved by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Get a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedSearchDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner of the search",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "saved search not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Replaces criteria of the search saved by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Update a saved search",
+                "parameters": [
+                    {
+                        "description": "Search criteria",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.savedSearchParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedSearchDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner of the search",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "saved search not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Deletes the search saved by the current user, the user does not get alerts about it anymore",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth",
+                    "job"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "user is not an owner of the search",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "saved search not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns logged in sessions of the current user from the recently used.\nThe session of the request is marked as current. Sessions expire after 30 days without use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SessionDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ends the session of the current user, for example on the lost device. The token of the session is not valid anymore.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "End a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "session not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change password for current authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Change password for current authenticated user",
+                "parameters": [
+                    {
+                        "description": "Change password request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.newPasswordParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContext"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized or old password is incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/persons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Returns person description by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Get person description by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updates existent person. User must be authenticated as this person.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Update existent person",
+                "parameters": [
+                    {
+                        "description": "Update person details",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.updatePerson"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/persons/{id}/resources": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updates resources for existent person. User must be authenticated as this person.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Set resources for person",
+                "parameters": [
+                    {
+                        "description": "Person resources in JSON",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid format (body should be formatted as JSON)",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "403": {
+                        "description": "insufficient rights",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "person not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Reports a job, a person or a chat message to admins. Comment is required for the \"other\" reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Report spam or abuse",
+                "parameters": [
+                    {
+                        "description": "Report params",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.createReportParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "user reports own entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "401": {
+                        "description": "user not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "404": {
+                        "description": "reported entity not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "409": {
+                        "description": "it is already reported",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "422": {
+                        "description": "validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackendError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "Register a new user with specified description",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Register new user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContext"
+                        }
+                    },
+                    "422": {
+                        "description": "input object is invalid",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Get stats",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stats"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/echo.HTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.acceptInvitationParams": {
+            "type": "object",
+            "required": [
+                "comment",
+                "price"
+            ],
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.answerParams"
+                    }
+                },
+                "attachment_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.adjustConnectsParams": {
+            "type": "object",
+            "required": [
+                "delta"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.adminActionParams": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.answerParams": {
+            "type": "object",
+            "required": [
+                "question_id"
+            ],
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.apiKeyParams": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "ip_allowlist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "jobs:read",
+                            "jobs:write",
+                            "applications:read",
+                            "applications:write",
+                            "contracts:read",
+                            "contracts:write",
+                            "chats:read",
+                            "chats:write"
+                        ]
+                    }
+                }
+            }
+        },
+        "controller.applicationStatusParams": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "shortlisted",
+                        "interviewing",
+                        "rejected",
+                        "hired"
+                    ]
+                }
+            }
+        },
+        "controller.blockParams": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.createApplicationParams": {
+            "type": "object",
+            "required": [
+                "comment",
+                "price"
+            ],
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.answerParams"
+                    }
+                },
+                "attachment_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.createContractParams": {
+            "type": "object",
+            "required": [
+                "application_id",
+                "description",
+                "price",
+                "title"
+            ],
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.createInvitationParams": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "person_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.createJobParams": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "attachment_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "budget": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "draft": {
+                    "type": "boolean"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.jobQuestionParams"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "private"
+                    ]
+                }
+            }
+        },
+        "controller.createReportParams": {
+            "type": "object",
+            "required": [
+                "reason",
+                "target_id",
+                "target_kind"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "fraud",
+                        "abuse",
+                        "other"
+                    ]
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_kind": {
+                    "type": "string",
+                    "enum": [
+                        "job",
+                        "person",
+                        "message"
+                    ]
+                }
+            }
+        },
+        "controller.dismissReportParams": {
+            "type": "object",
+            "properties": {
+                "resolution": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.emailDigestParams": {
+            "type": "object",
+            "required": [
+                "frequency"
+            ],
+            "properties": {
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "never",
+                        "hourly",
+                        "daily",
+                        "weekly"
+                    ]
+                }
+            }
+        },
+        "controller.jobQuestionParams": {
+            "type": "object",
+            "required": [
+                "kind",
+                "text"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "single_choice",
+                        "multi_choice"
+                    ]
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.loginParams": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.markReadParams": {
+            "type": "object",
+            "properties": {
+                "message_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.newMessage": {
+            "type": "object",
+            "properties": {
+                "attachment_ids": {
+                    "description": "IDs of files uploaded with POST /attachments",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.newPasswordParams": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.publishJobParams": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.reopenJobParams": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.resolveReportParams": {
+            "type": "object",
+            "required": [
+                "resolution"
+            ],
+            "properties": {
+                "block": {
+                    "type": "boolean"
+                },
+                "resolution": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.savedSearchParams": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "budget_max": {
+                    "type": "number"
+                },
+                "budget_min": {
+                    "type": "number"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.setJobQuestionsParams": {
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.jobQuestionParams"
+                    }
+                }
+            }
+        },
+        "controller.updateApplicationParams": {
+            "type": "object",
+            "required": [
+                "comment",
+                "price"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.updateJobParams": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "budget": {
+                    "type": "number"
+                },
+                "clear_expires_at": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.updatePerson": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "ethereum_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "echo.HTTPError": {
+            "type": "object",
+            "properties": {
+                "message": {}
+            }
+        },
+        "model.APIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_allowlist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "description": "Key is returned only once on creation, it is not stored",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "the beginning of the key to recognize it",
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AnswerDTO": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ApplicationDTO": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AnswerDTO"
+                    }
+                },
+                "applicant_display_name": {
+                    "type": "string"
+                },
+                "applicant_ethereum_address": {
+                    "type": "string"
+                },
+                "applicant_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "contract_id": {
+                    "type": "string"
+                },
+                "contract_status": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_budget": {
+                    "type": "number"
+                },
+                "job_description": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "job_title": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "withdrawn_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ApplicationRevisionDTO": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AttachmentDTO": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "has_thumbnail": {
+                    "description": "preview of the image is available with GET /attachments/{id}/thumbnail",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditRecordDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_display_name": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BackendError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "tech_info": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BlockedPersonDTO": {
+            "type": "object",
+            "properties": {
+                "blocked_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "ethereum_address": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Chat": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "has_older": {
+                    "description": "older messages are available with GET /chats/{chat_id}/messages",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Message"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChatDTO": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "contract_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_message_at": {
+                    "type": "string"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ParticipantDTO"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "description": {
+                "topic": {
                     "type": "string"
                 },
-                "duration": {
+                "unread_count": {
+                    "description": "messages of other participants which are not read by the user",
                     "type": "integer"
+                }
+            }
+        },
+        "model.ChatEvent": {
+            "type": "object",
+            "properties": {
+                "chat_id": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "kind": {
+                    "type": "string"
                 },
-                "title": {
+                "message": {
+                    "description": "new, edited or deleted message for message, edit and delete events",
+                    "$ref": "#/definitions/model.Message"
+                },
+                "person_id": {
+                    "description": "participant who is typing or has read the chat",
+                    "type": "string"
+                },
+                "read_at": {
+                    "description": "messages created before this moment are read for read events",
                     "type": "string"
                 }
             }
         },
-        "controller.createJobParams": {
+        "model.ChatTicketDTO": {
             "type": "object",
-            "required": [
-                "description",
-                "title"
-            ],
             "properties": {
-                "budget": {
-                    "type": "number"
+                "expires_at": {
+                    "type": "string"
+                },
+                "ticket": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConnectsDTO": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "refills_at": {
+                    "description": "when the balance is topped up to the weekly allowance next time",
+                    "type": "string"
+                },
+                "weekly_allowance": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ContractDTO": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "customer_address": {
+                    "type": "string"
+                },
+                "customer_display_name": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
                 },
                 "description": {
                     "type": "string"
@@ -2377,185 +8965,382 @@ const docTemplate = `{
                 "duration": {
                     "type": "integer"
                 },
+                "id": {
+                    "type": "string"
+                },
+                "performer_address": {
+                    "type": "string"
+                },
+                "performer_display_name": {
+                    "type": "string"
+                },
+                "performer_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "controller.loginParams": {
+        "model.EmailDigestSettingsDTO": {
             "type": "object",
             "properties": {
-                "login": {
+                "email": {
+                    "description": "digests are not sent if the email is empty",
                     "type": "string"
                 },
-                "password": {
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "never",
+                        "hourly",
+                        "daily",
+                        "weekly"
+                    ]
+                },
+                "sent_at": {
+                    "description": "when the last digest was sent",
                     "type": "string"
                 }
             }
         },
-        "controller.newMessage": {
+        "model.InvitationDTO": {
             "type": "object",
             "properties": {
-                "text": {
+                "application_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "customer_display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "job_title": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "person_display_name": {
+                    "type": "string"
+                },
+                "person_id": {
+                    "type": "string"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "controller.newPasswordParams": {
+        "model.JobCardDTO": {
             "type": "object",
             "properties": {
-                "new_password": {
+                "application_status_counts": {
+                    "description": "ApplicationStatusCounts contains counts of active applications per status. It is available for the job owner only.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "applications_count": {
+                    "type": "integer"
+                },
+                "budget": {
+                    "type": "number"
+                },
+                "closed_at": {
                     "type": "string"
                 },
-                "old_password": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "customer_display_name": {
+                    "type": "string"
+                },
+                "customer_ethereum_address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_suspended": {
+                    "type": "boolean"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.JobQuestionDTO"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visibility": {
                     "type": "string"
                 }
             }
         },
-        "controller.updateJobParams": {
+        "model.JobDTO": {
             "type": "object",
-            "required": [
-                "description",
-                "title"
-            ],
             "properties": {
+                "applications_count": {
+                    "type": "integer"
+                },
                 "budget": {
                     "type": "number"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "customer_display_name": {
+                    "type": "string"
+                },
+                "customer_ethereum_address": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
                 "duration": {
                     "type": "integer"
                 },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "controller.updatePerson": {
+        "model.JobQuestionDTO": {
             "type": "object",
             "properties": {
-                "display_name": {
+                "id": {
                     "type": "string"
                 },
-                "email": {
+                "kind": {
                     "type": "string"
                 },
-                "ethereum_address": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "echo.HTTPError": {
+        "model.JobRevisionDTO": {
             "type": "object",
             "properties": {
-                "message": {}
+                "budget": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "created_by_display_name": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
             }
         },
-        "model.ApplicationDTO": {
+        "model.Message": {
             "type": "object",
             "properties": {
-                "applicant_display_name": {
-                    "type": "string"
+                "attachments": {
+                    "description": "attachments of deleted message are not shown",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AttachmentDTO"
+                    }
                 },
-                "applicant_ethereum_address": {
+                "author_name": {
                     "type": "string"
                 },
-                "applicant_id": {
+                "chat_id": {
                     "type": "string"
                 },
-                "comment": {
+                "created_at": {
                     "type": "string"
                 },
-                "contract_id": {
+                "created_by": {
                     "type": "string"
                 },
-                "contract_status": {
+                "deleted_at": {
+                    "description": "text of deleted message is empty",
                     "type": "string"
                 },
-                "created_at": {
+                "edited_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "job_budget": {
-                    "type": "number"
-                },
-                "job_description": {
-                    "type": "string"
-                },
-                "job_id": {
-                    "type": "string"
+                "kind": {
+                    "description": "system message is generated by the platform and can not be changed",
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "system"
+                    ]
                 },
-                "job_title": {
-                    "type": "string"
+                "payload": {
+                    "description": "structured description of the event for system messages",
+                    "$ref": "#/definitions/model.SystemMessagePayload"
                 },
-                "price": {
-                    "type": "number"
-                }
-            }
-        },
-        "model.BackendError": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+                "read_by": {
+                    "description": "IDs of other participants who have read the message",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "tech_info": {
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "model.Chat": {
+        "model.MessagePageDTO": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "has_newer": {
+                    "description": "there are messages after the last one of the page",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "has_older": {
+                    "description": "there are messages before the first one of the page",
+                    "type": "boolean"
                 },
                 "messages": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/model.Message"
                     }
-                },
-                "topic": {
-                    "type": "string"
                 }
             }
         },
-        "model.ChatDTO": {
+        "model.MessageRevisionDTO": {
             "type": "object",
             "properties": {
-                "application_id": {
+                "created_at": {
+                    "description": "when the text was replaced",
                     "type": "string"
                 },
-                "contract_id": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "message_id": {
                     "type": "string"
                 },
-                "job_id": {
+                "text": {
                     "type": "string"
                 },
-                "kind": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MessageSearchHitDTO": {
+            "type": "object",
+            "properties": {
+                "job_id": {
                     "type": "string"
                 },
-                "last_message_at": {
+                "job_title": {
                     "type": "string"
                 },
-                "participants": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.ParticipantDTO"
-                    }
+                "message": {
+                    "$ref": "#/definitions/model.Message"
                 },
-                "title": {
+                "snippet": {
+                    "description": "HTML-escaped fragments of the text, matches are wrapped into \u003cmark\u003e tags",
                     "type": "string"
                 },
                 "topic": {
@@ -2563,111 +9348,90 @@ const docTemplate = `{
                 }
             }
         },
-        "model.ContractDTO": {
+        "model.ParticipantDTO": {
             "type": "object",
             "properties": {
-                "application_id": {
-                    "type": "string"
+                "blocked": {
+                    "description": "the participant is blocked by the current user",
+                    "type": "boolean"
                 },
-                "contract_address": {
+                "display_name": {
                     "type": "string"
                 },
-                "created_at": {
+                "ethereum_address": {
                     "type": "string"
                 },
-                "created_by": {
+                "id": {
                     "type": "string"
-                },
-                "customer_address": {
+                }
+            }
+        },
+        "model.Person": {
+            "type": "object",
+            "properties": {
+                "blocked_at": {
                     "type": "string"
                 },
-                "customer_display_name": {
+                "created_at": {
                     "type": "string"
                 },
-                "customer_id": {
+                "display_name": {
                     "type": "string"
                 },
-                "description": {
+                "email": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "id": {
+                "ethereum_address": {
                     "type": "string"
                 },
-                "performer_address": {
+                "id": {
                     "type": "string"
                 },
-                "performer_display_name": {
-                    "type": "string"
+                "is_admin": {
+                    "type": "boolean"
                 },
-                "performer_id": {
+                "login": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "status": {
+                "password": {
                     "type": "string"
                 },
-                "title": {
+                "realm": {
                     "type": "string"
                 },
-                "updated_at": {
+                "resources": {
                     "type": "string"
                 }
             }
         },
-        "model.JobCardDTO": {
+        "model.PersonNotification": {
             "type": "object",
             "properties": {
-                "applications_count": {
-                    "type": "integer"
-                },
-                "budget": {
-                    "type": "number"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "string"
-                },
-                "customer_display_name": {
-                    "type": "string"
-                },
-                "customer_ethereum_address": {
+                "id": {
                     "type": "string"
                 },
-                "description": {
+                "kind": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "id": {
+                "link": {
                     "type": "string"
                 },
-                "is_suspended": {
-                    "type": "boolean"
-                },
-                "title": {
+                "read_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "model.JobDTO": {
+        "model.ReportDTO": {
             "type": "object",
             "properties": {
-                "applications_count": {
-                    "type": "integer"
-                },
-                "budget": {
-                    "type": "number"
+                "comment": {
+                    "type": "string"
                 },
                 "created_at": {
                     "type": "string"
@@ -2675,97 +9439,84 @@ const docTemplate = `{
                 "created_by": {
                     "type": "string"
                 },
-                "customer_display_name": {
+                "id": {
                     "type": "string"
                 },
-                "customer_ethereum_address": {
+                "reason": {
                     "type": "string"
                 },
-                "description": {
+                "reporter_display_name": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
+                "resolution": {
+                    "type": "string"
                 },
-                "id": {
+                "resolved_at": {
                     "type": "string"
                 },
-                "title": {
+                "resolved_by": {
                     "type": "string"
                 },
-                "updated_at": {
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_kind": {
                     "type": "string"
                 }
             }
         },
-        "model.Message": {
+        "model.SavedSearchDTO": {
             "type": "object",
             "properties": {
-                "author_name": {
-                    "type": "string"
+                "budget_max": {
+                    "type": "number"
                 },
-                "chat_id": {
-                    "type": "string"
+                "budget_min": {
+                    "type": "number"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "text": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.ParticipantDTO": {
-            "type": "object",
-            "properties": {
-                "display_name": {
+                "keyword": {
                     "type": "string"
                 },
-                "ethereum_address": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.Person": {
+        "model.SessionDTO": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "display_name": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
+                "current": {
+                    "description": "the request is made within this session",
+                    "type": "boolean"
                 },
-                "ethereum_address": {
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_admin": {
-                    "type": "boolean"
-                },
-                "login": {
-                    "type": "string"
-                },
-                "password": {
+                "ip": {
                     "type": "string"
                 },
-                "realm": {
+                "last_used_at": {
                     "type": "string"
                 },
-                "resources": {
+                "user_agent": {
                     "type": "string"
                 }
             }
@@ -2793,24 +9544,98 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SystemMessagePayload": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "changed terms of the job or the application",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "budget",
+                            "description",
+                            "price",
+                            "comment"
+                        ]
+                    }
+                },
+                "contract_id": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "enum": [
+                        "contract_status_changed",
+                        "participant_joined",
+                        "job_changed",
+                        "application_changed",
+                        "application_withdrawn"
+                    ]
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "new_status": {
+                    "type": "string"
+                },
+                "old_status": {
+                    "description": "absent for the created contract and for messages posted before payloads",
+                    "type": "string"
+                },
+                "person_id": {
+                    "description": "the joined participant",
+                    "type": "string"
+                }
+            }
+        },
         "model.UserContext": {
             "type": "object",
             "properties": {
+                "api_key_id": {
+                    "description": "APIKeyID is ID of the API key of the request, only routes of the Scopes are accessible with the key",
+                    "type": "string"
+                },
                 "authenticated": {
                     "type": "boolean"
                 },
+                "connects": {
+                    "description": "Connects is a balance of connects of the subject, it is absent if connects are not limited",
+                    "$ref": "#/definitions/model.ConnectsDTO"
+                },
+                "impersonated_by": {
+                    "description": "ImpersonatedBy is ID of the admin who acts as the subject for support",
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "session_id": {
+                    "description": "SessionID is ID of the session of the token, it is absent for impersonation",
+                    "type": "string"
+                },
                 "subject": {
                     "$ref": "#/definitions/model.Person"
                 },
                 "token": {
                     "type": "string"
+                },
+                "unread_messages": {
+                    "description": "UnreadMessages is a total count of unread messages in all chats of the subject",
+                    "type": "integer"
                 }
             }
         }
     },
     "securityDefinitions": {
         "BearerToken": {
-            "description": "Bearer token in Authorization header",
+            "description": "Bearer token in Authorization header: the token of the session or the personal API key",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
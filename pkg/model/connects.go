@@ -0,0 +1,48 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// ConnectsPolicy defines how many connects persons get weekly and how many connects applications cost
+	ConnectsPolicy struct {
+		WeeklyAllowance int32 // connects are not limited, if zero
+		Tiers           []ConnectsTier
+	}
+
+	// ConnectsTier is a cost of an application for jobs with the budget starting from BudgetFrom
+	ConnectsTier struct {
+		BudgetFrom decimal.Decimal
+		Cost       int32
+	}
+)
+
+// Enabled returns true if applications cost connects
+func (p *ConnectsPolicy) Enabled() bool {
+	return p != nil && p.WeeklyAllowance > 0
+}
+
+// Cost returns how many connects the application for the job with the budget costs
+// The job without budget costs as the lowest tier. The cost is 1, if there are no tiers.
+func (p *ConnectsPolicy) Cost(budget decimal.Decimal) int32 {
+	tiers := make([]ConnectsTier, len(p.Tiers))
+	copy(tiers, p.Tiers)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].BudgetFrom.LessThan(tiers[j].BudgetFrom) })
+
+	if len(tiers) == 0 {
+		return 1
+	}
+
+	cost := tiers[0].Cost
+	for _, t := range tiers {
+		if budget.LessThan(t.BudgetFrom) {
+			break
+		}
+		cost = t.Cost
+	}
+
+	return cost
+}
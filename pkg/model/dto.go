@@ -1,6 +1,7 @@
 package model
 
 import (
+	"io"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -13,6 +14,14 @@ type (
 		Description string `validate:"required"`
 		Budget      decimal.Decimal
 		Duration    int32
+		Visibility  string
+		ExpiresAt   *time.Time
+
+		AttachmentIDs []string
+		Questions     []*JobQuestionParamsDTO
+
+		Draft     bool       // job is saved as a draft and is not published
+		PublishAt *time.Time // draft is published automatically at this moment
 	}
 
 	// JobDTO is a representation of the job
@@ -28,13 +37,50 @@ type (
 		ApplicationsCount       uint            `json:"applications_count"`
 		CustomerDisplayName     string          `json:"customer_display_name"`
 		CustomerEthereumAddress string          `json:"customer_ethereum_address"`
+		ExpiresAt               *time.Time      `json:"expires_at,omitempty"`
+	}
+
+	// JobFilterDTO is a filter of the job list
+	JobFilterDTO struct {
+		Keyword   string          // text which the job title or description has to contain
+		BudgetMin decimal.Decimal // not limited if zero
+		BudgetMax decimal.Decimal // not limited if zero
+		Limit     int             // max number of jobs, not limited if zero
 	}
 
 	// JobCardDTO is a representation of the job with extended attributes
 	JobCardDTO struct {
 		JobDTO
 
-		IsSuspended bool `json:"is_suspended"`
+		IsSuspended bool       `json:"is_suspended"`
+		Visibility  string     `json:"visibility"`
+		Status      string     `json:"status"`
+		ClosedAt    *time.Time `json:"closed_at,omitempty"`
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+		PublishAt   *time.Time `json:"publish_at,omitempty"`
+
+		// ApplicationStatusCounts contains counts of active applications per status. It is available for the job owner only.
+		ApplicationStatusCounts map[string]int64 `json:"application_status_counts,omitempty"`
+
+		Questions []*JobQuestionDTO `json:"questions,omitempty"`
+	}
+
+	// JobQuestionParamsDTO is a screening question representation on creation process
+	JobQuestionParamsDTO struct {
+		Kind     string   `validate:"required"`
+		Text     string   `validate:"required"`
+		Options  []string // for single_choice and multi_choice questions only
+		Required bool
+	}
+
+	// JobQuestionDTO is a screening question which applicants answer when they apply for the job
+	JobQuestionDTO struct {
+		ID       string   `json:"id"`
+		Position int32    `json:"position"`
+		Kind     string   `json:"kind"`
+		Text     string   `json:"text"`
+		Options  []string `json:"options,omitempty"`
+		Required bool     `json:"required"`
 	}
 
 	// UpdateJobDTO is a job representation on updation process
@@ -43,6 +89,77 @@ type (
 		Description string `validate:"required"`
 		Budget      decimal.Decimal
 		Duration    int32
+		ExpiresAt   *time.Time // the current expiration is kept if nil
+
+		// ClearExpiresAt removes the expiration, so the job does not expire
+		ClearExpiresAt bool
+	}
+
+	// ReopenJobDTO is a job representation on reopening process
+	ReopenJobDTO struct {
+		ExpiresAt *time.Time
+	}
+
+	// PublishJobDTO is a job representation on publication process
+	PublishJobDTO struct {
+		PublishAt *time.Time // the draft is published immediately if it is not specified
+	}
+
+	// JobRevisionDTO is a snapshot of the job terms after an edit
+	JobRevisionDTO struct {
+		ID                   string          `json:"id"`
+		JobID                string          `json:"job_id"`
+		Version              int32           `json:"version"`
+		Title                string          `json:"title"`
+		Description          string          `json:"description"`
+		Budget               decimal.Decimal `json:"budget"`
+		Duration             int32           `json:"duration,omitempty"`
+		ExpiresAt            *time.Time      `json:"expires_at,omitempty"`
+		CreatedBy            string          `json:"created_by"`
+		CreatedByDisplayName string          `json:"created_by_display_name"`
+		CreatedAt            time.Time       `json:"created_at"`
+	}
+
+	// SavedSearchParamsDTO is a saved search representation on creation and updating processes
+	SavedSearchParamsDTO struct {
+		Title     string `validate:"required"`
+		Keyword   string
+		BudgetMin decimal.Decimal // not limited if zero
+		BudgetMax decimal.Decimal // not limited if zero
+	}
+
+	// SavedSearchDTO is a search criteria saved by a person to get alerts about new jobs
+	SavedSearchDTO struct {
+		ID        string          `json:"id"`
+		Title     string          `json:"title"`
+		Keyword   string          `json:"keyword"`
+		BudgetMin decimal.Decimal `json:"budget_min"`
+		BudgetMax decimal.Decimal `json:"budget_max"`
+		CreatedAt time.Time       `json:"created_at"`
+		UpdatedAt time.Time       `json:"updated_at"`
+	}
+
+	// APIKeyParamsDTO is an API key representation on creation process
+	APIKeyParamsDTO struct {
+		Name        string `validate:"required"`
+		Scopes      []string
+		IPAllowlist []string   // IP addresses and CIDR ranges, empty means any address
+		ExpiresAt   *time.Time // the key does not expire if nil
+	}
+
+	// APIKeyDTO is a personal API key of the current user
+	APIKeyDTO struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Prefix      string     `json:"prefix"` // the beginning of the key to recognize it
+		Scopes      []string   `json:"scopes"`
+		IPAllowlist []string   `json:"ip_allowlist"`
+		CreatedAt   time.Time  `json:"created_at"`
+		LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+		ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+
+		// Key is returned only once on creation, it is not stored
+		Key string `json:"key,omitempty"`
 	}
 
 	// CreateContractDTO is a contract representation on creation process
@@ -90,6 +207,13 @@ type (
 		Resources       string `json:"resources"`
 	}
 
+	// EmailDigestSettingsDTO is a preference of the person about email digests of unread messages
+	EmailDigestSettingsDTO struct {
+		Frequency string     `json:"frequency" enums:"never,hourly,daily,weekly"`
+		Email     string     `json:"email"`             // digests are not sent if the email is empty
+		SentAt    *time.Time `json:"sent_at,omitempty"` // when the last digest was sent
+	}
+
 	// ChatDTO represents basic information about chat
 	ChatDTO struct {
 		ID            string            `json:"id"`
@@ -99,8 +223,54 @@ type (
 		JobID         string            `json:"job_id,omitempty"`
 		ApplicationID string            `json:"application_id,omitempty"`
 		ContractID    string            `json:"contract_id,omitempty"`
+		InvitationID  string            `json:"invitation_id,omitempty"`
 		Participants  []*ParticipantDTO `json:"participants,omitempty"`
 		LastMessageAt time.Time         `json:"last_message_at"`
+		UnreadCount   int64             `json:"unread_count"` // messages of other participants which are not read by the user
+	}
+
+	// CreateMessageDTO is a new chat message
+	CreateMessageDTO struct {
+		Text          string
+		AttachmentIDs []string // previously uploaded files of the author
+	}
+
+	// MessagePageParamsDTO is a cursor of the page of chat messages
+	MessagePageParamsDTO struct {
+		Before string // ID of the message, only older messages are returned
+		After  string // ID of the message, only newer messages are returned
+		Limit  int    // max number of messages
+	}
+
+	// MessagePageDTO is a page of chat messages in order of creation
+	MessagePageDTO struct {
+		Messages []Message `json:"messages"`
+		HasOlder bool      `json:"has_older"` // there are messages before the first one of the page
+		HasNewer bool      `json:"has_newer"` // there are messages after the last one of the page
+	}
+
+	// MessageSearchParamsDTO is a full-text query over messages of the chats of the person
+	MessageSearchParamsDTO struct {
+		Query string // web search syntax: quoted phrases, OR and - are supported
+		Limit int    // max number of found messages
+	}
+
+	// MessageSearchHitDTO is a found message with the context of the chat
+	MessageSearchHitDTO struct {
+		Message  Message `json:"message"`
+		Topic    string  `json:"topic"`
+		JobID    string  `json:"job_id,omitempty"`
+		JobTitle string  `json:"job_title,omitempty"`
+		Snippet  string  `json:"snippet"` // HTML-escaped fragments of the text, matches are wrapped into <mark> tags
+	}
+
+	// MessageRevisionDTO is a previous version of the edited or deleted message
+	MessageRevisionDTO struct {
+		ID        string    `json:"id"`
+		MessageID string    `json:"message_id"`
+		Version   int32     `json:"version"`
+		Text      string    `json:"text"`
+		CreatedAt time.Time `json:"created_at"` // when the text was replaced
 	}
 
 	// ParticipantDTO represents is a chat participant
@@ -108,6 +278,32 @@ type (
 		ID              string `json:"id"`
 		DisplayName     string `json:"display_name"`
 		EthereumAddress string `json:"ethereum_address"`
+		Blocked         bool   `json:"blocked,omitempty"` // the participant is blocked by the current user
+	}
+
+	// BlockedPersonDTO is a person blocked by the current user
+	BlockedPersonDTO struct {
+		ID              string    `json:"id"`
+		DisplayName     string    `json:"display_name"`
+		EthereumAddress string    `json:"ethereum_address"`
+		BlockedAt       time.Time `json:"blocked_at"`
+	}
+
+	// SessionDTO is a logged in session of the current user
+	SessionDTO struct {
+		ID         string    `json:"id"`
+		CreatedAt  time.Time `json:"created_at"`
+		LastUsedAt time.Time `json:"last_used_at"`
+		ExpiresAt  time.Time `json:"expires_at"`
+		UserAgent  string    `json:"user_agent"`
+		IP         string    `json:"ip"`
+		Current    bool      `json:"current,omitempty"` // the request is made within this session
+	}
+
+	// ChatTicketDTO is a short-lived single-use ticket for the WebSocket handshake of chat events
+	ChatTicketDTO struct {
+		Ticket    string    `json:"ticket"`
+		ExpiresAt time.Time `json:"expires_at"`
 	}
 
 	// CreateApplicationDTO is an application representation on applyment process
@@ -115,6 +311,25 @@ type (
 		JobID   string `validate:"required"`
 		Comment string `validate:"required"`
 		Price   decimal.Decimal
+
+		AttachmentIDs []string
+		Answers       []*AnswerParamsDTO
+	}
+
+	// AnswerParamsDTO is an answer to a screening question on applyment process
+	AnswerParamsDTO struct {
+		QuestionID string   `validate:"required"`
+		Text       string   // for text questions
+		Options    []string // for single_choice and multi_choice questions
+	}
+
+	// AnswerDTO is an answer of the applicant to a screening question of the job
+	AnswerDTO struct {
+		QuestionID   string   `json:"question_id"`
+		QuestionText string   `json:"question_text"`
+		Kind         string   `json:"kind"`
+		Text         string   `json:"text,omitempty"`
+		Options      []string `json:"options,omitempty"`
 	}
 
 	// ApplicationDTO is an application for a job
@@ -132,5 +347,142 @@ type (
 		ApplicantEthereumAddress string          `json:"applicant_ethereum_address"`
 		ApplicantDisplayName     string          `json:"applicant_display_name"`
 		CreatedAt                time.Time       `json:"created_at"`
+		UpdatedAt                time.Time       `json:"updated_at"`
+		WithdrawnAt              *time.Time      `json:"withdrawn_at,omitempty"`
+		Status                   string          `json:"status"`
+		RejectionReason          string          `json:"rejection_reason,omitempty"`
+		Answers                  []*AnswerDTO    `json:"answers,omitempty"`
+	}
+
+	// ApplicationStatusDTO is a change of the application status by the customer
+	ApplicationStatusDTO struct {
+		Status          string `validate:"required"`
+		RejectionReason string // it is allowed for rejected status only
+	}
+
+	// UpdateApplicationDTO is an application representation on updating process
+	UpdateApplicationDTO struct {
+		Comment string `validate:"required"`
+		Price   decimal.Decimal
+	}
+
+	// ApplicationRevisionDTO is a snapshot of the application terms after an edit
+	ApplicationRevisionDTO struct {
+		ID            string          `json:"id"`
+		ApplicationID string          `json:"application_id"`
+		Version       int32           `json:"version"`
+		Comment       string          `json:"comment"`
+		Price         decimal.Decimal `json:"price"`
+		CreatedAt     time.Time       `json:"created_at"`
+	}
+
+	// ConnectsDTO is a balance of connects of the person
+	ConnectsDTO struct {
+		Balance         int32      `json:"balance"`
+		WeeklyAllowance int32      `json:"weekly_allowance"`
+		RefillsAt       *time.Time `json:"refills_at,omitempty"` // when the balance is topped up to the weekly allowance next time
+	}
+
+	// AdjustConnectsDTO is a change of the connects balance by an admin
+	AdjustConnectsDTO struct {
+		Delta  int32 // positive value adds connects, negative one takes them away
+		Reason string
+	}
+
+	// CreateInvitationDTO is an invitation representation on creation process
+	// Either PersonID or Login must be specified
+	CreateInvitationDTO struct {
+		JobID    string `validate:"required"`
+		PersonID string
+		Login    string
+		Message  string
+	}
+
+	// AcceptInvitationDTO holds application details supplied by the invited person
+	AcceptInvitationDTO struct {
+		Comment string `validate:"required"`
+		Price   decimal.Decimal
+
+		AttachmentIDs []string
+		Answers       []*AnswerParamsDTO
+	}
+
+	// InvitationDTO is an invitation of a person to apply for a job
+	InvitationDTO struct {
+		ID                  string     `json:"id"`
+		JobID               string     `json:"job_id"`
+		JobTitle            string     `json:"job_title"`
+		PersonID            string     `json:"person_id"`
+		PersonDisplayName   string     `json:"person_display_name"`
+		CreatedBy           string     `json:"created_by"`
+		CustomerDisplayName string     `json:"customer_display_name"`
+		Message             string     `json:"message"`
+		Status              string     `json:"status"`
+		ApplicationID       string     `json:"application_id,omitempty"`
+		CreatedAt           time.Time  `json:"created_at"`
+		RespondedAt         *time.Time `json:"responded_at,omitempty"`
+	}
+
+	// CreateAttachmentDTO is an uploaded file
+	CreateAttachmentDTO struct {
+		Name    string `validate:"required"`
+		Size    int64
+		Content io.Reader
+	}
+
+	// AttachmentDTO is a file attached to a job, an application or a chat message
+	AttachmentDTO struct {
+		ID            string    `json:"id"`
+		Name          string    `json:"name"`
+		ContentType   string    `json:"content_type"`
+		Size          int64     `json:"size"`
+		CreatedBy     string    `json:"created_by"`
+		CreatedAt     time.Time `json:"created_at"`
+		JobID         string    `json:"job_id,omitempty"`
+		ApplicationID string    `json:"application_id,omitempty"`
+		MessageID     string    `json:"message_id,omitempty"`
+		HasThumbnail  bool      `json:"has_thumbnail"` // preview of the image is available with GET /attachments/{id}/thumbnail
+	}
+
+	// CreateReportDTO is a report representation on creation process
+	CreateReportDTO struct {
+		TargetKind string `validate:"required"`
+		TargetID   string `validate:"required"`
+		Reason     string `validate:"required"`
+		Comment    string
+	}
+
+	// ReportDTO is a report about spam or abuse
+	ReportDTO struct {
+		ID                  string     `json:"id"`
+		TargetKind          string     `json:"target_kind"`
+		TargetID            string     `json:"target_id"`
+		Reason              string     `json:"reason"`
+		Comment             string     `json:"comment,omitempty"`
+		CreatedBy           string     `json:"created_by"`
+		ReporterDisplayName string     `json:"reporter_display_name"`
+		CreatedAt           time.Time  `json:"created_at"`
+		Status              string     `json:"status"`
+		ResolvedBy          string     `json:"resolved_by,omitempty"`
+		ResolvedAt          *time.Time `json:"resolved_at,omitempty"`
+		Resolution          string     `json:"resolution,omitempty"`
+	}
+
+	// ResolveReportDTO is an admin decision on the report
+	ResolveReportDTO struct {
+		Resolution string `validate:"required"`
+		Block      bool   // the reported entity is blocked, message author is blocked for the reported message
+	}
+
+	// AuditRecordDTO is a record of a privileged action
+	AuditRecordDTO struct {
+		ID               string    `json:"id"`
+		ActorID          string    `json:"actor_id"`
+		ActorDisplayName string    `json:"actor_display_name"`
+		Action           string    `json:"action"`
+		TargetKind       string    `json:"target_kind"`
+		TargetID         string    `json:"target_id"`
+		Reason           string    `json:"reason,omitempty"`
+		CreatedAt        time.Time `json:"created_at"`
 	}
 )
@@ -39,6 +39,7 @@ var (
 	ErrInvalidFormat            = errors.New("invalid format")     // entire request body has invalid format
 	ErrInsufficientFunds        = errors.New("insufficient funds") // insufficient funds on some address in a blockchain network
 	ErrInvalidJSON              = errors.New("invalid JSON")
+	ErrNotEnoughConnects        = errors.New("not enough connects") // connects balance does not allow to apply for the job
 )
 
 // Validation errors
@@ -48,4 +49,5 @@ var (
 	ValidationErrorMustNotBeNegative = func(field string) string { return fmt.Sprintf("%s must not be negative", field) }
 	ValidationErrorInvalidFormat     = func(field string) string { return fmt.Sprintf("%s has an invalid format", field) } // only ONE field has invalid format, not entire request body!
 	ValidationErrorTooLong           = func(field string) string { return fmt.Sprintf("%s is too long", field) }
+	ValidationErrorMustBeInFuture    = func(field string) string { return fmt.Sprintf("%s must be in the future", field) }
 )
@@ -26,17 +26,18 @@ type (
 
 	// Person — customer, executor, seller, buyer etc.
 	Person struct {
-		ID              string    `json:"id"`
-		Realm           string    `json:"realm"`
-		Login           string    `json:"login"`
-		Password        string    `json:"password,omitempty"`
-		DisplayName     string    `json:"display_name"`
-		CreatedAt       time.Time `json:"created_at"`
-		Email           string    `json:"email"`
-		EthereumAddress string    `json:"ethereum_address"`
-		Resources       string    `json:"resources"`
-		AccessToken     string    `json:"-"`
-		IsAdmin         bool      `json:"is_admin"`
+		ID              string     `json:"id"`
+		Realm           string     `json:"realm"`
+		Login           string     `json:"login"`
+		Password        string     `json:"password,omitempty"`
+		DisplayName     string     `json:"display_name"`
+		CreatedAt       time.Time  `json:"created_at"`
+		Email           string     `json:"email"`
+		EthereumAddress string     `json:"ethereum_address"`
+		Resources       string     `json:"resources"`
+		AccessToken     string     `json:"-"` // token of the session started on sign up, only the hash is stored
+		IsAdmin         bool       `json:"is_admin"`
+		BlockedAt       *time.Time `json:"blocked_at,omitempty"`
 	}
 
 	// Contract is a contract for execution some a task and
@@ -89,16 +90,45 @@ type (
 		CreatedAt time.Time `json:"created_at"`
 		Topic     string    `json:"topic"`
 		Messages  []Message `json:"messages,omitempty"`
+		HasOlder  bool      `json:"has_older,omitempty"` // older messages are available with GET /chats/{chat_id}/messages
 	}
 
 	// Message is a message instance
 	Message struct {
-		ID         string    `json:"id"`
-		ChatID     string    `json:"chat_id"`
-		CreatedAt  time.Time `json:"created_at"`
-		CreatedBy  string    `json:"created_by"`
-		Text       string    `json:"text"`
-		AuthorName string    `json:"author_name"`
+		ID         string                `json:"id"`
+		ChatID     string                `json:"chat_id"`
+		CreatedAt  time.Time             `json:"created_at"`
+		CreatedBy  string                `json:"created_by"`
+		Text       string                `json:"text"`
+		AuthorName string                `json:"author_name"`
+		ReadBy     []string              `json:"read_by,omitempty"`        // IDs of other participants who have read the message
+		Kind       string                `json:"kind" enums:"user,system"` // system message is generated by the platform and can not be changed
+		Payload    *SystemMessagePayload `json:"payload,omitempty"`        // structured description of the event for system messages
+		EditedAt   *time.Time            `json:"edited_at,omitempty"`
+		DeletedAt  *time.Time            `json:"deleted_at,omitempty"` // text of deleted message is empty
+
+		Attachments []*AttachmentDTO `json:"attachments,omitempty"` // attachments of deleted message are not shown
+	}
+
+	// SystemMessagePayload describes the event of the system message, so clients are able to render and localize it
+	SystemMessagePayload struct {
+		Event         string   `json:"event" enums:"contract_status_changed,participant_joined,job_changed,application_changed,application_withdrawn"`
+		ContractID    string   `json:"contract_id,omitempty"`
+		OldStatus     string   `json:"old_status,omitempty"` // absent for the created contract and for messages posted before payloads
+		NewStatus     string   `json:"new_status,omitempty"`
+		PersonID      string   `json:"person_id,omitempty"` // the joined participant
+		JobID         string   `json:"job_id,omitempty"`
+		ApplicationID string   `json:"application_id,omitempty"`
+		Changes       []string `json:"changes,omitempty" enums:"budget,description,price,comment"` // changed terms of the job or the application
+	}
+
+	// ChatEvent is a real-time event in a chat of the person
+	ChatEvent struct {
+		Kind     string     `json:"kind"`
+		ChatID   string     `json:"chat_id"`
+		Message  *Message   `json:"message,omitempty"`   // new, edited or deleted message for message, edit and delete events
+		PersonID string     `json:"person_id,omitempty"` // participant who is typing or has read the chat
+		ReadAt   *time.Time `json:"read_at,omitempty"`   // messages created before this moment are read for read events
 	}
 
 	// JobApplicant represents a person who applied for specific job
@@ -108,6 +138,16 @@ type (
 		EthereumAddress string `json:"ethereum_address"`
 		Resources       string `json:"resources"`
 	}
+
+	// PersonNotification is an in-app notification addressed to a person
+	PersonNotification struct {
+		ID        string     `json:"id"`
+		Kind      string     `json:"kind"`
+		Text      string     `json:"text"`
+		Link      string     `json:"link,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+		ReadAt    *time.Time `json:"read_at,omitempty"`
+	}
 )
 
 // Contract statuses
@@ -120,3 +160,141 @@ const (
 	ContractApproved  = "approved"
 	ContractCompleted = "completed"
 )
+
+// Job visibilities
+const (
+	JobVisibilityPublic  = "public"
+	JobVisibilityHidden  = "hidden"
+	JobVisibilityPrivate = "private" // only the owner and invited persons can see and apply
+)
+
+// Job statuses
+const (
+	JobStatusDraft     = "draft" // not published yet, available only for the owner
+	JobStatusOpen      = "open"
+	JobStatusSuspended = "suspended"
+	JobStatusExpired   = "expired"
+	JobStatusClosed    = "closed" // a contract for the job has been funded
+	JobStatusBlocked   = "blocked"
+)
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+// Application statuses in the pipeline of the customer
+const (
+	ApplicationNew          = "new"
+	ApplicationShortlisted  = "shortlisted"
+	ApplicationInterviewing = "interviewing"
+	ApplicationRejected     = "rejected"
+	ApplicationHired        = "hired"
+)
+
+// Kinds of chat messages
+const (
+	MessageUser   = "user"
+	MessageSystem = "system"
+)
+
+// Events of system messages
+const (
+	SystemEventContractStatusChanged = "contract_status_changed"
+	SystemEventParticipantJoined     = "participant_joined"    // an admin has joined the contract chat
+	SystemEventJobChanged            = "job_changed"           // the customer has changed terms of the job the applicant applied for
+	SystemEventApplicationChanged    = "application_changed"   // the applicant has changed terms of the application
+	SystemEventApplicationWithdrawn  = "application_withdrawn" // the applicant has withdrawn the application
+)
+
+// Kinds of real-time chat events
+const (
+	ChatEventMessage = "message"
+	ChatEventTyping  = "typing"
+	ChatEventRead    = "read"
+	ChatEventEdit    = "edit"   // the message has been edited
+	ChatEventDelete  = "delete" // the message has been deleted
+)
+
+// Kinds of screening questions of jobs
+const (
+	QuestionText         = "text"
+	QuestionSingleChoice = "single_choice"
+	QuestionMultiChoice  = "multi_choice"
+)
+
+// Person notification kinds
+const (
+	NotificationJobInvitation         = "job_invitation"
+	NotificationJobInvitationAccepted = "job_invitation_accepted"
+	NotificationJobInvitationDeclined = "job_invitation_declined"
+	NotificationJobExpired            = "job_expired"
+	NotificationJobPublished          = "job_published"
+	NotificationSavedSearchMatch      = "saved_search_match"
+	NotificationApplicationStatus     = "application_status"
+)
+
+// Frequencies of email digests of unread messages
+const (
+	EmailDigestNever  = "never"
+	EmailDigestHourly = "hourly"
+	EmailDigestDaily  = "daily"
+	EmailDigestWeekly = "weekly"
+)
+
+// Scopes of API keys, every scope gives access to a group of routes
+const (
+	ScopeJobsRead          = "jobs:read"
+	ScopeJobsWrite         = "jobs:write"
+	ScopeApplicationsRead  = "applications:read"
+	ScopeApplicationsWrite = "applications:write"
+	ScopeContractsRead     = "contracts:read"
+	ScopeContractsWrite    = "contracts:write"
+	ScopeChatsRead         = "chats:read"
+	ScopeChatsWrite        = "chats:write"
+)
+
+// APIKeyPrefix starts every API key, so keys are distinguished from session tokens
+const APIKeyPrefix = "osk_"
+
+// Kinds of entities which can be reported and moderated
+const (
+	TargetJob      = "job"
+	TargetPerson   = "person"
+	TargetMessage  = "message"
+	TargetContract = "contract"
+)
+
+// Report reasons
+const (
+	ReportReasonSpam  = "spam"
+	ReportReasonFraud = "fraud"
+	ReportReasonAbuse = "abuse"
+	ReportReasonOther = "other"
+)
+
+// Report statuses
+const (
+	ReportOpen      = "open"
+	ReportResolved  = "resolved"
+	ReportDismissed = "dismissed"
+)
+
+// Audited actions
+const (
+	AuditBlock               = "block"
+	AuditUnblock             = "unblock"
+	AuditGrantAdmin          = "grant_admin"
+	AuditRevokeAdmin         = "revoke_admin"
+	AuditResetToken          = "reset_token"
+	AuditImpersonate         = "impersonate"
+	AuditImpersonatedRequest = "impersonated_request" // request made by an admin on behalf of the person
+	AuditViewJobs            = "view_jobs"
+	AuditViewApplications    = "view_applications"
+	AuditViewContracts       = "view_contracts"
+	AuditAdjustConnects      = "adjust_connects"
+	AuditViewMessageHistory  = "view_message_history"
+	AuditJoinChat            = "join_chat"
+)
@@ -6,6 +6,22 @@ type (
 		Authenticated bool    `json:"authenticated"`
 		Token         string  `json:"token,omitempty"`
 		Subject       *Person `json:"subject,omitempty"`
+
+		// SessionID is ID of the session of the token, it is absent for impersonation
+		SessionID string `json:"session_id,omitempty"`
+
+		// APIKeyID is ID of the API key of the request, only routes of the Scopes are accessible with the key
+		APIKeyID string   `json:"api_key_id,omitempty"`
+		Scopes   []string `json:"scopes,omitempty"`
+
+		// ImpersonatedBy is ID of the admin who acts as the subject for support
+		ImpersonatedBy string `json:"impersonated_by,omitempty"`
+
+		// Connects is a balance of connects of the subject, it is absent if connects are not limited
+		Connects *ConnectsDTO `json:"connects,omitempty"`
+
+		// UnreadMessages is a total count of unread messages in all chats of the subject
+		UnreadMessages int64 `json:"unread_messages,omitempty"`
 	}
 )
 
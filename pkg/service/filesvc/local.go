@@ -0,0 +1,90 @@
+package filesvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// localStorage keeps files in a directory of the local file system
+	localStorage struct {
+		dir string
+	}
+)
+
+// NewLocal creates a storage in the specified directory. The directory is created if needed
+func NewLocal(dir string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create storage directory %s: %w", dir, err)
+	}
+
+	return &localStorage{dir: dir}, nil
+}
+
+// Put implements Storage interface
+func (s *localStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	// write to a temporary file first to avoid partially written content under the key
+	f, err := os.CreateTemp(s.dir, "."+key+"-*")
+	if err != nil {
+		return fmt.Errorf("unable to create file for %s: %w", key, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write file for %s: %w", key, err)
+	}
+
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("unable to close file for %s: %w", key, err)
+	}
+
+	if err = os.Rename(f.Name(), filepath.Join(s.dir, key)); err != nil {
+		return fmt.Errorf("unable to save file for %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get implements Storage interface
+func (s *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file for %s: %w", key, err)
+	}
+
+	return f, nil
+}
+
+// Delete implements Storage interface
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(s.dir, key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to delete file for %s: %w", key, err)
+	}
+
+	return nil
+}
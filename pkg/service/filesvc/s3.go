@@ -0,0 +1,191 @@
+package filesvc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"optrispace.com/work/pkg/model"
+)
+
+// unsignedPayload allows to avoid reading the whole content to calculate signature
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+type (
+	// s3Storage keeps files in a bucket of S3-compatible storage (AWS S3, MinIO etc)
+	// Path-style addressing is used, so the bucket name is a part of the path
+	s3Storage struct {
+		endpoint  *url.URL
+		region    string
+		bucket    string
+		accessKey string
+		secretKey string
+		client    *http.Client
+	}
+)
+
+// NewS3 creates a storage in the bucket of S3-compatible service
+// The endpoint is a base URL of the service, like http://localhost:9000 for local MinIO
+func NewS3(endpoint, region, bucket, accessKey, secretKey string) (Storage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse S3 endpoint %s: %w", endpoint, err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w: S3 endpoint must be an absolute URL: %s", errInvalidSettings, endpoint)
+	}
+
+	if bucket == "" {
+		return nil, fmt.Errorf("%w: S3 bucket must be specified", errInvalidSettings)
+	}
+
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &s3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Put implements Storage interface
+func (s *s3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	res, err := s.do(ctx, http.MethodPut, key, r, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return s.unexpected(http.MethodPut, key, res)
+	}
+
+	return nil
+}
+
+// Get implements Storage interface
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, model.ErrEntityNotFound
+	default:
+		defer res.Body.Close()
+		return nil, s.unexpected(http.MethodGet, key, res)
+	}
+}
+
+// Delete implements Storage interface
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return s.unexpected(http.MethodDelete, key, res)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) do(ctx context.Context, method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	u := *s.endpoint
+	u.Path = path.Join("/", s.endpoint.Path, s.bucket, key)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create S3 request: %w", err)
+	}
+
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(req, time.Now().UTC())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to %s S3 object %s: %w", method, key, err)
+	}
+
+	return res, nil
+}
+
+// sign adds AWS Signature Version 4 to the request
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *s3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + unsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+hex.EncodeToString(hmacSHA256(key, stringToSign)))
+}
+
+func (s *s3Storage) unexpected(method, key string, res *http.Response) error {
+	bb, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected response on %s S3 object %s: %s: %s", method, key, res.Status, string(bb))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,71 @@
+package filesvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// ErrInfected is returned by Scanner when the content is considered as malicious
+var ErrInfected = errors.New("file is infected")
+
+type (
+	// Scanner checks files for viruses before they are saved
+	Scanner interface {
+		// Scan returns ErrInfected if the content must not be saved
+		Scan(ctx context.Context, name string, r io.Reader) error
+	}
+
+	noopScanner struct{}
+
+	// commandScanner passes the content to stdin of an external command, like `clamdscan -`
+	// Exit code 1 means the content is infected, as for ClamAV tools
+	commandScanner struct {
+		command string
+		args    []string
+	}
+)
+
+// NewNoopScanner creates a scanner which accepts any file
+func NewNoopScanner() Scanner {
+	return noopScanner{}
+}
+
+// NewCommandScanner creates a scanner which runs the external command for every file
+func NewCommandScanner(command string, args ...string) Scanner {
+	return &commandScanner{
+		command: command,
+		args:    args,
+	}
+}
+
+// Scan implements Scanner interface
+func (noopScanner) Scan(ctx context.Context, name string, r io.Reader) error {
+	return nil
+}
+
+// Scan implements Scanner interface
+func (s *commandScanner) Scan(ctx context.Context, name string, r io.Reader) error {
+	cmd := exec.CommandContext(ctx, s.command, s.args...) //nolint: gosec
+	cmd.Stdin = r
+
+	out := new(bytes.Buffer)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return fmt.Errorf("%w: %s: %s", ErrInfected, name, out.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to scan file %s: %w: %s", name, err, out.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package filesvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	errInvalidKey      = errors.New("invalid key")
+	errInvalidSettings = errors.New("invalid settings")
+)
+
+type (
+	// Storage keeps binary content of files by keys
+	Storage interface {
+		// Put saves content under the key, replacing the previous one
+		Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+		// Get returns content saved under the key
+		// It returns model.ErrEntityNotFound if there is no such key
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+		// Delete removes content under the key. It is not an error if the key does not exist
+		Delete(ctx context.Context, key string) error
+	}
+)
+
+// validateKey ensures the key is safe to use as a file name or an object name
+func validateKey(key string) error {
+	if key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return fmt.Errorf("%w: invalid storage key '%s'", errInvalidKey, key)
+	}
+	return nil
+}
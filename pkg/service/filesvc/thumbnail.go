@@ -0,0 +1,116 @@
+package filesvc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// ThumbnailSize is a max width and height of thumbnails in pixels
+	ThumbnailSize = 256
+
+	// thumbnailMaxPixels protects from decompression bombs: images with more pixels get no thumbnail
+	thumbnailMaxPixels = 40_000_000
+)
+
+// ErrNoThumbnail is returned when the content is not a supported image
+var ErrNoThumbnail = errors.New("thumbnail is not supported")
+
+// Thumbnail makes a preview of the image which fits into ThumbnailSize x ThumbnailSize keeping proportions.
+// PNG, JPEG and GIF images are supported, first frame is used for animated GIF.
+// JPEG thumbnail is made for JPEG image and PNG thumbnail is made for others to keep transparency.
+// It returns the content of the thumbnail and its MIME type.
+func Thumbnail(content []byte) ([]byte, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrNoThumbnail, err.Error())
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > thumbnailMaxPixels {
+		return nil, "", fmt.Errorf("%w: image is %dx%d", ErrNoThumbnail, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrNoThumbnail, err.Error())
+	}
+
+	dst := scaleDown(src, ThumbnailSize)
+
+	buf := new(bytes.Buffer)
+	if format == "jpeg" {
+		if e := jpeg.Encode(buf, dst, &jpeg.Options{Quality: 85}); e != nil {
+			return nil, "", fmt.Errorf("unable to encode thumbnail: %w", e)
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if e := png.Encode(buf, dst); e != nil {
+		return nil, "", fmt.Errorf("unable to encode thumbnail: %w", e)
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// scaleDown averages pixels of the source into the image fitting into size x size
+// Smaller images are copied as is.
+func scaleDown(src image.Image, size int) *image.NRGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if w > size || h > size {
+		if w >= h {
+			dw, dh = size, max(1, h*size/w)
+		} else {
+			dw, dh = max(1, w*size/h), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					// colors are weighted by alpha, so transparent pixels do not darken edges
+					r += uint64(c.R) * uint64(c.A)
+					g += uint64(c.G) * uint64(c.A)
+					bl += uint64(c.B) * uint64(c.A)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			if a == 0 {
+				continue // transparent
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / a >> 8),
+				G: uint8(g / a >> 8),
+				B: uint8(bl / a >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
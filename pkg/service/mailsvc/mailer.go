@@ -0,0 +1,181 @@
+package mailsvc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// sendTimeout limits the whole SMTP conversation if the context has no deadline
+const sendTimeout = 30 * time.Second
+
+var errInvalidAddress = errors.New("invalid email address")
+
+type (
+	// Mailer sends emails to persons
+	Mailer interface {
+		// Send delivers the plain text email
+		Send(ctx context.Context, m *Mail) error
+	}
+
+	// Mail is a plain text email
+	Mail struct {
+		To      string
+		ToName  string
+		Subject string
+		Body    string
+
+		// UnsubscribeURL is sent in List-Unsubscribe header, so mail clients are able to show unsubscribe button
+		UnsubscribeURL string
+	}
+
+	// smtpMailer sends emails through the SMTP server, STARTTLS is used if the server supports it
+	smtpMailer struct {
+		addr string
+		from mail.Address
+		auth smtp.Auth
+	}
+)
+
+// NewSMTP creates a mailer using the SMTP server at host:port
+// Authentication is not used if the username is empty.
+func NewSMTP(addr, username, password, from string) (Mailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %s: %w", addr, err)
+	}
+
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidAddress, from)
+	}
+
+	s := &smtpMailer{
+		addr: addr,
+		from: *fromAddr,
+	}
+
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s, nil
+}
+
+// Send implements Mailer interface
+func (s *smtpMailer) Send(ctx context.Context, m *Mail) error {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidAddress, m.To)
+	}
+	to.Name = m.ToName
+
+	msg, err := s.message(to, m)
+	if err != nil {
+		return err
+	}
+
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("unable to connect to SMTP server: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(sendTimeout)
+	}
+
+	if e := conn.SetDeadline(deadline); e != nil {
+		return fmt.Errorf("unable to set deadline: %w", e)
+	}
+
+	host, _, _ := net.SplitHostPort(s.addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return fmt.Errorf("unable to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if e := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); e != nil {
+			return fmt.Errorf("unable to STARTTLS: %w", e)
+		}
+	}
+
+	if s.auth != nil {
+		if e := c.Auth(s.auth); e != nil {
+			return fmt.Errorf("unable to authenticate on SMTP server: %w", e)
+		}
+	}
+
+	if e := c.Mail(s.from.Address); e != nil {
+		return fmt.Errorf("unable to set sender: %w", e)
+	}
+
+	if e := c.Rcpt(to.Address); e != nil {
+		return fmt.Errorf("unable to set recipient: %w", e)
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("unable to start data: %w", err)
+	}
+
+	if _, e := w.Write(msg); e != nil {
+		return fmt.Errorf("unable to write message: %w", e)
+	}
+
+	if e := w.Close(); e != nil {
+		return fmt.Errorf("unable to send message: %w", e)
+	}
+
+	return c.Quit()
+}
+
+// message formats RFC 5322 message with quoted-printable UTF-8 body
+func (s *smtpMailer) message(to *mail.Address, m *Mail) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	header := func(k, v string) {
+		// values must not break headers
+		v = strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+		fmt.Fprintf(buf, "%s: %s\r\n", k, v)
+	}
+
+	header("From", s.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+
+	if m.UnsubscribeURL != "" {
+		header("List-Unsubscribe", "<"+m.UnsubscribeURL+">")
+		header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("unable to encode body: %w", err)
+	}
+
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("unable to encode body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
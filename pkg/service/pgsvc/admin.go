@@ -0,0 +1,283 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// AdminSvc is a service for user management by admins
+	AdminSvc struct {
+		db               *sql.DB
+		impersonationTTL time.Duration
+	}
+)
+
+const (
+	defaultPersonsLimit = 50
+	maxPersonsLimit     = 500
+)
+
+// NewAdmin creates service
+func NewAdmin(db *sql.DB, impersonationTTL time.Duration) *AdminSvc {
+	return &AdminSvc{
+		db:               db,
+		impersonationTTL: impersonationTTL,
+	}
+}
+
+// ListPersons implements service.Admin interface
+func (s *AdminSvc) ListPersons(ctx context.Context, actorID, query string, limit, offset int) ([]*model.Person, error) {
+	if limit <= 0 {
+		limit = defaultPersonsLimit
+	}
+
+	if limit > maxPersonsLimit {
+		limit = maxPersonsLimit
+	}
+
+	if offset < 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("offset"),
+		}
+	}
+
+	result := make([]*model.Person, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		oo, err := queries.PersonsSearch(ctx, pgdao.PersonsSearchParams{
+			Query: strings.TrimSpace(query),
+			Lim:   int32(limit),
+			Off:   int32(offset),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to PersonsSearch: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, personDBtoModel(o))
+		}
+
+		return nil
+	})
+}
+
+// GetPerson implements service.Admin interface
+func (s *AdminSvc) GetPerson(ctx context.Context, id, actorID string) (*model.Person, error) {
+	var result *model.Person
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		o, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		result = personDBtoModel(o)
+		return nil
+	})
+}
+
+// SetAdmin implements service.Admin interface
+func (s *AdminSvc) SetAdmin(ctx context.Context, id, actorID string, isAdmin bool, reason string) (*model.Person, error) {
+	var result *model.Person
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		o, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		// admin is unable to revoke own privileges to keep at least one admin in the system
+		if o.ID == actorID {
+			return fmt.Errorf("%w: admin can not change own privileges", model.ErrInappropriateAction)
+		}
+
+		if o.IsAdmin == isAdmin {
+			if isAdmin {
+				return fmt.Errorf("%w: person is already admin", model.ErrInappropriateAction)
+			}
+			return fmt.Errorf("%w: person is not admin", model.ErrInappropriateAction)
+		}
+
+		if e := queries.PersonSetIsAdmin(ctx, pgdao.PersonSetIsAdminParams{
+			IsAdmin: isAdmin,
+			ID:      o.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to PersonSetIsAdmin with id=%s: %w", o.ID, e)
+		}
+
+		action := model.AuditRevokeAdmin
+		if isAdmin {
+			action = model.AuditGrantAdmin
+		}
+
+		if e := addAudit(ctx, queries, actorID, action, model.TargetPerson, o.ID, strings.TrimSpace(reason)); e != nil {
+			return e
+		}
+
+		o.IsAdmin = isAdmin
+		result = personDBtoModel(o)
+		return nil
+	})
+}
+
+// ResetAccessToken implements service.Admin interface
+func (s *AdminSvc) ResetAccessToken(ctx context.Context, id, actorID, reason string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		o, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		if e := queries.SessionsDeleteByPerson(ctx, o.ID); e != nil {
+			return fmt.Errorf("unable to SessionsDeleteByPerson with id=%s: %w", o.ID, e)
+		}
+
+		if e := queries.APIKeysDeleteByPerson(ctx, o.ID); e != nil {
+			return fmt.Errorf("unable to APIKeysDeleteByPerson with id=%s: %w", o.ID, e)
+		}
+
+		if e := queries.ImpersonationsEndByPerson(ctx, o.ID); e != nil {
+			return fmt.Errorf("unable to ImpersonationsEndByPerson with id=%s: %w", o.ID, e)
+		}
+
+		return addAudit(ctx, queries, actorID, model.AuditResetToken, model.TargetPerson, o.ID, strings.TrimSpace(reason))
+	})
+}
+
+// Impersonate implements service.Admin interface
+func (s *AdminSvc) Impersonate(ctx context.Context, id, actorID, reason string) (*model.UserContext, error) {
+	var result *model.UserContext
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("reason"),
+		}
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		o, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		// impersonation of an admin would be a way to get privileges of another admin
+		if o.IsAdmin {
+			return fmt.Errorf("%w: admin can not be impersonated", model.ErrInappropriateAction)
+		}
+
+		if o.BlockedAt.Valid {
+			return fmt.Errorf("%w: person is blocked", model.ErrInappropriateAction)
+		}
+
+		token := pgdao.NewID()
+
+		_, err = queries.ImpersonationAdd(ctx, pgdao.ImpersonationAddParams{
+			ID:        pgdao.NewID(),
+			TokenHash: hashToken(token),
+			AdminID:   actorID,
+			PersonID:  o.ID,
+			Reason:    reason,
+			ExpiresAt: time.Now().Add(s.impersonationTTL).UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to ImpersonationAdd: %w", err)
+		}
+
+		if e := addAudit(ctx, queries, actorID, model.AuditImpersonate, model.TargetPerson, o.ID, reason); e != nil {
+			return e
+		}
+
+		result = &model.UserContext{
+			Authenticated:  true,
+			Token:          token,
+			Subject:        personDBtoModel(o),
+			ImpersonatedBy: actorID,
+		}
+		return nil
+	})
+}
+
+// ListPersonJobs implements service.Admin interface
+func (s *AdminSvc) ListPersonJobs(ctx context.Context, id, actorID string) ([]*model.JobCardDTO, error) {
+	if err := s.auditView(ctx, id, actorID, model.AuditViewJobs); err != nil {
+		return nil, err
+	}
+
+	return NewJob(s.db).ListByOwner(ctx, id)
+}
+
+// ListPersonApplications implements service.Admin interface
+func (s *AdminSvc) ListPersonApplications(ctx context.Context, id, actorID string) ([]*model.ApplicationDTO, error) {
+	if err := s.auditView(ctx, id, actorID, model.AuditViewApplications); err != nil {
+		return nil, err
+	}
+
+	return NewApplication(s.db, nil).ListByApplicant(ctx, id)
+}
+
+// ListPersonContracts implements service.Admin interface
+func (s *AdminSvc) ListPersonContracts(ctx context.Context, id, actorID string) ([]*model.ContractDTO, error) {
+	if err := s.auditView(ctx, id, actorID, model.AuditViewContracts); err != nil {
+		return nil, err
+	}
+
+	return NewContract(s.db, nil).ListByPersonID(ctx, id)
+}
+
+// auditView checks that the actor is an admin and records that the admin looked at the person's data
+func (s *AdminSvc) auditView(ctx context.Context, id, actorID, action string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		o, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		return addAudit(ctx, queries, actorID, action, model.TargetPerson, o.ID, "")
+	})
+}
+
+func personForAdmin(ctx context.Context, queries *pgdao.Queries, id string) (pgdao.Person, error) {
+	o, err := queries.PersonGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgdao.Person{}, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return pgdao.Person{}, fmt.Errorf("unable to PersonGet with id=%s: %w", id, err)
+	}
+
+	return o, nil
+}
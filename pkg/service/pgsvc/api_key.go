@@ -0,0 +1,285 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+// apiKeyPrefixLen is length of the beginning of the key shown in the list
+const apiKeyPrefixLen = len(model.APIKeyPrefix) + 6
+
+// apiKeyRoutes are routes accessible with API keys and scopes required for them
+// The pattern is matched against the request path like auth exceptions. Routes absent here are not accessible with API keys.
+var apiKeyRoutes = [][3]string{
+	{http.MethodGet, "/me", ""}, // any key
+	{http.MethodGet, "/jobs/mine", model.ScopeJobsRead},
+	{http.MethodGet, "/jobs/*", model.ScopeJobsRead},
+	{http.MethodGet, "/jobs/*/revisions", model.ScopeJobsRead},
+	{http.MethodPost, "/jobs", model.ScopeJobsWrite},
+	{http.MethodPut, "/jobs/*", model.ScopeJobsWrite},
+	{http.MethodPut, "/jobs/*/questions", model.ScopeJobsWrite},
+	{http.MethodPost, "/jobs/*/suspend", model.ScopeJobsWrite},
+	{http.MethodPost, "/jobs/*/resume", model.ScopeJobsWrite},
+	{http.MethodPost, "/jobs/*/reopen", model.ScopeJobsWrite},
+	{http.MethodPost, "/jobs/*/publish", model.ScopeJobsWrite},
+	{http.MethodPost, "/attachments", model.ScopeJobsWrite},
+	{http.MethodDelete, "/attachments/*", model.ScopeJobsWrite},
+	{http.MethodGet, "/applications", model.ScopeApplicationsRead},
+	{http.MethodGet, "/applications/*", model.ScopeApplicationsRead},
+	{http.MethodGet, "/applications/*/revisions", model.ScopeApplicationsRead},
+	{http.MethodGet, "/applications/*/attachments", model.ScopeApplicationsRead},
+	{http.MethodGet, "/jobs/*/application", model.ScopeApplicationsRead},
+	{http.MethodGet, "/jobs/*/applications", model.ScopeApplicationsRead},
+	{http.MethodPost, "/jobs/*/applications", model.ScopeApplicationsWrite},
+	{http.MethodPut, "/applications/*", model.ScopeApplicationsWrite},
+	{http.MethodPost, "/applications/*/withdraw", model.ScopeApplicationsWrite},
+	{http.MethodPost, "/applications/*/status", model.ScopeApplicationsWrite},
+	{http.MethodGet, "/contracts", model.ScopeContractsRead},
+	{http.MethodGet, "/contracts/*", model.ScopeContractsRead},
+	{http.MethodPost, "/contracts", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/accept", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/deploy", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/sign", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/fund", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/approve", model.ScopeContractsWrite},
+	{http.MethodPost, "/contracts/*/complete", model.ScopeContractsWrite},
+	{http.MethodGet, "/chats", model.ScopeChatsRead},
+	{http.MethodGet, "/chats/*", model.ScopeChatsRead},
+	{http.MethodGet, "/chats/*/messages", model.ScopeChatsRead},
+	{http.MethodGet, "/applications/*/chat", model.ScopeChatsRead},
+	{http.MethodGet, "/contracts/*/chat", model.ScopeChatsRead},
+	{http.MethodPost, "/chats/*/messages", model.ScopeChatsWrite},
+	{http.MethodPut, "/chats/*/messages/*", model.ScopeChatsWrite},
+	{http.MethodDelete, "/chats/*/messages/*", model.ScopeChatsWrite},
+	{http.MethodPost, "/chats/*/typing", model.ScopeChatsWrite},
+	{http.MethodPost, "/chats/*/read", model.ScopeChatsWrite},
+}
+
+var apiKeyScopes = []string{
+	model.ScopeJobsRead,
+	model.ScopeJobsWrite,
+	model.ScopeApplicationsRead,
+	model.ScopeApplicationsWrite,
+	model.ScopeContractsRead,
+	model.ScopeContractsWrite,
+	model.ScopeChatsRead,
+	model.ScopeChatsWrite,
+}
+
+type (
+	// APIKeySvc is a service for personal API keys of persons
+	APIKeySvc struct {
+		db *sql.DB
+	}
+)
+
+// NewAPIKey creates service
+func NewAPIKey(db *sql.DB) *APIKeySvc {
+	return &APIKeySvc{db: db}
+}
+
+// apiKeyRouteAllowed checks if the route is accessible with the scopes
+func apiKeyRouteAllowed(method, urlPath string, scopes []string) bool {
+	for _, r := range apiKeyRoutes {
+		match, err := path.Match(r[1], urlPath)
+		if err != nil {
+			panic(fmt.Errorf("invalid pattern %s: %w", r, err))
+		}
+
+		if !match || r[0] != method {
+			continue
+		}
+
+		if r[2] == "" || hasScope(scopes, r[2]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ipAllowed checks if the IP address is in the allowlist, empty allowlist allows any address
+func ipAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, a := range allowlist {
+		if _, network, err := net.ParseCIDR(a); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+		} else if allowed := net.ParseIP(a); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func apiKeyDBtoDTO(o pgdao.ApiKey) *model.APIKeyDTO {
+	return &model.APIKeyDTO{
+		ID:          o.ID,
+		Name:        o.Name,
+		Prefix:      o.TokenPrefix,
+		Scopes:      o.Scopes,
+		IPAllowlist: o.IpAllowlist,
+		CreatedAt:   o.CreatedAt,
+		LastUsedAt:  nullTimeToPtr(o.LastUsedAt),
+		ExpiresAt:   nullTimeToPtr(o.ExpiresAt),
+	}
+}
+
+// validateAPIKey checks the params and returns scopes without duplicates and normalized allowlist
+func validateAPIKey(dto *model.APIKeyParamsDTO) ([]string, []string, error) {
+	if strings.TrimSpace(dto.Name) == "" {
+		return nil, nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("name"),
+		}
+	}
+
+	if len(dto.Scopes) == 0 {
+		return nil, nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("scopes"),
+		}
+	}
+
+	for _, requested := range dto.Scopes {
+		if !hasScope(apiKeyScopes, requested) {
+			return nil, nil, &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorInvalidFormat("scopes"),
+			}
+		}
+	}
+
+	// known scopes keep their order
+	scopes := make([]string, 0, len(dto.Scopes))
+	for _, s := range apiKeyScopes {
+		if hasScope(dto.Scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+
+	allowlist := make([]string, 0, len(dto.IPAllowlist))
+	for _, a := range dto.IPAllowlist {
+		a = strings.TrimSpace(a)
+		if _, network, err := net.ParseCIDR(a); err == nil {
+			allowlist = append(allowlist, network.String())
+		} else if ip := net.ParseIP(a); ip != nil {
+			allowlist = append(allowlist, ip.String())
+		} else {
+			return nil, nil, &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorInvalidFormat("ip_allowlist"),
+			}
+		}
+	}
+
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return nil, nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustBeInFuture("expires_at"),
+		}
+	}
+
+	return scopes, allowlist, nil
+}
+
+// Add implements service.APIKey interface
+func (s *APIKeySvc) Add(ctx context.Context, actorID string, dto *model.APIKeyParamsDTO) (*model.APIKeyDTO, error) {
+	scopes, allowlist, err := validateAPIKey(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *model.APIKeyDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		key := model.APIKeyPrefix + pgdao.NewID()
+
+		input := pgdao.APIKeyAddParams{
+			ID:          pgdao.NewID(),
+			PersonID:    actorID,
+			Name:        strings.TrimSpace(dto.Name),
+			TokenHash:   hashToken(key),
+			TokenPrefix: key[:apiKeyPrefixLen],
+			Scopes:      scopes,
+			IpAllowlist: allowlist,
+		}
+
+		if dto.ExpiresAt != nil {
+			input.ExpiresAt = sql.NullTime{Time: dto.ExpiresAt.UTC(), Valid: true}
+		}
+
+		o, err := queries.APIKeyAdd(ctx, input)
+		if err != nil {
+			return fmt.Errorf("unable to APIKeyAdd: %w", err)
+		}
+
+		result = apiKeyDBtoDTO(o)
+		result.Key = key
+		return nil
+	})
+}
+
+// List implements service.APIKey interface
+func (s *APIKeySvc) List(ctx context.Context, actorID string) ([]*model.APIKeyDTO, error) {
+	result := make([]*model.APIKeyDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.APIKeysListByPerson(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to APIKeysListByPerson: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, apiKeyDBtoDTO(o))
+		}
+
+		return nil
+	})
+}
+
+// Delete implements service.APIKey interface
+func (s *APIKeySvc) Delete(ctx context.Context, id, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		n, err := queries.APIKeyDelete(ctx, pgdao.APIKeyDeleteParams{
+			ID:       id,
+			PersonID: actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to APIKeyDelete with id='%s': %w", id, err)
+		}
+
+		if n == 0 {
+			return model.ErrEntityNotFound
+		}
+
+		return nil
+	})
+}
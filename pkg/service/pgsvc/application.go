@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/lib/pq"
@@ -17,110 +18,233 @@ import (
 type (
 	// ApplicationSvc is a application service
 	ApplicationSvc struct {
-		db *sql.DB
+		db       *sql.DB
+		connects *model.ConnectsPolicy
 	}
 )
 
 // NewApplication creates service
-func NewApplication(db *sql.DB) *ApplicationSvc {
-	return &ApplicationSvc{db: db}
+// Applications cost connects according to the policy.
+func NewApplication(db *sql.DB, connects *model.ConnectsPolicy) *ApplicationSvc {
+	return &ApplicationSvc{
+		db:       db,
+		connects: connects,
+	}
 }
 
 // Add implements Application interface
 func (s *ApplicationSvc) Add(ctx context.Context, applicantID string, dto *model.CreateApplicationDTO) (*model.ApplicationDTO, error) {
 	var result *model.ApplicationDTO
 
-	if strings.TrimSpace(dto.Comment) == "" {
-		return nil, &model.BackendError{
+	if err := validateApplicationParams(dto.Comment, dto.Price); err != nil {
+		return nil, err
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, dto.JobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to get job %s info: %w", dto.JobID, err)
+		}
+
+		result, err = applyForJob(ctx, queries, s.connects, applicantID, job, dto)
+		return err
+	})
+}
+
+// applyForJob creates the application, spends connects of the applicant and binds uploaded attachments to the application
+// It is the only way to apply, either directly or by accepting an invitation.
+func applyForJob(ctx context.Context, queries *pgdao.Queries, connects *model.ConnectsPolicy, applicantID string, job pgdao.JobGetRow, dto *model.CreateApplicationDTO) (*model.ApplicationDTO, error) {
+	result, err := addApplication(ctx, queries, applicantID, job, dto.Comment, dto.Price, dto.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if e := spendConnects(ctx, queries, connects, applicantID, job.Budget); e != nil {
+		return nil, e
+	}
+
+	if e := bindAttachments(ctx, queries, applicantID, dto.AttachmentIDs, attachToApplication, result.ID); e != nil {
+		return nil, e
+	}
+
+	return result, nil
+}
+
+func validateApplicationParams(comment string, price decimal.Decimal) error {
+	if strings.TrimSpace(comment) == "" {
+		return &model.BackendError{
 			Cause:   model.ErrValidationFailed,
 			Message: model.ValidationErrorRequired("comment"),
 		}
 	}
 
-	if decimal.Zero.Equal(dto.Price) {
-		return nil, &model.BackendError{
+	if decimal.Zero.Equal(price) {
+		return &model.BackendError{
 			Cause:   model.ErrValidationFailed,
 			Message: model.ValidationErrorRequired("price"),
 		}
 	}
 
-	if dto.Price.IsNegative() {
-		return nil, &model.BackendError{
+	if price.IsNegative() {
+		return &model.BackendError{
 			Cause:   model.ErrValidationFailed,
 			Message: model.ValidationErrorMustBePositive("price"),
 		}
 	}
 
-	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
-		job, err := queries.JobGet(ctx, dto.JobID)
+	return nil
+}
 
-		if errors.Is(err, sql.ErrNoRows) {
-			return model.ErrEntityNotFound
+// addApplication creates a new application for the job with a chat for it
+// Private jobs accept applications only from invited persons.
+// A pending invitation of the applicant becomes accepted.
+// Answers are validated against screening questions of the job.
+func addApplication(ctx context.Context, queries *pgdao.Queries, applicantID string, job pgdao.JobGetRow, comment string, price decimal.Decimal, answers []*model.AnswerParamsDTO) (*model.ApplicationDTO, error) {
+	if job.Status != model.JobStatusOpen {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "job does not accept new applications",
 		}
+	}
 
-		if err != nil {
-			return fmt.Errorf("unable to get job %s info: %w", dto.JobID, err)
-		}
+	applicant, err := queries.PersonGet(ctx, applicantID)
+	if err != nil {
+		return nil, model.ErrInsufficientRights
+	}
 
-		if job.SuspendedAt.Valid {
-			return &model.BackendError{
-				Cause:   model.ErrValidationFailed,
-				Message: "job does not accept new applications",
-			}
-		}
+	if applicant.ID == job.CreatedBy {
+		return nil, model.ErrInsufficientRights
+	}
 
-		applicant, err := queries.PersonGet(ctx, applicantID)
-		if err != nil {
-			return model.ErrInsufficientRights
-		}
+	blocked, err := queries.PersonBlocked(ctx, pgdao.PersonBlockedParams{
+		PersonID:  job.CreatedBy,
+		BlockedID: applicant.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to PersonBlocked: %w", err)
+	}
 
-		if applicant.ID == job.CreatedBy {
-			return model.ErrInsufficientRights
-		}
+	if blocked {
+		return nil, fmt.Errorf("%w: the applicant is blocked by the customer", model.ErrInsufficientRights)
+	}
 
-		applicantEthereumAddress := strings.ToLower(strings.TrimSpace(applicant.EthereumAddress))
-		if applicantEthereumAddress == "" {
-			return &model.BackendError{
-				Cause:   model.ErrValidationFailed,
-				Message: "applicant does not have wallet",
-			}
-		}
+	invitation, err := queries.InvitationFindByJobAndPerson(ctx, pgdao.InvitationFindByJobAndPersonParams{
+		JobID:    job.ID,
+		PersonID: applicant.ID,
+	})
+	invited := err == nil
 
-		applicationParams := pgdao.ApplicationAddParams{
-			ID:          pgdao.NewID(),
-			Comment:     strings.TrimSpace(dto.Comment),
-			Price:       dto.Price.String(),
-			JobID:       job.ID,
-			ApplicantID: applicant.ID,
-		}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("unable to InvitationFindByJobAndPerson: %w", err)
+	}
 
-		newApplication, err := queries.ApplicationAdd(ctx, applicationParams)
+	if job.Visibility == model.JobVisibilityPrivate && !invited {
+		return nil, model.ErrEntityNotFound
+	}
 
-		if pqe, ok := err.(*pq.Error); ok { //nolint: errorlint
-			if pqe.Code == "23505" {
-				return fmt.Errorf("%s: %w", pqe.Detail, model.ErrApplicationAlreadyExists)
-			}
+	applicantEthereumAddress := strings.ToLower(strings.TrimSpace(applicant.EthereumAddress))
+	if applicantEthereumAddress == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "applicant does not have wallet",
 		}
+	}
 
-		if err != nil {
-			return fmt.Errorf("unable to ApplicationAdd: %w", err)
-		}
+	applicationParams := pgdao.ApplicationAddParams{
+		ID:          pgdao.NewID(),
+		Comment:     strings.TrimSpace(comment),
+		Price:       price.String(),
+		JobID:       job.ID,
+		ApplicantID: applicant.ID,
+	}
+
+	newApplication, err := queries.ApplicationAdd(ctx, applicationParams)
 
-		if _, e := newChat(ctx, queries, newChatTopicApplication(newApplication.ID), newApplication.Comment, newApplication.ApplicantID, job.CreatedBy); e != nil {
-			clog.Ctx(ctx).Warn().Err(e).Str("applicationID", newApplication.ID).Msg("Failed to create chat for application")
+	if pqe, ok := err.(*pq.Error); ok { //nolint: errorlint
+		if pqe.Code == "23505" {
+			return nil, fmt.Errorf("%s: %w", pqe.Detail, model.ErrApplicationAlreadyExists)
 		}
+	}
 
-		result = &model.ApplicationDTO{
-			ID:          newApplication.ID,
-			JobID:       job.ID,
-			ApplicantID: applicant.ID,
-			Comment:     newApplication.Comment,
-			Price:       decimal.RequireFromString(newApplication.Price),
-			CreatedAt:   newApplication.CreatedAt,
+	if err != nil {
+		return nil, fmt.Errorf("unable to ApplicationAdd: %w", err)
+	}
+
+	if e := addApplicationRevision(ctx, queries, newApplication); e != nil {
+		return nil, e
+	}
+
+	if e := addApplicationAnswers(ctx, queries, job.ID, newApplication.ID, answers); e != nil {
+		return nil, e
+	}
+
+	if _, e := newChat(ctx, queries, newChatTopicApplication(newApplication.ID), newApplication.Comment, newApplication.ApplicantID, job.CreatedBy); e != nil {
+		clog.Ctx(ctx).Warn().Err(e).Str("applicationID", newApplication.ID).Msg("Failed to create chat for application")
+	}
+
+	if invited && invitation.Status == model.InvitationPending {
+		if _, e := queries.InvitationRespond(ctx, pgdao.InvitationRespondParams{
+			Status:        model.InvitationAccepted,
+			ApplicationID: sql.NullString{String: newApplication.ID, Valid: true},
+			ID:            invitation.ID,
+		}); e != nil {
+			return nil, fmt.Errorf("unable to InvitationRespond with id=%s: %w", invitation.ID, e)
 		}
+	}
 
-		return nil
-	})
+	aa, err := listApplicationAnswers(ctx, queries, newApplication.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ApplicationDTO{
+		ID:          newApplication.ID,
+		JobID:       job.ID,
+		ApplicantID: applicant.ID,
+		Comment:     newApplication.Comment,
+		Price:       decimal.RequireFromString(newApplication.Price),
+		CreatedAt:   newApplication.CreatedAt,
+		UpdatedAt:   newApplication.UpdatedAt,
+		Status:      newApplication.Status,
+		Answers:     aa,
+	}, nil
+}
+
+// addApplicationRevision saves the current terms of the application as a new revision
+func addApplicationRevision(ctx context.Context, queries *pgdao.Queries, a pgdao.Application) error {
+	if _, err := queries.ApplicationRevisionAdd(ctx, pgdao.ApplicationRevisionAddParams{
+		ID:            pgdao.NewID(),
+		ApplicationID: a.ID,
+		Comment:       a.Comment,
+		Price:         a.Price,
+	}); err != nil {
+		return fmt.Errorf("unable to ApplicationRevisionAdd for application %s: %w", a.ID, err)
+	}
+
+	return nil
+}
+
+// addApplicationSystemMessage posts the system message about the event to the application chat on behalf of the actor
+// The chat is started with both participants if it does not exist.
+func addApplicationSystemMessage(ctx context.Context, queries *pgdao.Queries, applicationID, actorID, participant, text string, payload *model.SystemMessagePayload) error {
+	topic := newChatTopicApplication(applicationID)
+	chat, err := queries.ChatGetByTopic(ctx, topic)
+
+	// chat might be not created together with the application
+	if errors.Is(err, sql.ErrNoRows) {
+		chat, err = startChat(ctx, queries, topic, actorID, participant)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to get chat by topic: %w", err)
+	}
+
+	return addSystemMessage(ctx, queries, chat.ID, actorID, text, payload)
 }
 
 // GetForJob returns application for specific job by applicant
@@ -166,13 +290,17 @@ func (s *ApplicationSvc) GetForJob(ctx context.Context, jobID, actorID string) (
 			Comment:                  a.Comment,
 			Price:                    decimal.RequireFromString(a.Price),
 			CreatedAt:                a.CreatedAt,
+			UpdatedAt:                a.UpdatedAt,
+			Status:                   a.Status,
+			RejectionReason:          a.RejectionReason,
 			ApplicantEthereumAddress: a.ApplicantEthereumAddress,
 			ApplicantDisplayName:     a.ApplicantDisplayName,
 			ContractID:               a.ContractID.String,
 			ContractStatus:           a.ContractStatus.String,
 		}
 
-		return nil
+		result.Answers, err = listApplicationAnswers(ctx, queries, a.ID)
+		return err
 	})
 }
 
@@ -218,6 +346,10 @@ func (s *ApplicationSvc) Get(ctx context.Context, id, actorID string) (*model.Ap
 			Comment:                  application.Comment,
 			Price:                    decimal.RequireFromString(application.Price),
 			CreatedAt:                application.CreatedAt,
+			UpdatedAt:                application.UpdatedAt,
+			WithdrawnAt:              nullTimeToPtr(application.WithdrawnAt),
+			Status:                   application.Status,
+			RejectionReason:          application.RejectionReason,
 			ApplicantEthereumAddress: application.ApplicantEthereumAddress,
 			ApplicantDisplayName:     application.ApplicantDisplayName,
 			ContractID:               application.ContractID.String,
@@ -228,14 +360,22 @@ func (s *ApplicationSvc) Get(ctx context.Context, id, actorID string) (*model.Ap
 			result.JobBudget = decimal.RequireFromString(application.JobBudget.String)
 		}
 
-		return nil
+		result.Answers, err = listApplicationAnswers(ctx, queries, application.ID)
+		return err
 	})
 }
 
 // ListByJob returns applications belong to specific job by ID
-func (s *ApplicationSvc) ListByJob(ctx context.Context, jobID, actorID string) ([]*model.ApplicationDTO, error) {
+func (s *ApplicationSvc) ListByJob(ctx context.Context, jobID, actorID, status string) ([]*model.ApplicationDTO, error) {
 	result := make([]*model.ApplicationDTO, 0)
 
+	if status != "" && !validApplicationStatus(status) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("status"),
+		}
+	}
+
 	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
 		job, err := queries.JobGet(ctx, jobID)
 
@@ -256,11 +396,26 @@ func (s *ApplicationSvc) ListByJob(ctx context.Context, jobID, actorID string) (
 			return model.ErrInsufficientRights
 		}
 
-		aa, err := queries.ApplicationsGetByJob(ctx, job.ID)
+		aa, err := queries.ApplicationsGetByJob(ctx, pgdao.ApplicationsGetByJobParams{
+			JobID:  job.ID,
+			Status: status,
+		})
 		if err != nil {
 			return fmt.Errorf("unable to ApplicationsGetByJob: %w", err)
 		}
 
+		questions, err := listJobQuestions(ctx, queries, job.ID)
+		if err != nil {
+			return err
+		}
+
+		answers, err := queries.ApplicationAnswersListByJob(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationAnswersListByJob: %w", err)
+		}
+
+		answersByApplication := groupAnswers(answers)
+
 		for _, a := range aa {
 			app := &model.ApplicationDTO{
 				ID:                       a.ID,
@@ -269,10 +424,15 @@ func (s *ApplicationSvc) ListByJob(ctx context.Context, jobID, actorID string) (
 				Comment:                  a.Comment,
 				Price:                    decimal.RequireFromString(a.Price),
 				CreatedAt:                a.CreatedAt,
+				UpdatedAt:                a.UpdatedAt,
+				WithdrawnAt:              nullTimeToPtr(a.WithdrawnAt),
+				Status:                   a.Status,
+				RejectionReason:          a.RejectionReason,
 				ApplicantEthereumAddress: a.ApplicantEthereumAddress,
 				ApplicantDisplayName:     a.ApplicantDisplayName,
 				ContractID:               a.ContractID.String,
 				ContractStatus:           a.ContractStatus.String,
+				Answers:                  alignAnswers(questions, answersByApplication[a.ID]),
 			}
 
 			result = append(result, app)
@@ -306,6 +466,10 @@ func (s *ApplicationSvc) ListByApplicant(ctx context.Context, actorID string) ([
 				Comment:                  a.Comment,
 				Price:                    decimal.RequireFromString(a.Price),
 				CreatedAt:                a.CreatedAt,
+				UpdatedAt:                a.UpdatedAt,
+				WithdrawnAt:              nullTimeToPtr(a.WithdrawnAt),
+				Status:                   a.Status,
+				RejectionReason:          a.RejectionReason,
 				ApplicantEthereumAddress: a.ApplicantEthereumAddress,
 				ApplicantDisplayName:     a.ApplicantDisplayName,
 				ContractID:               a.ContractID.String,
@@ -373,3 +537,239 @@ func (s *ApplicationSvc) GetChat(ctx context.Context, id, actorID string) (*mode
 		return err
 	})
 }
+
+// getOwnApplication returns the application which can be changed by the actor
+// It must belong to the actor, must not be withdrawn and must not have a contract.
+func getOwnApplication(ctx context.Context, queries *pgdao.Queries, id, actorID string) (pgdao.ApplicationGetRow, error) {
+	a, err := queries.ApplicationGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return a, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return a, fmt.Errorf("unable to ApplicationGet with id=%s: %w", id, err)
+	}
+
+	if a.ApplicantID != actorID {
+		return a, model.ErrInsufficientRights
+	}
+
+	if a.WithdrawnAt.Valid {
+		return a, fmt.Errorf("%w: application is withdrawn", model.ErrInappropriateAction)
+	}
+
+	if a.ContractID.Valid {
+		return a, fmt.Errorf("%w: application already has a contract", model.ErrInappropriateAction)
+	}
+
+	return a, nil
+}
+
+// Update implements Application interface
+func (s *ApplicationSvc) Update(ctx context.Context, id, actorID string, dto *model.UpdateApplicationDTO) (*model.ApplicationDTO, error) {
+	if err := validateApplicationParams(dto.Comment, dto.Price); err != nil {
+		return nil, err
+	}
+
+	err := doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		a, err := getOwnApplication(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		comment := strings.TrimSpace(dto.Comment)
+		oldPrice := decimal.RequireFromString(a.Price)
+
+		var (
+			changes = make([]string, 0, 2)
+			payload = &model.SystemMessagePayload{
+				Event:         model.SystemEventApplicationChanged,
+				JobID:         a.JobID,
+				ApplicationID: a.ID,
+			}
+		)
+
+		if !oldPrice.Equal(dto.Price) {
+			changes = append(changes, "price has been changed from "+oldPrice.String()+" to "+dto.Price.String())
+			payload.Changes = append(payload.Changes, "price")
+		}
+
+		if a.Comment != comment {
+			changes = append(changes, "comment has been changed")
+			payload.Changes = append(payload.Changes, "comment")
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		updated, err := queries.ApplicationUpdate(ctx, pgdao.ApplicationUpdateParams{
+			Comment: comment,
+			Price:   dto.Price.String(),
+			ID:      a.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationUpdate with id=%s: %w", a.ID, err)
+		}
+
+		if e := addApplicationRevision(ctx, queries, updated); e != nil {
+			return e
+		}
+
+		text := "The application terms have been changed: " + strings.Join(changes, ", ")
+		if a.Comment != comment {
+			text += "\n\n" + comment
+		}
+
+		return addApplicationSystemMessage(ctx, queries, a.ID, a.ApplicantID, a.CustomerID, text, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id, actorID)
+}
+
+// Withdraw implements Application interface
+func (s *ApplicationSvc) Withdraw(ctx context.Context, id, actorID string) (*model.ApplicationDTO, error) {
+	err := doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		a, err := getOwnApplication(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		if e := queries.ApplicationWithdraw(ctx, a.ID); e != nil {
+			return fmt.Errorf("unable to ApplicationWithdraw with id=%s: %w", a.ID, e)
+		}
+
+		return addApplicationSystemMessage(ctx, queries, a.ID, a.ApplicantID, a.CustomerID, "The application has been withdrawn", &model.SystemMessagePayload{
+			Event:         model.SystemEventApplicationWithdrawn,
+			JobID:         a.JobID,
+			ApplicationID: a.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id, actorID)
+}
+
+// ListRevisions implements Application interface
+func (s *ApplicationSvc) ListRevisions(ctx context.Context, id, actorID string) ([]*model.ApplicationRevisionDTO, error) {
+	result := make([]*model.ApplicationRevisionDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		a, err := queries.ApplicationGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationGet with id=%s: %w", id, err)
+		}
+
+		if actorID != a.ApplicantID && actorID != a.CustomerID {
+			return model.ErrInsufficientRights
+		}
+
+		rr, err := queries.ApplicationRevisionsListByApplication(ctx, a.ID)
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationRevisionsListByApplication: %w", err)
+		}
+
+		for _, r := range rr {
+			result = append(result, &model.ApplicationRevisionDTO{
+				ID:            r.ID,
+				ApplicationID: r.ApplicationID,
+				Version:       r.Version,
+				Comment:       r.Comment,
+				Price:         decimal.RequireFromString(r.Price),
+				CreatedAt:     r.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+}
+
+func validApplicationStatus(status string) bool {
+	switch status {
+	case model.ApplicationNew, model.ApplicationShortlisted, model.ApplicationInterviewing, model.ApplicationRejected, model.ApplicationHired:
+		return true
+	}
+
+	return false
+}
+
+// SetStatus implements Application interface
+func (s *ApplicationSvc) SetStatus(ctx context.Context, id, actorID string, dto *model.ApplicationStatusDTO) (*model.ApplicationDTO, error) {
+	status := strings.TrimSpace(dto.Status)
+	reason := strings.TrimSpace(dto.RejectionReason)
+
+	if status == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("status"),
+		}
+	}
+
+	if !validApplicationStatus(status) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("status"),
+		}
+	}
+
+	if reason != "" && status != model.ApplicationRejected {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "rejection_reason is allowed for rejected status only",
+		}
+	}
+
+	err := doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		a, err := queries.ApplicationGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationGet with id=%s: %w", id, err)
+		}
+
+		if a.CustomerID != actorID {
+			return model.ErrInsufficientRights
+		}
+
+		if a.WithdrawnAt.Valid {
+			return fmt.Errorf("%w: application is withdrawn", model.ErrInappropriateAction)
+		}
+
+		if a.Status == status && a.RejectionReason == reason {
+			return fmt.Errorf("%w: application is already %s", model.ErrInappropriateAction, status)
+		}
+
+		if _, e := queries.ApplicationSetStatus(ctx, pgdao.ApplicationSetStatusParams{
+			Status:          status,
+			RejectionReason: reason,
+			ID:              a.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to ApplicationSetStatus with id=%s: %w", a.ID, e)
+		}
+
+		text := fmt.Sprintf("Your application for the job %q is %s", a.JobTitle, status)
+		if reason != "" {
+			text += ": " + reason
+		}
+
+		return notifyPerson(ctx, queries, a.ApplicantID, model.NotificationApplicationStatus, text, path.Join("/", "applications", a.ID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id, actorID)
+}
@@ -0,0 +1,502 @@
+package pgsvc
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service/filesvc"
+)
+
+type (
+	// AttachmentSvc is a service for files attached to jobs, applications and chat messages
+	AttachmentSvc struct {
+		db      *sql.DB
+		storage filesvc.Storage
+		scanner filesvc.Scanner
+		maxSize int64
+		types   []string
+	}
+)
+
+// Targets of attachments
+const (
+	attachToJob         = "job"
+	attachToApplication = "application"
+	attachToMessage     = "message"
+)
+
+// thumbnailKeySuffix is added to the storage key of the file to get the key of its thumbnail
+const thumbnailKeySuffix = "-thumbnail"
+
+// NewAttachment creates service
+// maxSize is the maximum size of a file in bytes, types is a list of allowed MIME types
+func NewAttachment(db *sql.DB, storage filesvc.Storage, scanner filesvc.Scanner, maxSize int64, types []string) *AttachmentSvc {
+	return &AttachmentSvc{
+		db:      db,
+		storage: storage,
+		scanner: scanner,
+		maxSize: maxSize,
+		types:   types,
+	}
+}
+
+// Add implements service.Attachment interface
+func (s *AttachmentSvc) Add(ctx context.Context, actorID string, dto *model.CreateAttachmentDTO) (*model.AttachmentDTO, error) {
+	var result *model.AttachmentDTO
+
+	name := filepath.Base(strings.TrimSpace(dto.Name))
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("file name"),
+		}
+	}
+
+	if dto.Size > s.maxSize {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorTooLong("file"),
+		}
+	}
+
+	// the content is read with a limit because the declared size is not trustworthy
+	content, err := io.ReadAll(io.LimitReader(dto.Content, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file content: %w", err)
+	}
+
+	if int64(len(content)) > s.maxSize {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorTooLong("file"),
+		}
+	}
+
+	if len(content) == 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "file is empty",
+		}
+	}
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if !s.allowedType(contentType) {
+		return nil, &model.BackendError{
+			Cause:    model.ErrValidationFailed,
+			Message:  "file type is not allowed",
+			TechInfo: contentType,
+		}
+	}
+
+	if e := s.scanner.Scan(ctx, name, bytes.NewReader(content)); e != nil {
+		if errors.Is(e, filesvc.ErrInfected) {
+			return nil, &model.BackendError{
+				Cause:    model.ErrValidationFailed,
+				Message:  filesvc.ErrInfected.Error(),
+				TechInfo: e.Error(),
+			}
+		}
+		return nil, e
+	}
+
+	id := pgdao.NewID()
+
+	if e := s.storage.Put(ctx, id, bytes.NewReader(content), int64(len(content)), contentType); e != nil {
+		return nil, fmt.Errorf("unable to save file content: %w", e)
+	}
+
+	thumbnailKey := s.addThumbnail(ctx, id, contentType, content)
+
+	err = doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		a, err := queries.AttachmentAdd(ctx, pgdao.AttachmentAddParams{
+			ID:           id,
+			CreatedBy:    actorID,
+			Name:         name,
+			ContentType:  contentType,
+			Size:         int64(len(content)),
+			StorageKey:   id,
+			ThumbnailKey: thumbnailKey,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to AttachmentAdd: %w", err)
+		}
+
+		result = attachmentFromDB(a)
+		return nil
+	})
+
+	if err != nil {
+		s.deleteContent(ctx, id, thumbnailKey)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// addThumbnail saves the preview of the image and returns its storage key
+// Failures are not fatal, the attachment just has no thumbnail.
+func (s *AttachmentSvc) addThumbnail(ctx context.Context, id, contentType string, content []byte) sql.NullString {
+	if !strings.HasPrefix(contentType, "image/") {
+		return sql.NullString{}
+	}
+
+	thumbnail, thumbnailType, err := filesvc.Thumbnail(content)
+	if err != nil {
+		if !errors.Is(err, filesvc.ErrNoThumbnail) {
+			clog.Ctx(ctx).Warn().Err(err).Str("key", id).Msg("Unable to make thumbnail")
+		}
+		return sql.NullString{}
+	}
+
+	key := id + thumbnailKeySuffix
+	if e := s.storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), thumbnailType); e != nil {
+		clog.Ctx(ctx).Warn().Err(e).Str("key", key).Msg("Unable to save thumbnail")
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: key, Valid: true}
+}
+
+// deleteContent removes the file content and its thumbnail from the storage
+func (s *AttachmentSvc) deleteContent(ctx context.Context, storageKey string, thumbnailKey sql.NullString) {
+	if e := s.storage.Delete(ctx, storageKey); e != nil {
+		clog.Ctx(ctx).Warn().Err(e).Str("key", storageKey).Msg("Unable to delete file content")
+	}
+
+	if thumbnailKey.Valid {
+		if e := s.storage.Delete(ctx, thumbnailKey.String); e != nil {
+			clog.Ctx(ctx).Warn().Err(e).Str("key", thumbnailKey.String).Msg("Unable to delete thumbnail")
+		}
+	}
+}
+
+func (s *AttachmentSvc) allowedType(contentType string) bool {
+	for _, t := range s.types {
+		if strings.EqualFold(strings.TrimSpace(t), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get implements service.Attachment interface
+func (s *AttachmentSvc) Get(ctx context.Context, id, actorID string) (*model.AttachmentDTO, io.ReadCloser, error) {
+	var a pgdao.Attachment
+
+	err := doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		a, err = accessibleAttachment(ctx, queries, id, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Get(ctx, a.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get file content of attachment %s: %w", a.ID, err)
+	}
+
+	return attachmentFromDB(a), content, nil
+}
+
+// GetThumbnail implements service.Attachment interface
+func (s *AttachmentSvc) GetThumbnail(ctx context.Context, id, actorID string) (*model.AttachmentDTO, io.ReadCloser, error) {
+	var a pgdao.Attachment
+
+	err := doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		a, err = accessibleAttachment(ctx, queries, id, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !a.ThumbnailKey.Valid {
+		return nil, nil, model.ErrEntityNotFound
+	}
+
+	content, err := s.storage.Get(ctx, a.ThumbnailKey.String)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get thumbnail of attachment %s: %w", a.ID, err)
+	}
+
+	return attachmentFromDB(a), content, nil
+}
+
+// Delete implements service.Attachment interface
+func (s *AttachmentSvc) Delete(ctx context.Context, id, actorID string) error {
+	var (
+		storageKey   string
+		thumbnailKey sql.NullString
+	)
+
+	err := doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		a, err := queries.AttachmentGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to AttachmentGet with id=%s: %w", id, err)
+		}
+
+		if a.CreatedBy != actorID {
+			return model.ErrInsufficientRights
+		}
+
+		storageKey, thumbnailKey = a.StorageKey, a.ThumbnailKey
+		return queries.AttachmentDelete(ctx, a.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteContent(ctx, storageKey, thumbnailKey)
+	return nil
+}
+
+// ListByJob implements service.Attachment interface
+func (s *AttachmentSvc) ListByJob(ctx context.Context, jobID, actorID string) ([]*model.AttachmentDTO, error) {
+	result := make([]*model.AttachmentDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, jobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", jobID, err)
+		}
+
+		if e := checkJobVisibleFor(ctx, queries, job, actorID); e != nil {
+			return e
+		}
+
+		aa, err := queries.AttachmentsListByJob(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to AttachmentsListByJob: %w", err)
+		}
+
+		for _, a := range aa {
+			result = append(result, attachmentFromDB(a))
+		}
+
+		return nil
+	})
+}
+
+// ListByApplication implements service.Attachment interface
+func (s *AttachmentSvc) ListByApplication(ctx context.Context, applicationID, actorID string) ([]*model.AttachmentDTO, error) {
+	result := make([]*model.AttachmentDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := checkApplicationAccessibleFor(ctx, queries, applicationID, actorID); e != nil {
+			return e
+		}
+
+		aa, err := queries.AttachmentsListByApplication(ctx, applicationID)
+		if err != nil {
+			return fmt.Errorf("unable to AttachmentsListByApplication: %w", err)
+		}
+
+		for _, a := range aa {
+			result = append(result, attachmentFromDB(a))
+		}
+
+		return nil
+	})
+}
+
+// accessibleAttachment returns the attachment if the actor is able to see it:
+// unbound attachment is available only for its creator,
+// job attachment is available for everyone who can see the job,
+// application attachment is available only for the applicant and the job owner,
+// message attachment is available only for participants of the chat while the message is not deleted
+func accessibleAttachment(ctx context.Context, queries *pgdao.Queries, id, actorID string) (pgdao.Attachment, error) {
+	a, err := queries.AttachmentGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgdao.Attachment{}, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return pgdao.Attachment{}, fmt.Errorf("unable to AttachmentGet with id=%s: %w", id, err)
+	}
+
+	switch {
+	case a.JobID.Valid:
+		job, err := queries.JobGet(ctx, a.JobID.String)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return pgdao.Attachment{}, model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return pgdao.Attachment{}, fmt.Errorf("unable to JobGet with id='%s': %w", a.JobID.String, err)
+		}
+
+		if e := checkJobVisibleFor(ctx, queries, job, actorID); e != nil {
+			return pgdao.Attachment{}, e
+		}
+
+	case a.ApplicationID.Valid:
+		if e := checkApplicationAccessibleFor(ctx, queries, a.ApplicationID.String, actorID); e != nil {
+			return pgdao.Attachment{}, e
+		}
+
+	case a.MessageID.Valid:
+		m, err := queries.MessageGet(ctx, a.MessageID.String)
+		if err != nil {
+			return pgdao.Attachment{}, fmt.Errorf("unable to MessageGet with id='%s': %w", a.MessageID.String, err)
+		}
+
+		if m.DeletedAt.Valid {
+			return pgdao.Attachment{}, model.ErrEntityNotFound
+		}
+
+		_, err = queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   m.ChatID,
+			PersonID: actorID,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return pgdao.Attachment{}, model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return pgdao.Attachment{}, fmt.Errorf("unable to ChatParticipantGet: %w", err)
+		}
+
+	default:
+		if a.CreatedBy != actorID {
+			return pgdao.Attachment{}, model.ErrEntityNotFound
+		}
+	}
+
+	return a, nil
+}
+
+// checkApplicationAccessibleFor returns model.ErrEntityNotFound if the actor is neither the applicant nor the job owner
+func checkApplicationAccessibleFor(ctx context.Context, queries *pgdao.Queries, applicationID, actorID string) error {
+	application, err := queries.ApplicationGet(ctx, applicationID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to ApplicationGet with id=%s: %w", applicationID, err)
+	}
+
+	if actorID == "" || (actorID != application.ApplicantID && actorID != application.CustomerID) {
+		return model.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+// bindAttachments attaches previously uploaded files of the actor to the job, to the application or to the message
+func bindAttachments(ctx context.Context, queries *pgdao.Queries, actorID string, ids []string, target, targetID string) error {
+	uniq := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			uniq = append(uniq, id)
+		}
+	}
+
+	if len(uniq) == 0 {
+		return nil
+	}
+
+	var (
+		n   int64
+		err error
+	)
+
+	switch target {
+	case attachToJob:
+		n, err = queries.AttachmentsBindToJob(ctx, pgdao.AttachmentsBindToJobParams{
+			JobID:     targetID,
+			Ids:       uniq,
+			CreatedBy: actorID,
+		})
+	case attachToApplication:
+		n, err = queries.AttachmentsBindToApplication(ctx, pgdao.AttachmentsBindToApplicationParams{
+			ApplicationID: targetID,
+			Ids:           uniq,
+			CreatedBy:     actorID,
+		})
+	case attachToMessage:
+		n, err = queries.AttachmentsBindToMessage(ctx, pgdao.AttachmentsBindToMessageParams{
+			MessageID: targetID,
+			Ids:       uniq,
+			CreatedBy: actorID,
+		})
+	default:
+		return fmt.Errorf("unable to bind attachments to %s: unsupported target", target)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to bind attachments: %w", err)
+	}
+
+	if n != int64(len(uniq)) {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "attachment_ids contain unknown or already used attachments",
+		}
+	}
+
+	return nil
+}
+
+// messagesAttachments returns attachments of the messages grouped by message IDs
+func messagesAttachments(ctx context.Context, queries *pgdao.Queries, messageIDs []string) (map[string][]*model.AttachmentDTO, error) {
+	result := make(map[string][]*model.AttachmentDTO)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+
+	aa, err := queries.AttachmentsListByMessages(ctx, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to AttachmentsListByMessages: %w", err)
+	}
+
+	for _, a := range aa {
+		result[a.MessageID.String] = append(result[a.MessageID.String], attachmentFromDB(a))
+	}
+
+	return result, nil
+}
+
+func attachmentFromDB(a pgdao.Attachment) *model.AttachmentDTO {
+	return &model.AttachmentDTO{
+		ID:            a.ID,
+		Name:          a.Name,
+		ContentType:   a.ContentType,
+		Size:          a.Size,
+		CreatedBy:     a.CreatedBy,
+		CreatedAt:     a.CreatedAt,
+		JobID:         a.JobID.String,
+		ApplicationID: a.ApplicationID.String,
+		MessageID:     a.MessageID.String,
+		HasThumbnail:  a.ThumbnailKey.Valid,
+	}
+}
@@ -3,9 +3,11 @@ package pgsvc
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"optrispace.com/work/pkg/clog"
@@ -16,7 +18,8 @@ import (
 type (
 	// ChatSvc is a application service
 	ChatSvc struct {
-		db *sql.DB
+		db         *sql.DB
+		editWindow time.Duration // time after posting when the author is able to edit or delete the message
 	}
 )
 
@@ -26,6 +29,8 @@ func topicParts(topic string) (kind, id string) {
 		return kindApplication, topic[len(kindApplication)+5:]
 	case strings.HasPrefix(topic, "urn:"+kindContract+":"):
 		return kindContract, topic[len(kindContract)+5:]
+	case strings.HasPrefix(topic, "urn:"+kindInvitation+":"):
+		return kindInvitation, topic[len(kindInvitation)+5:]
 	}
 	return "", ""
 }
@@ -33,18 +38,26 @@ func topicParts(topic string) (kind, id string) {
 const (
 	kindApplication = "application"
 	kindContract    = "contract"
+	kindInvitation  = "invitation"
 
 	messageTextMaxLen = 4096
+
+	defaultMessagesLimit = 50
+	maxMessagesLimit     = 200
 )
 
 var (
 	newChatTopicApplication = func(id string) string { return "urn:" + kindApplication + ":" + id }
-	newChatTopicContract    = func(id string) string { return "urn:" + kindContract + ":" + id } //nolint: deadcode,unused,varcheck
+	newChatTopicContract    = func(id string) string { return "urn:" + kindContract + ":" + id }
+	newChatTopicInvitation  = func(id string) string { return "urn:" + kindInvitation + ":" + id }
 )
 
 // NewChat creates service
-func NewChat(db *sql.DB) *ChatSvc {
-	return &ChatSvc{db: db}
+func NewChat(db *sql.DB, editWindow time.Duration) *ChatSvc {
+	return &ChatSvc{
+		db:         db,
+		editWindow: editWindow,
+	}
 }
 
 func chatFromDB(src pgdao.Chat, messages ...pgdao.Message) *model.Chat {
@@ -62,6 +75,7 @@ func chatFromDB(src pgdao.Chat, messages ...pgdao.Message) *model.Chat {
 			CreatedAt: m.CreatedAt,
 			CreatedBy: m.CreatedBy,
 			Text:      m.Text,
+			Kind:      m.Kind,
 		})
 	}
 	return dst
@@ -69,12 +83,33 @@ func chatFromDB(src pgdao.Chat, messages ...pgdao.Message) *model.Chat {
 
 // starts new chat and immediately add the first message to it
 func newChat(ctx context.Context, queries *pgdao.Queries, topic, text, createdBy string, participants ...string) (*model.Chat, error) {
+	newChat, err := startChat(ctx, queries, topic, createdBy, participants...)
+	if err != nil {
+		return nil, err
+	}
+
+	// copy application comment to the first message
+	msg, err := queries.MessageAdd(ctx, pgdao.MessageAddParams{
+		ID:        pgdao.NewID(),
+		ChatID:    newChat.ID,
+		CreatedBy: createdBy,
+		Text:      text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create new message: %w", err)
+	}
+
+	return chatFromDB(newChat, msg), nil
+}
+
+// startChat creates new chat without messages
+func startChat(ctx context.Context, queries *pgdao.Queries, topic, createdBy string, participants ...string) (pgdao.Chat, error) {
 	newChat, err := queries.ChatAdd(ctx, pgdao.ChatAddParams{
 		ID:    pgdao.NewID(),
 		Topic: topic,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to create new chat: %w", err)
+		return newChat, fmt.Errorf("unable to create new chat: %w", err)
 	}
 
 	_, err = queries.ChatParticipantAdd(ctx, pgdao.ChatParticipantAddParams{
@@ -82,7 +117,7 @@ func newChat(ctx context.Context, queries *pgdao.Queries, topic, text, createdBy
 		PersonID: createdBy,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to add a person %s for a new chat: %w", createdBy, err)
+		return newChat, fmt.Errorf("unable to add a person %s for a new chat: %w", createdBy, err)
 	}
 
 	for _, p := range participants {
@@ -91,37 +126,19 @@ func newChat(ctx context.Context, queries *pgdao.Queries, topic, text, createdBy
 			PersonID: p,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("unable to add a participant %s for a new chat: %w", p, err)
+			return newChat, fmt.Errorf("unable to add a participant %s for a new chat: %w", p, err)
 		}
 	}
 
-	// copy application comment to the first message
-	msg, err := queries.MessageAdd(ctx, pgdao.MessageAddParams{
-		ID:        pgdao.NewID(),
-		ChatID:    newChat.ID,
-		CreatedBy: createdBy,
-		Text:      text,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create new message: %w", err)
-	}
-
-	return chatFromDB(newChat, msg), nil
+	return newChat, nil
 }
 
 // AddMessage implements service.Chat
-func (s *ChatSvc) AddMessage(ctx context.Context, chatID, participantID, text string) (*model.Message, error) {
-	if strings.TrimSpace(text) == "" {
-		return nil, &model.BackendError{
-			Cause:   model.ErrValidationFailed,
-			Message: model.ValidationErrorRequired("text"),
-		}
-	}
-
-	if utf8.RuneCountInString(text) > messageTextMaxLen {
-		return nil, &model.BackendError{
-			Cause:   model.ErrValidationFailed,
-			Message: model.ValidationErrorTooLong("text"),
+func (s *ChatSvc) AddMessage(ctx context.Context, chatID, participantID string, dto *model.CreateMessageDTO) (*model.Message, error) {
+	// the text may be omitted if files are sent
+	if len(dto.AttachmentIDs) == 0 || strings.TrimSpace(dto.Text) != "" {
+		if err := validateMessageText(dto.Text); err != nil {
+			return nil, err
 		}
 	}
 
@@ -147,21 +164,218 @@ func (s *ChatSvc) AddMessage(ctx context.Context, chatID, participantID, text st
 			return e
 		}
 
+		blocked, err := queries.ChatBlockedForPerson(ctx, pgdao.ChatBlockedForPersonParams{
+			ChatID:   chat.ID,
+			PersonID: participantID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to ChatBlockedForPerson: %w", err)
+		}
+
+		if blocked {
+			return fmt.Errorf("%w: the author is blocked by a participant of the chat", model.ErrInsufficientRights)
+		}
+
 		r, err := queries.MessageAdd(ctx, pgdao.MessageAddParams{
 			ID:        pgdao.NewID(),
 			ChatID:    chat.ID,
 			CreatedBy: participantID,
-			Text:      strings.TrimSpace(text),
+			Text:      strings.TrimSpace(dto.Text),
 		})
+		if err != nil {
+			return fmt.Errorf("unable to MessageAdd: %w", err)
+		}
+
+		if e := bindAttachments(ctx, queries, participantID, dto.AttachmentIDs, attachToMessage, r.ID); e != nil {
+			return e
+		}
+
+		attachments, err := messagesAttachments(ctx, queries, []string{r.ID})
+		if err != nil {
+			return err
+		}
 
 		result = &model.Message{
-			ID:        r.ID,
-			ChatID:    r.ChatID,
-			CreatedAt: r.CreatedAt,
-			CreatedBy: r.CreatedBy,
-			Text:      r.Text,
+			ID:          r.ID,
+			ChatID:      r.ChatID,
+			CreatedAt:   r.CreatedAt,
+			CreatedBy:   r.CreatedBy,
+			Text:        r.Text,
+			Kind:        r.Kind,
+			Attachments: attachments[r.ID],
 		}
-		return err
+		return nil
+	})
+}
+
+// systemPayload returns the structured description of the event of the system message
+func systemPayload(ctx context.Context, kind string, payload json.RawMessage) *model.SystemMessagePayload {
+	if kind != model.MessageSystem {
+		return nil
+	}
+
+	result := new(model.SystemMessagePayload)
+	if err := json.Unmarshal(payload, result); err != nil || result.Event == "" {
+		clog.Ctx(ctx).Warn().Err(err).RawJSON("payload", payload).Msg("System message has invalid payload")
+		return nil
+	}
+
+	return result
+}
+
+// addSystemMessage posts the message about the event on behalf of the actor
+// The text describes the event for clients which do not know it.
+func addSystemMessage(ctx context.Context, queries *pgdao.Queries, chatID, actorID, text string, payload *model.SystemMessagePayload) error {
+	p, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to marshal payload of system message: %w", err)
+	}
+
+	if _, e := queries.MessageAddSystem(ctx, pgdao.MessageAddSystemParams{
+		ID:        pgdao.NewID(),
+		ChatID:    chatID,
+		CreatedBy: actorID,
+		Text:      text,
+		Payload:   p,
+	}); e != nil {
+		return fmt.Errorf("unable to MessageAddSystem: %w", e)
+	}
+
+	return nil
+}
+
+// validateMessageText checks the text of the new or edited message
+func validateMessageText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("text"),
+		}
+	}
+
+	if utf8.RuneCountInString(text) > messageTextMaxLen {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorTooLong("text"),
+		}
+	}
+
+	return nil
+}
+
+// getOwnMessage returns the message of the chat which can be changed by the participant
+func (s *ChatSvc) getOwnMessage(ctx context.Context, queries *pgdao.Queries, chatID, messageID, participantID string) (pgdao.Message, error) {
+	m, err := queries.MessageGet(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && m.ChatID != chatID) {
+		return m, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return m, fmt.Errorf("unable to MessageGet with id=%s: %w", messageID, err)
+	}
+
+	if m.CreatedBy != participantID {
+		if _, e := queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chatID,
+			PersonID: participantID,
+		}); errors.Is(e, sql.ErrNoRows) {
+			return m, model.ErrEntityNotFound
+		}
+		return m, model.ErrInsufficientRights
+	}
+
+	if m.Kind == model.MessageSystem {
+		return m, fmt.Errorf("%w: system messages can not be changed", model.ErrInappropriateAction)
+	}
+
+	if m.DeletedAt.Valid {
+		return m, fmt.Errorf("%w: message is deleted", model.ErrInappropriateAction)
+	}
+
+	if time.Since(m.CreatedAt) > s.editWindow {
+		return m, fmt.Errorf("%w: message can be changed within %s after posting", model.ErrInappropriateAction, s.editWindow)
+	}
+
+	return m, nil
+}
+
+// EditMessage implements service.Chat
+func (s *ChatSvc) EditMessage(ctx context.Context, chatID, messageID, participantID, text string) (*model.Message, error) {
+	if err := validateMessageText(text); err != nil {
+		return nil, err
+	}
+
+	var result *model.Message
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		m, err := s.getOwnMessage(ctx, queries, chatID, messageID, participantID)
+		if err != nil {
+			return err
+		}
+
+		if text = strings.TrimSpace(text); text != m.Text {
+			if _, e := queries.MessageRevisionAdd(ctx, pgdao.MessageRevisionAddParams{
+				ID:        pgdao.NewID(),
+				MessageID: m.ID,
+				Text:      m.Text,
+			}); e != nil {
+				return fmt.Errorf("unable to MessageRevisionAdd for message %s: %w", m.ID, e)
+			}
+
+			m, err = queries.MessageUpdateText(ctx, pgdao.MessageUpdateTextParams{
+				Text: text,
+				ID:   m.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("unable to MessageUpdateText with id=%s: %w", messageID, err)
+			}
+
+			if e := notifyChatEvent(ctx, queries, &chatNotification{
+				Kind:      model.ChatEventEdit,
+				ChatID:    chatID,
+				MessageID: m.ID,
+			}); e != nil {
+				return e
+			}
+		}
+
+		result = &model.Message{
+			ID:        m.ID,
+			ChatID:    m.ChatID,
+			CreatedAt: m.CreatedAt,
+			CreatedBy: m.CreatedBy,
+			Text:      m.Text,
+			Kind:      m.Kind,
+			EditedAt:  nullTimeToPtr(m.EditedAt),
+		}
+		return nil
+	})
+}
+
+// DeleteMessage implements service.Chat
+func (s *ChatSvc) DeleteMessage(ctx context.Context, chatID, messageID, participantID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		m, err := s.getOwnMessage(ctx, queries, chatID, messageID, participantID)
+		if err != nil {
+			return err
+		}
+
+		if _, e := queries.MessageRevisionAdd(ctx, pgdao.MessageRevisionAddParams{
+			ID:        pgdao.NewID(),
+			MessageID: m.ID,
+			Text:      m.Text,
+		}); e != nil {
+			return fmt.Errorf("unable to MessageRevisionAdd for message %s: %w", m.ID, e)
+		}
+
+		if _, e := queries.MessageDelete(ctx, m.ID); e != nil {
+			return fmt.Errorf("unable to MessageDelete with id=%s: %w", m.ID, e)
+		}
+
+		return notifyChatEvent(ctx, queries, &chatNotification{
+			Kind:      model.ChatEventDelete,
+			ChatID:    chatID,
+			MessageID: m.ID,
+		})
 	})
 }
 
@@ -188,20 +402,228 @@ func (s *ChatSvc) Get(ctx context.Context, chatID, participantID string) (*model
 			return e
 		}
 
-		mm, err := queries.MessagesListByChat(ctx, chatID)
+		page, err := messagesPage(ctx, queries, chatID, &model.MessagePageParamsDTO{})
 		if err != nil {
 			return err
 		}
 
 		result = chatFromDB(ch)
+		result.Messages = page.Messages
+		result.HasOlder = page.HasOlder
+
+		return nil
+	})
+}
+
+// ListMessages implements service.Chat
+func (s *ChatSvc) ListMessages(ctx context.Context, chatID, participantID string, params *model.MessagePageParamsDTO) (*model.MessagePageDTO, error) {
+	if params.Limit < 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("limit"),
+		}
+	}
+
+	var result *model.MessagePageDTO
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if _, e := queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chatID,
+			PersonID: participantID,
+		}); e != nil {
+			if errors.Is(e, sql.ErrNoRows) {
+				e = model.ErrEntityNotFound
+			}
+			return e
+		}
+
+		var err error
+		result, err = messagesPage(ctx, queries, chatID, params)
+		return err
+	})
+}
+
+// messageCursor returns the message of the chat which is used as a bound of the page
+func messageCursor(ctx context.Context, queries *pgdao.Queries, chatID, messageID, field string) (pgdao.Message, error) {
+	m, err := queries.MessageGet(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && m.ChatID != chatID) {
+		return m, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: fmt.Sprintf("%s message does not belong to the chat", field),
+		}
+	}
+
+	if err != nil {
+		return m, fmt.Errorf("unable to MessageGet with id=%s: %w", messageID, err)
+	}
+
+	return m, nil
+}
+
+// messagesPage returns the page of messages of the chat in order of creation
+// The most recent messages are returned without cursors, messages right after the cursor are returned if after is set,
+// otherwise messages right before the cursor are returned.
+func messagesPage(ctx context.Context, queries *pgdao.Queries, chatID string, params *model.MessagePageParamsDTO) (*model.MessagePageDTO, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultMessagesLimit
+	}
+
+	if limit > maxMessagesLimit {
+		limit = maxMessagesLimit
+	}
+
+	// one more message is requested to know if there are more messages after the page
+	qp := pgdao.MessagesListByChatBackwardParams{
+		ChatID: chatID,
+		Lim:    int32(limit + 1),
+	}
+
+	if params.Before != "" {
+		m, err := messageCursor(ctx, queries, chatID, params.Before, "before")
+		if err != nil {
+			return nil, err
+		}
+		qp.HasBefore, qp.BeforeAt, qp.BeforeID = true, m.CreatedAt, m.ID
+	}
+
+	if params.After != "" {
+		m, err := messageCursor(ctx, queries, chatID, params.After, "after")
+		if err != nil {
+			return nil, err
+		}
+		qp.HasAfter, qp.AfterAt, qp.AfterID = true, m.CreatedAt, m.ID
+	}
+
+	result := &model.MessagePageDTO{
+		Messages: []model.Message{},
+	}
+
+	var mm []pgdao.MessagesListByChatBackwardRow
+
+	if params.After != "" {
+		rr, err := queries.MessagesListByChatForward(ctx, pgdao.MessagesListByChatForwardParams(qp))
+		if err != nil {
+			return nil, fmt.Errorf("unable to MessagesListByChatForward with chat_id=%s: %w", chatID, err)
+		}
+
+		for _, r := range rr {
+			mm = append(mm, pgdao.MessagesListByChatBackwardRow(r))
+		}
+
+		result.HasOlder = true // the cursor message at least
+		result.HasNewer = params.Before != "" || len(mm) > limit
+		if len(mm) > limit {
+			mm = mm[:limit]
+		}
+	} else {
+		var err error
+		mm, err = queries.MessagesListByChatBackward(ctx, qp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to MessagesListByChatBackward with chat_id=%s: %w", chatID, err)
+		}
+
+		result.HasOlder = len(mm) > limit
+		result.HasNewer = params.Before != ""
+		if len(mm) > limit {
+			mm = mm[:limit]
+		}
+
+		for i, j := 0, len(mm)-1; i < j; i, j = i+1, j-1 {
+			mm[i], mm[j] = mm[j], mm[i]
+		}
+	}
+
+	markers, err := queries.ChatParticipantsReadMarkers(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to ChatParticipantsReadMarkers with chat_id=%s: %w", chatID, err)
+	}
+
+	ids := make([]string, 0, len(mm))
+	for _, m := range mm {
+		if !m.DeletedAt.Valid {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	attachments, err := messagesAttachments(ctx, queries, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range mm {
+		result.Messages = append(result.Messages, model.Message{
+			ID:         m.ID,
+			ChatID:     chatID,
+			CreatedAt:  m.CreatedAt,
+			CreatedBy:  m.CreatedBy,
+			AuthorName: m.DisplayName,
+			Text:       m.Text,
+			ReadBy:     readBy(markers, m.CreatedBy, m.CreatedAt),
+			Kind:       m.Kind,
+			Payload:    systemPayload(ctx, m.Kind, m.Payload),
+			EditedAt:   nullTimeToPtr(m.EditedAt),
+			DeletedAt:  nullTimeToPtr(m.DeletedAt),
+
+			Attachments: attachments[m.ID],
+		})
+	}
+
+	return result, nil
+}
+
+// SearchMessages implements service.Chat
+func (s *ChatSvc) SearchMessages(ctx context.Context, participantID string, params *model.MessageSearchParamsDTO) ([]*model.MessageSearchHitDTO, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("q"),
+		}
+	}
+
+	if params.Limit < 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("limit"),
+		}
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultMessagesLimit
+	}
+
+	if limit > maxMessagesLimit {
+		limit = maxMessagesLimit
+	}
+
+	result := make([]*model.MessageSearchHitDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		mm, err := queries.MessagesSearch(ctx, pgdao.MessagesSearchParams{
+			ParticipantID: participantID,
+			Query:         params.Query,
+			Lim:           int32(limit),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to MessagesSearch: %w", err)
+		}
+
 		for _, m := range mm {
-			result.Messages = append(result.Messages, model.Message{
-				ID:         m.ID,
-				ChatID:     chatID,
-				CreatedAt:  m.CreatedAt,
-				CreatedBy:  m.CreatedBy,
-				AuthorName: m.DisplayName,
-				Text:       m.Text,
+			result = append(result, &model.MessageSearchHitDTO{
+				Message: model.Message{
+					ID:         m.ID,
+					ChatID:     m.ChatID,
+					CreatedAt:  m.CreatedAt,
+					CreatedBy:  m.CreatedBy,
+					AuthorName: m.DisplayName,
+					Text:       m.Text,
+					Kind:       m.Kind,
+					Payload:    systemPayload(ctx, m.Kind, m.Payload),
+					EditedAt:   nullTimeToPtr(m.EditedAt),
+				},
+				Topic:    m.Topic,
+				JobID:    m.JobID,
+				JobTitle: m.JobTitle,
+				Snippet:  m.Snippet,
 			})
 		}
 
@@ -219,12 +641,33 @@ func (s *ChatSvc) ListByParticipant(ctx context.Context, participantID string) (
 			return err
 		}
 
+		uu, err := queries.ChatsUnreadCountsByParticipant(ctx, participantID)
+		if err != nil {
+			return fmt.Errorf("unable to ChatsUnreadCountsByParticipant: %w", err)
+		}
+
+		unread := make(map[string]int64, len(uu))
+		for _, u := range uu {
+			unread[u.ChatID] = u.UnreadCount
+		}
+
+		bb, err := queries.PersonBlocksListByPerson(ctx, participantID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonBlocksListByPerson: %w", err)
+		}
+
+		blocked := make(map[string]bool, len(bb))
+		for _, b := range bb {
+			blocked[b.ID] = true
+		}
+
 		for _, c := range cc {
 			var (
 				kind, id      = topicParts(c.Topic)
 				jobID         = ""
 				applicationID = ""
 				contractID    = ""
+				invitationID  = ""
 				title         = ""
 			)
 
@@ -239,6 +682,26 @@ func (s *ChatSvc) ListByParticipant(ctx context.Context, participantID string) (
 					contractID = details.ContractID.String
 					title = details.JobTitle
 				}
+			case kindContract:
+				details, err := queries.ChatGetDetailsByContractID(ctx, id)
+				if err != nil {
+					clog.Ctx(ctx).Warn().Err(err).Str("chat-topic", c.Topic).Msg("Failed to get information about chat.")
+				} else {
+					jobID = details.JobID
+					applicationID = details.ApplicationID
+					contractID = details.ContractID
+					title = details.ContractTitle
+				}
+			case kindInvitation:
+				details, err := queries.ChatGetDetailsByInvitationID(ctx, id)
+				if err != nil {
+					clog.Ctx(ctx).Warn().Err(err).Str("chat-topic", c.Topic).Msg("Failed to get information about chat.")
+				} else {
+					jobID = details.JobID
+					applicationID = details.ApplicationID.String
+					invitationID = details.InvitationID
+					title = details.JobTitle
+				}
 			}
 
 			found := false
@@ -248,6 +711,7 @@ func (s *ChatSvc) ListByParticipant(ctx context.Context, participantID string) (
 						ID:              c.PersonID,
 						DisplayName:     c.PersonDisplayName,
 						EthereumAddress: c.PersonEthereumAddress,
+						Blocked:         blocked[c.PersonID],
 					})
 					found = true
 					break
@@ -262,12 +726,15 @@ func (s *ChatSvc) ListByParticipant(ctx context.Context, participantID string) (
 					JobID:         jobID,
 					ApplicationID: applicationID,
 					ContractID:    contractID,
+					InvitationID:  invitationID,
 					LastMessageAt: c.LastMessageAt,
+					UnreadCount:   unread[c.ID],
 					Participants: []*model.ParticipantDTO{
 						{
 							ID:              c.PersonID,
 							DisplayName:     c.PersonDisplayName,
 							EthereumAddress: c.PersonEthereumAddress,
+							Blocked:         blocked[c.PersonID],
 						},
 					},
 				})
@@ -277,3 +744,78 @@ func (s *ChatSvc) ListByParticipant(ctx context.Context, participantID string) (
 		return nil
 	})
 }
+
+// readBy returns IDs of participants, except the author, whose read markers cover the message created at the moment
+func readBy(markers []pgdao.ChatParticipantsReadMarkersRow, author string, createdAt time.Time) []string {
+	var result []string
+	for _, m := range markers {
+		if m.PersonID != author && !m.LastReadAt.Time.Before(createdAt) {
+			result = append(result, m.PersonID)
+		}
+	}
+
+	return result
+}
+
+// MarkRead implements service.Chat
+func (s *ChatSvc) MarkRead(ctx context.Context, chatID, participantID, messageID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if _, e := queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chatID,
+			PersonID: participantID,
+		}); e != nil {
+			if errors.Is(e, sql.ErrNoRows) {
+				e = model.ErrEntityNotFound
+			}
+			return e
+		}
+
+		params := pgdao.ChatParticipantMarkReadParams{
+			ReadAll:  messageID == "",
+			ChatID:   chatID,
+			PersonID: participantID,
+		}
+
+		if messageID != "" {
+			m, err := queries.MessageGet(ctx, messageID)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && m.ChatID != chatID) {
+				return &model.BackendError{
+					Cause:   model.ErrValidationFailed,
+					Message: "message does not belong to the chat",
+				}
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to MessageGet with id=%s: %w", messageID, err)
+			}
+
+			params.ReadAt = m.CreatedAt
+		}
+
+		p, err := queries.ChatParticipantMarkRead(ctx, params)
+		if err != nil {
+			return fmt.Errorf("unable to ChatParticipantMarkRead: %w", err)
+		}
+
+		return notifyChatEvent(ctx, queries, &chatNotification{
+			Kind:     model.ChatEventRead,
+			ChatID:   chatID,
+			PersonID: participantID,
+			ReadAt:   nullTimeToPtr(p.LastReadAt),
+		})
+	})
+}
+
+// UnreadCount implements service.Chat
+func (s *ChatSvc) UnreadCount(ctx context.Context, participantID string) (int64, error) {
+	var result int64
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		result, err = queries.MessagesUnreadCountByParticipant(ctx, participantID)
+		if err != nil {
+			return fmt.Errorf("unable to MessagesUnreadCountByParticipant: %w", err)
+		}
+
+		return nil
+	})
+}
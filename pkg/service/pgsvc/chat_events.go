@@ -0,0 +1,312 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// ChatEventsSvc delivers chat events received by LISTEN from the database to subscribed persons
+	ChatEventsSvc struct {
+		db    *sql.DB
+		dbURL string
+
+		mu          sync.RWMutex
+		subscribers map[string]map[chan *model.ChatEvent]struct{} // by person ID
+	}
+
+	// chatNotification is a payload of the chat_events channel
+	chatNotification struct {
+		Kind      string     `json:"kind"`
+		ChatID    string     `json:"chat_id"`
+		MessageID string     `json:"message_id,omitempty"`
+		PersonID  string     `json:"person_id,omitempty"`
+		ReadAt    *time.Time `json:"read_at,omitempty"`
+	}
+)
+
+const (
+	chatEventsChannel = "chat_events"
+
+	// chatEventsBuffer is a count of events kept for a slow subscriber, newer events are dropped if it is full
+	chatEventsBuffer = 64
+
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+
+	// chatTicketTTL is how long the WebSocket ticket may be used
+	chatTicketTTL = 30 * time.Second
+)
+
+// NewChatEvents creates service
+func NewChatEvents(db *sql.DB, dbURL string) *ChatEventsSvc {
+	return &ChatEventsSvc{
+		db:          db,
+		dbURL:       dbURL,
+		subscribers: make(map[string]map[chan *model.ChatEvent]struct{}),
+	}
+}
+
+// Listen implements service.ChatEvents interface
+func (s *ChatEventsSvc) Listen(ctx context.Context) error {
+	listener := pq.NewListener(s.dbURL, listenerMinReconnect, listenerMaxReconnect, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			clog.Ctx(ctx).Warn().Err(err).Int("event", int(ev)).Msg("Chat events listener problem")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(chatEventsChannel); err != nil {
+		return fmt.Errorf("unable to listen %s: %w", chatEventsChannel, err)
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.Notify:
+			if n == nil { // connection has been re-established, some events might be lost
+				continue
+			}
+
+			if err := s.dispatch(ctx, n.Extra); err != nil {
+				clog.Ctx(ctx).Warn().Err(err).Str("payload", n.Extra).Msg("Failed to dispatch chat event")
+			}
+
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				clog.Ctx(ctx).Warn().Err(err).Msg("Chat events listener ping failed")
+			}
+		}
+	}
+}
+
+// dispatch sends the event to subscribed participants of the chat
+func (s *ChatEventsSvc) dispatch(ctx context.Context, payload string) error {
+	n := new(chatNotification)
+	if err := json.Unmarshal([]byte(payload), n); err != nil {
+		return fmt.Errorf("unable to parse chat event: %w", err)
+	}
+
+	event := &model.ChatEvent{
+		Kind:     n.Kind,
+		ChatID:   n.ChatID,
+		PersonID: n.PersonID,
+		ReadAt:   n.ReadAt,
+	}
+
+	s.mu.RLock()
+	idle := len(s.subscribers) == 0
+	s.mu.RUnlock()
+
+	if idle {
+		return nil // nobody is connected to this replica
+	}
+
+	var participants []string
+
+	err := doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		participants, err = queries.ChatParticipantsList(ctx, n.ChatID)
+		if err != nil {
+			return fmt.Errorf("unable to ChatParticipantsList with chat_id=%s: %w", n.ChatID, err)
+		}
+
+		if !s.hasSubscribers(participants) {
+			participants = nil
+			return nil // participants are connected to other replicas
+		}
+
+		switch n.Kind {
+		case model.ChatEventMessage, model.ChatEventEdit, model.ChatEventDelete:
+		default:
+			return nil
+		}
+
+		m, err := queries.MessageGetWithAuthor(ctx, n.MessageID)
+		if err != nil {
+			return fmt.Errorf("unable to MessageGetWithAuthor with id=%s: %w", n.MessageID, err)
+		}
+
+		event.Message = &model.Message{
+			ID:         m.ID,
+			ChatID:     m.ChatID,
+			CreatedAt:  m.CreatedAt,
+			CreatedBy:  m.CreatedBy,
+			AuthorName: m.DisplayName,
+			Text:       m.Text,
+			Kind:       m.Kind,
+			Payload:    systemPayload(ctx, m.Kind, m.Payload),
+			EditedAt:   nullTimeToPtr(m.EditedAt),
+			DeletedAt:  nullTimeToPtr(m.DeletedAt),
+		}
+
+		if m.DeletedAt.Valid {
+			return nil
+		}
+
+		attachments, err := messagesAttachments(ctx, queries, []string{m.ID})
+		if err != nil {
+			return err
+		}
+
+		event.Message.Attachments = attachments[m.ID]
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range participants {
+		if event.Kind == model.ChatEventTyping && p == event.PersonID {
+			continue
+		}
+
+		for ch := range s.subscribers[p] {
+			select {
+			case ch <- event:
+			default:
+				clog.Ctx(ctx).Warn().Str("person", p).Str("chat", event.ChatID).Msg("Chat event dropped for slow subscriber")
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasSubscribers checks if any of the persons is subscribed on this replica
+func (s *ChatEventsSvc) hasSubscribers(persons []string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range persons {
+		if len(s.subscribers[p]) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscribe implements service.ChatEvents interface
+func (s *ChatEventsSvc) Subscribe(personID string) (<-chan *model.ChatEvent, func()) {
+	ch := make(chan *model.ChatEvent, chatEventsBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[personID] == nil {
+		s.subscribers[personID] = make(map[chan *model.ChatEvent]struct{})
+	}
+	s.subscribers[personID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subscribers[personID], ch)
+			if len(s.subscribers[personID]) == 0 {
+				delete(s.subscribers, personID)
+			}
+			close(ch)
+		})
+	}
+}
+
+// Typing implements service.ChatEvents interface
+func (s *ChatEventsSvc) Typing(ctx context.Context, chatID, personID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if _, e := queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chatID,
+			PersonID: personID,
+		}); e != nil {
+			if errors.Is(e, sql.ErrNoRows) {
+				e = model.ErrEntityNotFound
+			}
+			return e
+		}
+
+		return notifyChatEvent(ctx, queries, &chatNotification{
+			Kind:     model.ChatEventTyping,
+			ChatID:   chatID,
+			PersonID: personID,
+		})
+	})
+}
+
+// notifyChatEvent sends the event to all replicas, it is delivered after the commit of the transaction
+func notifyChatEvent(ctx context.Context, queries *pgdao.Queries, n *chatNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("unable to marshal chat event: %w", err)
+	}
+
+	if e := queries.ChatEventNotify(ctx, string(payload)); e != nil {
+		return fmt.Errorf("unable to ChatEventNotify: %w", e)
+	}
+
+	return nil
+}
+
+// Ticket implements service.ChatEvents interface
+// The ticket is stored in the database, because the handshake may come to another replica.
+func (s *ChatEventsSvc) Ticket(ctx context.Context, personID string) (*model.ChatTicketDTO, error) {
+	result := &model.ChatTicketDTO{
+		Ticket:    pgdao.NewID(),
+		ExpiresAt: time.Now().Add(chatTicketTTL).UTC(),
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := queries.ChatTicketsDeleteExpired(ctx); e != nil {
+			return fmt.Errorf("unable to ChatTicketsDeleteExpired: %w", e)
+		}
+
+		if e := queries.ChatTicketAdd(ctx, pgdao.ChatTicketAddParams{
+			TokenHash: hashToken(result.Ticket),
+			PersonID:  personID,
+			ExpiresAt: result.ExpiresAt,
+		}); e != nil {
+			return fmt.Errorf("unable to ChatTicketAdd with person_id='%s': %w", personID, e)
+		}
+
+		return nil
+	})
+}
+
+// UseTicket implements service.ChatEvents interface
+func (s *ChatEventsSvc) UseTicket(ctx context.Context, ticket string) (string, error) {
+	var personID string
+	return personID, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		personID, err = queries.ChatTicketUse(ctx, hashToken(ticket))
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrUnauthorized
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ChatTicketUse: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,169 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// ConnectsSvc is a service for balances of connects spent on applications
+	ConnectsSvc struct {
+		db     *sql.DB
+		policy *model.ConnectsPolicy
+	}
+)
+
+// connectsRefillPeriod is a period between top-ups of the balance to the weekly allowance
+const connectsRefillPeriod = 7 * 24 * time.Hour
+
+// NewConnects creates service
+func NewConnects(db *sql.DB, policy *model.ConnectsPolicy) *ConnectsSvc {
+	return &ConnectsSvc{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// Balance implements service.Connects interface
+func (s *ConnectsSvc) Balance(ctx context.Context, personID string) (*model.ConnectsDTO, error) {
+	if !s.policy.Enabled() {
+		return nil, nil
+	}
+
+	var result *model.ConnectsDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		result, err = refillConnects(ctx, queries, s.policy, personID)
+		return err
+	})
+}
+
+// Adjust implements service.Connects interface
+func (s *ConnectsSvc) Adjust(ctx context.Context, id, actorID string, dto *model.AdjustConnectsDTO) (*model.ConnectsDTO, error) {
+	if dto.Delta == 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("delta"),
+		}
+	}
+
+	var result *model.ConnectsDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		person, err := personForAdmin(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		// the weekly top-up must not override the adjustment
+		if s.policy.Enabled() {
+			if _, e := refillConnects(ctx, queries, s.policy, person.ID); e != nil {
+				return e
+			}
+		}
+
+		o, err := queries.PersonConnectsAdjust(ctx, pgdao.PersonConnectsAdjustParams{
+			Delta: dto.Delta,
+			ID:    person.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to PersonConnectsAdjust with id=%s: %w", person.ID, err)
+		}
+
+		reason := fmt.Sprintf("%+d connects", dto.Delta)
+		if r := strings.TrimSpace(dto.Reason); r != "" {
+			reason += ": " + r
+		}
+
+		if e := addAudit(ctx, queries, actorID, model.AuditAdjustConnects, model.TargetPerson, person.ID, reason); e != nil {
+			return e
+		}
+
+		result = connectsFromDB(s.policy, pgdao.PersonConnectsGetRow(o))
+		return nil
+	})
+}
+
+// refillConnects tops up the balance of the person to the weekly allowance if the week has passed
+// and returns the current balance
+func refillConnects(ctx context.Context, queries *pgdao.Queries, policy *model.ConnectsPolicy, personID string) (*model.ConnectsDTO, error) {
+	if err := queries.PersonConnectsRefill(ctx, pgdao.PersonConnectsRefillParams{
+		Allowance: policy.WeeklyAllowance,
+		ID:        personID,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to PersonConnectsRefill with id=%s: %w", personID, err)
+	}
+
+	o, err := queries.PersonConnectsGet(ctx, personID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to PersonConnectsGet with id=%s: %w", personID, err)
+	}
+
+	return connectsFromDB(policy, o), nil
+}
+
+// spendConnects takes connects for the application for the job with the budget
+// It returns model.ErrNotEnoughConnects if the balance is too low.
+func spendConnects(ctx context.Context, queries *pgdao.Queries, policy *model.ConnectsPolicy, personID string, budget sql.NullString) error {
+	if !policy.Enabled() {
+		return nil
+	}
+
+	balance, err := refillConnects(ctx, queries, policy, personID)
+	if err != nil {
+		return err
+	}
+
+	cost := policy.Cost(nullStringToDecimal(budget))
+
+	n, err := queries.PersonConnectsSpend(ctx, pgdao.PersonConnectsSpendParams{
+		Cost: cost,
+		ID:   personID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to PersonConnectsSpend with id=%s: %w", personID, err)
+	}
+
+	if n == 0 {
+		message := fmt.Sprintf("not enough connects: the application costs %d, the balance is %d", cost, balance.Balance)
+		if balance.RefillsAt != nil {
+			message += fmt.Sprintf(", it is topped up to %d at %s", policy.WeeklyAllowance, balance.RefillsAt.Format(time.RFC3339))
+		}
+
+		return &model.BackendError{
+			Cause:   model.ErrNotEnoughConnects,
+			Message: message,
+		}
+	}
+
+	return nil
+}
+
+func connectsFromDB(policy *model.ConnectsPolicy, o pgdao.PersonConnectsGetRow) *model.ConnectsDTO {
+	result := &model.ConnectsDTO{
+		Balance:         o.Connects,
+		WeeklyAllowance: policy.WeeklyAllowance,
+	}
+
+	if policy.Enabled() && o.ConnectsRefilledAt.Valid {
+		refillsAt := o.ConnectsRefilledAt.Time.Add(connectsRefillPeriod)
+		result.RefillsAt = &refillsAt
+	}
+
+	return result
+}
@@ -45,6 +45,10 @@ func (s *ContractSvc) Add(ctx context.Context, customerID string, dto *model.Cre
 			return model.ErrEntityNotFound
 		}
 
+		if application.WithdrawnAt.Valid {
+			return fmt.Errorf("%w: application is withdrawn", model.ErrInappropriateAction)
+		}
+
 		job, err := queries.JobGet(ctx, application.JobID)
 		if err != nil {
 			return model.ErrEntityNotFound
@@ -79,6 +83,18 @@ func (s *ContractSvc) Add(ctx context.Context, customerID string, dto *model.Cre
 			return model.ErrInappropriateAction
 		}
 
+		blocked, err := queries.PersonBlocked(ctx, pgdao.PersonBlockedParams{
+			PersonID:  customer.ID,
+			BlockedID: performer.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to PersonBlocked: %w", err)
+		}
+
+		if blocked {
+			return fmt.Errorf("%w: the performer is blocked by the customer", model.ErrInappropriateAction)
+		}
+
 		performerEthereumAddress := strings.ToLower(strings.TrimSpace(performer.EthereumAddress))
 		if performerEthereumAddress == "" {
 			return &model.BackendError{
@@ -129,7 +145,7 @@ func (s *ContractSvc) Add(ctx context.Context, customerID string, dto *model.Cre
 
 		result = restoreContractFromDatabase(newContract)
 
-		return notifyContractStatusChanged(ctx, queries, newContract.CustomerID, newContract.Status, newContract)
+		return notifyContractStatusChanged(ctx, queries, newContract.CustomerID, "", newContract)
 	})
 }
 
@@ -363,6 +379,48 @@ func (s *ContractSvc) GetByIDForPerson(ctx context.Context, id, personID string)
 	})
 }
 
+// GetChat returns the chat of the contract with the latest messages
+func (s *ContractSvc) GetChat(ctx context.Context, id, actorID string) (*model.Chat, error) {
+	var result *model.Chat
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		contract, err := queries.ContractGet(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ContractGet with id=%s: %w", id, err)
+		}
+
+		chat, err := contractChat(ctx, queries, contract)
+		if err != nil {
+			return err
+		}
+
+		// admins joined the chat during dispute are participants as well
+		if _, e := queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chat.ID,
+			PersonID: actorID,
+		}); e != nil {
+			if errors.Is(e, sql.ErrNoRows) {
+				e = model.ErrEntityNotFound
+			}
+			return e
+		}
+
+		page, err := messagesPage(ctx, queries, chat.ID, &model.MessagePageParamsDTO{})
+		if err != nil {
+			return err
+		}
+
+		result = chatFromDB(chat)
+		result.Messages = page.Messages
+		result.HasOlder = page.HasOlder
+
+		return nil
+	})
+}
+
 // ListByPersonID loads all related contracts for specific person
 func (s *ContractSvc) ListByPersonID(ctx context.Context, actorID string) ([]*model.ContractDTO, error) {
 	result := make([]*model.ContractDTO, 0)
@@ -445,7 +503,14 @@ func (s *ContractSvc) toStatus(ctx context.Context, actorID string, patchParams
 
 		result = restoreContractFromDatabase(o)
 
-		return notifyContractStatusChanged(ctx, queries, actorID, o.Status, o)
+		// the job is not needed anymore when the money is on the contract
+		if o.Status == model.ContractFunded {
+			if e := queries.JobCloseByApplication(ctx, o.ApplicationID); e != nil {
+				return fmt.Errorf("unable to JobCloseByApplication with application_id=%s: %w", o.ApplicationID, e)
+			}
+		}
+
+		return notifyContractStatusChanged(ctx, queries, actorID, c.Status, o)
 	})
 }
 
@@ -489,45 +554,32 @@ func restoreContractFromDatabase(contract pgdao.Contract) *model.ContractDTO {
 	return result
 }
 
-func notifyContractStatusChanged(ctx context.Context, queries *pgdao.Queries, actorID, newStatus string, contract pgdao.Contract) error {
-	contractNewStatus := "Contract has been " + newStatus
-
-	topic := newChatTopicApplication(contract.ApplicationID)
+// contractChat returns the chat of the contract, the chat is started if it does not exist yet
+func contractChat(ctx context.Context, queries *pgdao.Queries, contract pgdao.Contract) (pgdao.Chat, error) {
+	topic := newChatTopicContract(contract.ID)
 	chat, err := queries.ChatGetByTopic(ctx, topic)
-
-	// NOTE: This is a workaround to make sure that chat already exists
 	if errors.Is(err, sql.ErrNoRows) {
-		var participantID string
-
-		if actorID == contract.CustomerID {
-			participantID = contract.PerformerID
-		} else {
-			participantID = contract.CustomerID
-		}
-
-		_, err = newChat(ctx, queries, topic, contractNewStatus, actorID, participantID)
-
-		if err != nil {
-			return fmt.Errorf("unable to create chat and add message: %w", err)
-		}
-
-		return nil
+		return startChat(ctx, queries, topic, contract.CustomerID, contract.PerformerID)
 	}
 
 	if err != nil {
-		return fmt.Errorf("unable to get chat by topic: %w", err)
+		return chat, fmt.Errorf("unable to get chat by topic: %w", err)
 	}
 
-	_, err = queries.MessageAdd(ctx, pgdao.MessageAddParams{
-		ID:        pgdao.NewID(),
-		ChatID:    chat.ID,
-		CreatedBy: actorID,
-		Text:      contractNewStatus,
-	})
+	return chat, nil
+}
 
+// notifyContractStatusChanged posts the system message about the new status of the contract to its chat
+func notifyContractStatusChanged(ctx context.Context, queries *pgdao.Queries, actorID, oldStatus string, contract pgdao.Contract) error {
+	chat, err := contractChat(ctx, queries, contract)
 	if err != nil {
-		return fmt.Errorf("unable to add message to chat: %w", err)
+		return err
 	}
 
-	return nil
+	return addSystemMessage(ctx, queries, chat.ID, actorID, "Contract has been "+contract.Status, &model.SystemMessagePayload{
+		Event:      model.SystemEventContractStatusChanged,
+		ContractID: contract.ID,
+		OldStatus:  oldStatus,
+		NewStatus:  contract.Status,
+	})
 }
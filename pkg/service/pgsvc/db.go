@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"optrispace.com/work/pkg/db/pgdao"
 )
 
@@ -31,3 +33,28 @@ func doWithQueries(ctx context.Context, db *sql.DB, opts *sql.TxOptions, f func(
 	}
 	return nil
 }
+
+// nullTimeToPtr converts nullable timestamp into pointer suitable for omitempty JSON fields
+func nullTimeToPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// ptrToNullTime is an opposite of nullTimeToPtr
+// The time is converted to UTC because timestamp columns do not keep time zone
+func ptrToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// nullStringToDecimal converts nullable decimal column into decimal value, it is zero if the column is null
+func nullStringToDecimal(s sql.NullString) decimal.Decimal {
+	if !s.Valid {
+		return decimal.Zero
+	}
+	return decimal.RequireFromString(s.String)
+}
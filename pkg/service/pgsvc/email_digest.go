@@ -0,0 +1,400 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+	"optrispace.com/work/pkg/service/mailsvc"
+)
+
+const (
+	// digestMessagesPerChat limits messages of the chat quoted in the digest, the rest are only counted
+	digestMessagesPerChat = 5
+
+	// digestTextMaxLen is max length of the quoted message in runes
+	digestTextMaxLen = 200
+)
+
+type (
+	// EmailDigestSvc sends persons digests of unread chat messages by email
+	EmailDigestSvc struct {
+		db     *sql.DB
+		mailer mailsvc.Mailer
+		apiURL string // public URL of the API for unsubscribe links
+		webURL string // URL of the web application for chat links
+	}
+
+	// digestChat is a chat with unread messages in the digest
+	digestChat struct {
+		id       string
+		title    string
+		messages []pgdao.MessagesUnreadForDigestRow
+	}
+)
+
+// NewEmailDigest creates service
+// Digests are not sent if the mailer is nil.
+func NewEmailDigest(db *sql.DB, mailer mailsvc.Mailer, apiURL, webURL string) *EmailDigestSvc {
+	return &EmailDigestSvc{
+		db:     db,
+		mailer: mailer,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		webURL: strings.TrimSuffix(webURL, "/"),
+	}
+}
+
+func emailDigestSettingsFromDB(p pgdao.Person) *model.EmailDigestSettingsDTO {
+	return &model.EmailDigestSettingsDTO{
+		Frequency: p.EmailDigest,
+		Email:     p.Email,
+		SentAt:    nullTimeToPtr(p.EmailDigestSentAt),
+	}
+}
+
+// GetSettings implements service.EmailDigest
+func (s *EmailDigestSvc) GetSettings(ctx context.Context, personID string) (*model.EmailDigestSettingsDTO, error) {
+	var result *model.EmailDigestSettingsDTO
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		p, err := queries.PersonGet(ctx, personID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id='%s': %w", personID, err)
+		}
+
+		result = emailDigestSettingsFromDB(p)
+		return nil
+	})
+}
+
+// SetFrequency implements service.EmailDigest
+func (s *EmailDigestSvc) SetFrequency(ctx context.Context, personID, frequency string) (*model.EmailDigestSettingsDTO, error) {
+	switch frequency {
+	case model.EmailDigestNever, model.EmailDigestHourly, model.EmailDigestDaily, model.EmailDigestWeekly:
+	default:
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("frequency"),
+		}
+	}
+
+	var result *model.EmailDigestSettingsDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		p, err := queries.PersonSetEmailDigest(ctx, pgdao.PersonSetEmailDigestParams{
+			EmailDigest: frequency,
+			ID:          personID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to PersonSetEmailDigest with id='%s': %w", personID, err)
+		}
+
+		result = emailDigestSettingsFromDB(p)
+		return nil
+	})
+}
+
+// CheckUnsubscribe implements service.EmailDigest
+func (s *EmailDigestSvc) CheckUnsubscribe(ctx context.Context, token string) error {
+	if e := validateUnsubscribeToken(token); e != nil {
+		return e
+	}
+
+	return doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		_, err := queries.UnsubscribeTokenGet(ctx, hashToken(token))
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to UnsubscribeTokenGet: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Unsubscribe implements service.EmailDigest
+func (s *EmailDigestSvc) Unsubscribe(ctx context.Context, token string) error {
+	if e := validateUnsubscribeToken(token); e != nil {
+		return e
+	}
+
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		_, err := queries.PersonUnsubscribe(ctx, hashToken(token))
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to PersonUnsubscribe: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// validateUnsubscribeToken checks the token from the unsubscribe link is passed
+func validateUnsubscribeToken(token string) error {
+	if strings.TrimSpace(token) == "" {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("token"),
+		}
+	}
+
+	return nil
+}
+
+// SendDigests implements service.EmailDigest
+// Every digest is sent in its own transaction, so failed sending does not stop others.
+func (s *EmailDigestSvc) SendDigests(ctx context.Context) (int, error) {
+	if s.mailer == nil {
+		return 0, nil
+	}
+
+	var pp []pgdao.Person
+	if err := doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		var err error
+		pp, err = queries.PersonsDueForDigest(ctx)
+		return err
+	}); err != nil {
+		return 0, fmt.Errorf("unable to PersonsDueForDigest: %w", err)
+	}
+
+	var (
+		result int
+		ee     errs
+	)
+
+	for _, p := range pp {
+		sent, err := s.sendDigest(ctx, p)
+		if err != nil {
+			clog.Ctx(ctx).Warn().Err(err).Str("person-id", p.ID).Msg("Failed to send email digest.")
+			ee.errs = append(ee.errs, err)
+			continue
+		}
+
+		if sent {
+			result++
+		}
+	}
+
+	if ee.hasErrs() {
+		return result, ee
+	}
+
+	return result, nil
+}
+
+// sendDigest claims the digest of the person and sends unread messages.
+// Concurrent senders read the same moment of the previous digest, so only one of them succeeds in claiming.
+func (s *EmailDigestSvc) sendDigest(ctx context.Context, person pgdao.Person) (bool, error) {
+	var sent bool
+	return sent, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		mm, err := queries.MessagesUnreadForDigest(ctx, person.ID)
+		if err != nil {
+			return fmt.Errorf("unable to MessagesUnreadForDigest: %w", err)
+		}
+
+		if len(mm) == 0 {
+			return nil // messages have been read meanwhile
+		}
+
+		// the claim is rolled back together with the transaction if sending fails
+		n, e := queries.PersonClaimDigest(ctx, pgdao.PersonClaimDigestParams{
+			ID:     person.ID,
+			SentAt: person.EmailDigestSentAt,
+		})
+		if e != nil {
+			return fmt.Errorf("unable to PersonClaimDigest: %w", e)
+		}
+
+		if n == 0 {
+			return nil // the digest has been sent by another instance meanwhile
+		}
+
+		// only the hash is stored, so every email gets a new token and links of previous emails keep working
+		if e := queries.UnsubscribeTokensDeleteOld(ctx, person.ID); e != nil {
+			return fmt.Errorf("unable to UnsubscribeTokensDeleteOld: %w", e)
+		}
+
+		token := pgdao.NewID()
+		if e := queries.UnsubscribeTokenAdd(ctx, pgdao.UnsubscribeTokenAddParams{
+			TokenHash: hashToken(token),
+			PersonID:  person.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to UnsubscribeTokenAdd: %w", e)
+		}
+
+		// mail clients unsubscribe with POST to the API, people confirm unsubscribing on the web page
+		unsubscribeURL := s.apiURL + "/email-digest/unsubscribe?token=" + url.QueryEscape(token)
+		unsubscribePage := ""
+		if s.webURL != "" {
+			unsubscribePage = s.webURL + "/email-digest/unsubscribe?token=" + url.QueryEscape(token)
+		}
+
+		name := person.DisplayName
+		if name == "" {
+			name = person.Login
+		}
+
+		if e := s.mailer.Send(ctx, &mailsvc.Mail{
+			To:             person.Email,
+			ToName:         name,
+			Subject:        fmt.Sprintf("You have %d unread messages", len(mm)),
+			Body:           s.digestBody(name, digestChats(ctx, queries, mm), unsubscribePage),
+			UnsubscribeURL: unsubscribeURL,
+		}); e != nil {
+			return fmt.Errorf("unable to send email digest: %w", e)
+		}
+
+		sent = true
+		return nil
+	})
+}
+
+// digestChats groups messages by chats keeping the order of messages
+func digestChats(ctx context.Context, queries *pgdao.Queries, mm []pgdao.MessagesUnreadForDigestRow) []*digestChat {
+	var result []*digestChat
+
+	for _, m := range mm {
+		if len(result) == 0 || result[len(result)-1].id != m.ChatID {
+			result = append(result, &digestChat{
+				id:    m.ChatID,
+				title: digestChatTitle(ctx, queries, m.Topic),
+			})
+		}
+
+		c := result[len(result)-1]
+		c.messages = append(c.messages, m)
+	}
+
+	return result
+}
+
+// digestChatTitle describes the chat by its topic
+func digestChatTitle(ctx context.Context, queries *pgdao.Queries, topic string) string {
+	var (
+		kind, id = topicParts(topic)
+		title    string
+		err      error
+	)
+
+	switch kind {
+	case kindApplication:
+		var d pgdao.ChatGetDetailsByApplicationIDRow
+		d, err = queries.ChatGetDetailsByApplicationID(ctx, id)
+		title = "Application for " + d.JobTitle
+	case kindContract:
+		var d pgdao.ChatGetDetailsByContractIDRow
+		d, err = queries.ChatGetDetailsByContractID(ctx, id)
+		title = "Contract " + d.ContractTitle
+	case kindInvitation:
+		var d pgdao.ChatGetDetailsByInvitationIDRow
+		d, err = queries.ChatGetDetailsByInvitationID(ctx, id)
+		title = "Invitation to " + d.JobTitle
+	default:
+		return "Chat"
+	}
+
+	if err != nil {
+		clog.Ctx(ctx).Warn().Err(err).Str("chat-topic", topic).Msg("Failed to get information about chat.")
+		return "Chat"
+	}
+
+	return title
+}
+
+// digestBody formats plain text of the digest
+// Contract status changes are listed separately from conversations.
+// The link to the unsubscribe page is omitted, if the page is empty.
+func (s *EmailDigestSvc) digestBody(name string, chats []*digestChat, unsubscribePage string) string {
+	var (
+		conversations = new(strings.Builder)
+		statuses      = new(strings.Builder)
+	)
+
+	for _, c := range chats {
+		var userMessages []pgdao.MessagesUnreadForDigestRow
+
+		for _, m := range c.messages {
+			// other system messages are listed along with the conversation they belong to
+			if m.Kind == model.MessageSystem && strings.HasPrefix(m.Topic, "urn:"+kindContract+":") {
+				fmt.Fprintf(statuses, "  %s: %s\n", c.title, m.Text)
+				continue
+			}
+			userMessages = append(userMessages, m)
+		}
+
+		if len(userMessages) == 0 {
+			continue
+		}
+
+		conversations.WriteString("\n" + c.title)
+		if s.webURL != "" {
+			conversations.WriteString(" " + s.webURL + "/chats/" + c.id)
+		}
+		conversations.WriteString("\n")
+
+		// the latest messages are the most relevant
+		shown := userMessages
+		if len(shown) > digestMessagesPerChat {
+			shown = shown[len(shown)-digestMessagesPerChat:]
+			fmt.Fprintf(conversations, "  ... and %d earlier messages\n", len(userMessages)-digestMessagesPerChat)
+		}
+
+		for _, m := range shown {
+			fmt.Fprintf(conversations, "  %s, %s: %s\n", m.DisplayName, m.CreatedAt.Format("Jan 2 15:04"), digestText(m.Text))
+		}
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "Hello, %s!\n", name)
+
+	if conversations.Len() > 0 {
+		b.WriteString("\nYou have unread messages:\n")
+		b.WriteString(conversations.String())
+	}
+
+	if statuses.Len() > 0 {
+		b.WriteString("\nYour contracts have been updated:\n")
+		b.WriteString(statuses.String())
+	}
+
+	b.WriteString("\n--\nYou receive this email because of unread messages in your chats. ")
+	if unsubscribePage != "" {
+		b.WriteString("Change the frequency of digests in your profile or unsubscribe: " + unsubscribePage + "\n")
+	} else {
+		b.WriteString("Change the frequency of digests in your profile.\n")
+	}
+
+	return b.String()
+}
+
+// digestText makes a single line of the message text limited by digestTextMaxLen
+func digestText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return "[attachment]"
+	}
+
+	if utf8.RuneCountInString(text) <= digestTextMaxLen {
+		return text
+	}
+
+	return string([]rune(text)[:digestTextMaxLen]) + "..."
+}
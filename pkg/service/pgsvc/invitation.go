@@ -0,0 +1,377 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// InvitationSvc is a job invitation service
+	InvitationSvc struct {
+		db       *sql.DB
+		connects *model.ConnectsPolicy
+	}
+)
+
+// NewInvitation creates service
+// Accepted invitations cost connects like applications according to the policy.
+func NewInvitation(db *sql.DB, connects *model.ConnectsPolicy) *InvitationSvc {
+	return &InvitationSvc{
+		db:       db,
+		connects: connects,
+	}
+}
+
+// Add implements service.Invitation interface
+func (s *InvitationSvc) Add(ctx context.Context, customerID string, dto *model.CreateInvitationDTO) (*model.InvitationDTO, error) {
+	var result *model.InvitationDTO
+
+	personID := strings.TrimSpace(dto.PersonID)
+	login := strings.ToLower(strings.TrimSpace(dto.Login))
+
+	if personID == "" && login == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("person_id or login"),
+		}
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, dto.JobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", dto.JobID, err)
+		}
+
+		customer, err := queries.PersonGet(ctx, customerID)
+		if err != nil {
+			return model.ErrInsufficientRights
+		}
+
+		if customer.ID != job.CreatedBy {
+			return model.ErrInsufficientRights
+		}
+
+		if job.Status != model.JobStatusOpen {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: "job does not accept new applications",
+			}
+		}
+
+		var invitee pgdao.Person
+		if personID != "" {
+			invitee, err = queries.PersonGet(ctx, personID)
+		} else {
+			invitee, err = queries.PersonGetByLogin(ctx, pgdao.PersonGetByLoginParams{
+				Login: login,
+				Realm: model.InhouseRealm,
+			})
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.BackendError{
+				Cause:   model.ErrEntityNotFound,
+				Message: "person does not exist",
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to get invited person: %w", err)
+		}
+
+		if invitee.ID == customer.ID {
+			return model.ErrInappropriateAction
+		}
+
+		blocked, err := queries.PersonBlocked(ctx, pgdao.PersonBlockedParams{
+			PersonID:  invitee.ID,
+			BlockedID: customer.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to PersonBlocked: %w", err)
+		}
+
+		if blocked {
+			return fmt.Errorf("%w: the customer is blocked by the person", model.ErrInsufficientRights)
+		}
+
+		_, err = queries.ApplicationFindByJobAndApplicant(ctx, pgdao.ApplicationFindByJobAndApplicantParams{
+			JobID:       job.ID,
+			ApplicantID: invitee.ID,
+		})
+
+		if err == nil {
+			return &model.BackendError{
+				Cause:   model.ErrApplicationAlreadyExists,
+				Message: "person has already applied for this job",
+			}
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unable to ApplicationFindByJobAndApplicant: %w", err)
+		}
+
+		invitation, err := queries.InvitationAdd(ctx, pgdao.InvitationAddParams{
+			ID:        pgdao.NewID(),
+			JobID:     job.ID,
+			PersonID:  invitee.ID,
+			CreatedBy: customer.ID,
+			Message:   strings.TrimSpace(dto.Message),
+		})
+
+		if pqe, ok := err.(*pq.Error); ok { //nolint: errorlint
+			if pqe.Code == "23505" {
+				return &model.BackendError{
+					Cause:   model.ErrDuplication,
+					Message: "person is already invited",
+				}
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to InvitationAdd: %w", err)
+		}
+
+		text := invitation.Message
+		if text == "" {
+			text = "You are invited to apply for the job: " + job.Title
+		}
+
+		topic := newChatTopicInvitation(invitation.ID)
+
+		if _, e := newChat(ctx, queries, topic, text, customer.ID, invitee.ID); e != nil {
+			return fmt.Errorf("unable to create chat for invitation: %w", e)
+		}
+
+		if e := notifyPerson(ctx, queries, invitee.ID, model.NotificationJobInvitation,
+			job.CustomerDisplayName+" invited you to apply for the job: "+job.Title, topic); e != nil {
+			return e
+		}
+
+		result, err = invitationByID(ctx, queries, invitation.ID)
+		return err
+	})
+}
+
+// Get implements service.Invitation interface
+func (s *InvitationSvc) Get(ctx context.Context, id, actorID string) (*model.InvitationDTO, error) {
+	var result *model.InvitationDTO
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		i, err := invitationByID(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		if actorID != i.PersonID && actorID != i.CreatedBy {
+			return model.ErrEntityNotFound
+		}
+
+		result = i
+		return nil
+	})
+}
+
+// ListByJob implements service.Invitation interface
+func (s *InvitationSvc) ListByJob(ctx context.Context, jobID, actorID string) ([]*model.InvitationDTO, error) {
+	result := make([]*model.InvitationDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, jobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", jobID, err)
+		}
+
+		if actorID != job.CreatedBy {
+			return model.ErrInsufficientRights
+		}
+
+		ii, err := queries.InvitationsListByJob(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to InvitationsListByJob: %w", err)
+		}
+
+		for _, i := range ii {
+			result = append(result, &model.InvitationDTO{
+				ID:                  i.ID,
+				JobID:               i.JobID,
+				JobTitle:            i.JobTitle,
+				PersonID:            i.PersonID,
+				PersonDisplayName:   i.PersonDisplayName,
+				CreatedBy:           i.CreatedBy,
+				CustomerDisplayName: i.CustomerDisplayName,
+				Message:             i.Message,
+				Status:              i.Status,
+				ApplicationID:       i.ApplicationID.String,
+				CreatedAt:           i.CreatedAt,
+				RespondedAt:         nullTimeToPtr(i.RespondedAt),
+			})
+		}
+
+		return nil
+	})
+}
+
+// ListByPerson implements service.Invitation interface
+func (s *InvitationSvc) ListByPerson(ctx context.Context, personID string) ([]*model.InvitationDTO, error) {
+	result := make([]*model.InvitationDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		ii, err := queries.InvitationsListByPerson(ctx, personID)
+		if err != nil {
+			return fmt.Errorf("unable to InvitationsListByPerson: %w", err)
+		}
+
+		for _, i := range ii {
+			result = append(result, &model.InvitationDTO{
+				ID:                  i.ID,
+				JobID:               i.JobID,
+				JobTitle:            i.JobTitle,
+				PersonID:            i.PersonID,
+				PersonDisplayName:   i.PersonDisplayName,
+				CreatedBy:           i.CreatedBy,
+				CustomerDisplayName: i.CustomerDisplayName,
+				Message:             i.Message,
+				Status:              i.Status,
+				ApplicationID:       i.ApplicationID.String,
+				CreatedAt:           i.CreatedAt,
+				RespondedAt:         nullTimeToPtr(i.RespondedAt),
+			})
+		}
+
+		return nil
+	})
+}
+
+// Accept implements service.Invitation interface
+func (s *InvitationSvc) Accept(ctx context.Context, id, actorID string, dto *model.AcceptInvitationDTO) (*model.InvitationDTO, error) {
+	var result *model.InvitationDTO
+
+	if err := validateApplicationParams(dto.Comment, dto.Price); err != nil {
+		return nil, err
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		i, err := pendingInvitationFor(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		job, err := queries.JobGet(ctx, i.JobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", i.JobID, err)
+		}
+
+		// the invitation becomes accepted here
+		if _, e := applyForJob(ctx, queries, s.connects, actorID, job, &model.CreateApplicationDTO{
+			JobID:         job.ID,
+			Comment:       dto.Comment,
+			Price:         dto.Price,
+			AttachmentIDs: dto.AttachmentIDs,
+			Answers:       dto.Answers,
+		}); e != nil {
+			return e
+		}
+
+		if e := notifyPerson(ctx, queries, i.CreatedBy, model.NotificationJobInvitationAccepted,
+			i.PersonDisplayName+" accepted your invitation to the job: "+i.JobTitle, newChatTopicInvitation(i.ID)); e != nil {
+			return e
+		}
+
+		result, err = invitationByID(ctx, queries, i.ID)
+		return err
+	})
+}
+
+// Decline implements service.Invitation interface
+func (s *InvitationSvc) Decline(ctx context.Context, id, actorID string) (*model.InvitationDTO, error) {
+	var result *model.InvitationDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		i, err := pendingInvitationFor(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		if _, e := queries.InvitationRespond(ctx, pgdao.InvitationRespondParams{
+			Status: model.InvitationDeclined,
+			ID:     i.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to InvitationRespond with id=%s: %w", i.ID, e)
+		}
+
+		if e := notifyPerson(ctx, queries, i.CreatedBy, model.NotificationJobInvitationDeclined,
+			i.PersonDisplayName+" declined your invitation to the job: "+i.JobTitle, newChatTopicInvitation(i.ID)); e != nil {
+			return e
+		}
+
+		result, err = invitationByID(ctx, queries, i.ID)
+		return err
+	})
+}
+
+// pendingInvitationFor returns the invitation only if it is addressed to the actor and is not responded yet
+func pendingInvitationFor(ctx context.Context, queries *pgdao.Queries, id, actorID string) (*model.InvitationDTO, error) {
+	i, err := invitationByID(ctx, queries, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.PersonID != actorID {
+		if i.CreatedBy == actorID {
+			return nil, model.ErrInsufficientRights
+		}
+		return nil, model.ErrEntityNotFound
+	}
+
+	if i.Status != model.InvitationPending {
+		return nil, fmt.Errorf("%w: invitation is already %s", model.ErrInappropriateAction, i.Status)
+	}
+
+	return i, nil
+}
+
+func invitationByID(ctx context.Context, queries *pgdao.Queries, id string) (*model.InvitationDTO, error) {
+	i, err := queries.InvitationGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to InvitationGet with id=%s: %w", id, err)
+	}
+
+	return &model.InvitationDTO{
+		ID:                  i.ID,
+		JobID:               i.JobID,
+		JobTitle:            i.JobTitle,
+		PersonID:            i.PersonID,
+		PersonDisplayName:   i.PersonDisplayName,
+		CreatedBy:           i.CreatedBy,
+		CustomerDisplayName: i.CustomerDisplayName,
+		Message:             i.Message,
+		Status:              i.Status,
+		ApplicationID:       i.ApplicationID.String,
+		CreatedAt:           i.CreatedAt,
+		RespondedAt:         nullTimeToPtr(i.RespondedAt),
+	}, nil
+}
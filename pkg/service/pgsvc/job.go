@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"optrispace.com/work/pkg/db/pgdao"
@@ -56,6 +58,43 @@ func (s *JobSvc) Add(ctx context.Context, customerID string, dto *model.CreateJo
 		}
 	}
 
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustBeInFuture("expires_at"),
+		}
+	}
+
+	if dto.PublishAt != nil && !dto.PublishAt.After(time.Now()) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustBeInFuture("publish_at"),
+		}
+	}
+
+	if dto.PublishAt != nil && dto.ExpiresAt != nil && !dto.ExpiresAt.After(*dto.PublishAt) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "job expires before publication",
+		}
+	}
+
+	if err := validateJobQuestions(dto.Questions); err != nil {
+		return nil, err
+	}
+
+	visibility := strings.TrimSpace(dto.Visibility)
+	if visibility == "" {
+		visibility = model.JobVisibilityPublic
+	}
+
+	if visibility != model.JobVisibilityPublic && visibility != model.JobVisibilityPrivate {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("visibility"),
+		}
+	}
+
 	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
 		customer, err := queries.PersonGet(ctx, customerID)
 		if err != nil {
@@ -84,6 +123,7 @@ func (s *JobSvc) Add(ctx context.Context, customerID string, dto *model.CreateJo
 				Valid: dto.Duration > 0,
 			},
 			CreatedBy: customer.ID,
+			ExpiresAt: ptrToNullTime(dto.ExpiresAt),
 		}
 
 		newJob, err := queries.JobAdd(ctx, jobParams)
@@ -91,31 +131,67 @@ func (s *JobSvc) Add(ctx context.Context, customerID string, dto *model.CreateJo
 			return fmt.Errorf("unable to JobAdd job: %w", err)
 		}
 
-		budget := decimal.Zero
-		if newJob.Budget.Valid {
-			budget = decimal.RequireFromString(newJob.Budget.String)
+		if visibility != newJob.Visibility {
+			if e := queries.JobSetVisibility(ctx, pgdao.JobSetVisibilityParams{
+				Visibility: visibility,
+				ID:         newJob.ID,
+			}); e != nil {
+				return fmt.Errorf("unable to JobSetVisibility with id='%s': %w", newJob.ID, e)
+			}
+		}
+
+		if e := bindAttachments(ctx, queries, customer.ID, dto.AttachmentIDs, attachToJob, newJob.ID); e != nil {
+			return e
+		}
+
+		if _, e := setJobQuestions(ctx, queries, newJob.ID, dto.Questions); e != nil {
+			return e
+		}
+
+		if dto.Draft || dto.PublishAt != nil {
+			if e := queries.JobMakeDraft(ctx, pgdao.JobMakeDraftParams{
+				PublishAt: ptrToNullTime(dto.PublishAt),
+				ID:        newJob.ID,
+			}); e != nil {
+				return fmt.Errorf("unable to JobMakeDraft with id='%s': %w", newJob.ID, e)
+			}
+		}
+
+		if e := addJobRevision(ctx, queries, newJob); e != nil {
+			return e
 		}
 
 		result = &model.JobDTO{
 			ID:          newJob.ID,
 			Title:       newJob.Title,
 			Description: newJob.Description,
-			Budget:      budget,
+			Budget:      nullStringToDecimal(newJob.Budget),
 			Duration:    newJob.Duration.Int32,
 			CreatedAt:   newJob.CreatedAt,
 			UpdatedAt:   newJob.UpdatedAt,
 			CreatedBy:   newJob.CreatedBy,
+			ExpiresAt:   nullTimeToPtr(newJob.ExpiresAt),
 		}
 		return nil
 	})
 }
 
 // Get implements service.Job interface
-func (s *JobSvc) Get(ctx context.Context, id string) (*model.JobCardDTO, error) {
+func (s *JobSvc) Get(ctx context.Context, id, actorID string) (*model.JobCardDTO, error) {
 	var result *model.JobCardDTO
 	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
 		o, err := queries.JobGet(ctx, id)
 
+		if errors.Is(err, sql.ErrNoRows) && actorID != "" {
+			// the owner sees the blocked job with its status
+			var b pgdao.JobGetBlockedRow
+			b, err = queries.JobGetBlocked(ctx, pgdao.JobGetBlockedParams{
+				ID:        id,
+				CreatedBy: actorID,
+			})
+			o = pgdao.JobGetRow(b)
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.ErrEntityNotFound
 		}
@@ -124,34 +200,125 @@ func (s *JobSvc) Get(ctx context.Context, id string) (*model.JobCardDTO, error)
 			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
 		}
 
-		budget := decimal.Zero
-		if o.Budget.Valid {
-			budget = decimal.RequireFromString(o.Budget.String)
+		if e := checkJobVisibleFor(ctx, queries, o, actorID); e != nil {
+			return e
 		}
 
-		result = &model.JobCardDTO{}
-		result.ID = o.ID
-		result.Title = o.Title
-		result.Description = o.Description
-		result.Budget = budget
-		result.Duration = o.Duration.Int32
-		result.CreatedAt = o.CreatedAt
-		result.CreatedBy = o.CreatedBy
-		result.UpdatedAt = o.UpdatedAt
-		result.ApplicationsCount = uint(o.ApplicationCount)
-		result.CustomerDisplayName = o.CustomerDisplayName
-		result.CustomerEthereumAddress = o.CustomerEthereumAddress
-		result.IsSuspended = o.SuspendedAt.Valid
+		result = jobCardFromDB(o)
+
+		result.Questions, err = listJobQuestions(ctx, queries, o.ID)
+		if err != nil {
+			return err
+		}
+
+		if actorID == o.CreatedBy {
+			cc, err := queries.ApplicationStatusCountsByJob(ctx, o.ID)
+			if err != nil {
+				return fmt.Errorf("unable to ApplicationStatusCountsByJob with id='%s': %w", o.ID, err)
+			}
+
+			result.ApplicationStatusCounts = make(map[string]int64, len(cc))
+			for _, c := range cc {
+				result.ApplicationStatusCounts[c.Status] = c.Count
+			}
+		}
+
+		return nil
+	})
+}
+
+func jobCardFromDB(o pgdao.JobGetRow) *model.JobCardDTO {
+	budget := decimal.Zero
+	if o.Budget.Valid {
+		budget = decimal.RequireFromString(o.Budget.String)
+	}
+
+	result := &model.JobCardDTO{}
+	result.ID = o.ID
+	result.Title = o.Title
+	result.Description = o.Description
+	result.Budget = budget
+	result.Duration = o.Duration.Int32
+	result.CreatedAt = o.CreatedAt
+	result.CreatedBy = o.CreatedBy
+	result.UpdatedAt = o.UpdatedAt
+	result.ApplicationsCount = uint(o.ApplicationCount)
+	result.CustomerDisplayName = o.CustomerDisplayName
+	result.CustomerEthereumAddress = o.CustomerEthereumAddress
+	result.ExpiresAt = nullTimeToPtr(o.ExpiresAt)
+	result.IsSuspended = o.SuspendedAt.Valid
+	result.Visibility = o.Visibility
+	result.Status = o.Status
+	result.ClosedAt = nullTimeToPtr(o.ClosedAt)
+	result.PublishedAt = nullTimeToPtr(o.PublishedAt)
+	result.PublishAt = nullTimeToPtr(o.PublishAt)
+
+	return result
+}
 
+// checkJobVisibleFor returns model.ErrEntityNotFound if the draft is requested by someone who is not its owner
+// or the private job is requested by someone who is neither its owner nor invited to it
+func checkJobVisibleFor(ctx context.Context, queries *pgdao.Queries, job pgdao.JobGetRow, actorID string) error {
+	if actorID != "" && actorID == job.CreatedBy {
 		return nil
+	}
+
+	if !job.PublishedAt.Valid {
+		return model.ErrEntityNotFound
+	}
+
+	if job.Visibility != model.JobVisibilityPrivate {
+		return nil
+	}
+
+	_, err := queries.InvitationFindByJobAndPerson(ctx, pgdao.InvitationFindByJobAndPersonParams{
+		JobID:    job.ID,
+		PersonID: actorID,
 	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to InvitationFindByJobAndPerson: %w", err)
+	}
+
+	return nil
 }
 
 // List implements service.Job interface
-func (s *JobSvc) List(ctx context.Context) ([]*model.JobDTO, error) {
+func (s *JobSvc) List(ctx context.Context, filter *model.JobFilterDTO) ([]*model.JobDTO, error) {
 	result := make([]*model.JobDTO, 0)
+
+	if filter.BudgetMin.IsNegative() {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("budget_min"),
+		}
+	}
+
+	if filter.BudgetMax.IsNegative() {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("budget_max"),
+		}
+	}
+
+	if filter.Limit < 0 {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("limit"),
+		}
+	}
+
 	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
-		oo, err := queries.JobsList(ctx)
+		oo, err := queries.JobsList(ctx, pgdao.JobsListParams{
+			Keyword:   strings.TrimSpace(filter.Keyword),
+			BudgetMin: filter.BudgetMin.String(),
+			BudgetMax: filter.BudgetMax.String(),
+			Lim:       int32(filter.Limit),
+		})
 		if err != nil {
 			return fmt.Errorf("unable to JobsList job: %w", err)
 		}
@@ -168,10 +335,12 @@ func (s *JobSvc) List(ctx context.Context) ([]*model.JobDTO, error) {
 				Description:             o.Description,
 				Budget:                  budget,
 				CreatedAt:               o.CreatedAt,
+				UpdatedAt:               o.UpdatedAt,
 				CreatedBy:               o.CreatedBy,
 				ApplicationsCount:       uint(o.ApplicationCount),
 				CustomerDisplayName:     o.CustomerDisplayName,
 				CustomerEthereumAddress: o.CustomerEthereumAddress,
+				ExpiresAt:               nullTimeToPtr(o.ExpiresAt),
 			})
 		}
 
@@ -179,29 +348,49 @@ func (s *JobSvc) List(ctx context.Context) ([]*model.JobDTO, error) {
 	})
 }
 
-// Block implements service.Job interface
-func (s *JobSvc) Block(ctx context.Context, id, actorID string) error {
-	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
-		job, err := queries.JobGet(ctx, id)
-
-		if errors.Is(err, sql.ErrNoRows) {
-			return model.ErrEntityNotFound
+// ListByOwner implements service.Job interface
+func (s *JobSvc) ListByOwner(ctx context.Context, actorID string) ([]*model.JobCardDTO, error) {
+	result := make([]*model.JobCardDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.JobsListByOwner(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to JobsListByOwner: %w", err)
 		}
 
+		cc, err := queries.ApplicationStatusCountsByOwner(ctx, actorID)
 		if err != nil {
-			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
+			return fmt.Errorf("unable to ApplicationStatusCountsByOwner: %w", err)
 		}
 
-		person, err := queries.PersonGet(ctx, actorID)
-		if err != nil {
-			return model.ErrInsufficientRights
+		counts := make(map[string]map[string]int64)
+		for _, c := range cc {
+			if counts[c.JobID] == nil {
+				counts[c.JobID] = make(map[string]int64)
+			}
+			counts[c.JobID][c.Status] = c.Count
 		}
 
-		if !person.IsAdmin {
-			return model.ErrInsufficientRights
+		for _, o := range oo {
+			card := jobCardFromDB(pgdao.JobGetRow(o))
+			card.ApplicationStatusCounts = counts[o.ID]
+			result = append(result, card)
 		}
 
-		return queries.JobBlock(ctx, job.ID)
+		return nil
+	})
+}
+
+// Block implements service.Job interface
+func (s *JobSvc) Block(ctx context.Context, id, actorID, reason string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		return setBlocked(ctx, queries, actorID, model.TargetJob, id, reason, true)
+	})
+}
+
+// Unblock implements service.Job interface
+func (s *JobSvc) Unblock(ctx context.Context, id, actorID, reason string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		return setBlocked(ctx, queries, actorID, model.TargetJob, id, reason, false)
 	})
 }
 
@@ -253,6 +442,13 @@ func (s *JobSvc) Resume(ctx context.Context, id, actorID string) error {
 			return model.ErrInsufficientRights
 		}
 
+		if job.Status == model.JobStatusExpired || job.Status == model.JobStatusClosed {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: "job is " + job.Status + ", reopen it instead",
+			}
+		}
+
 		if !job.SuspendedAt.Valid {
 			return &model.BackendError{
 				Cause:   model.ErrValidationFailed,
@@ -264,6 +460,153 @@ func (s *JobSvc) Resume(ctx context.Context, id, actorID string) error {
 	})
 }
 
+// Reopen implements service.Job interface
+func (s *JobSvc) Reopen(ctx context.Context, id, actorID string, dto *model.ReopenJobDTO) (*model.JobCardDTO, error) {
+	var result *model.JobCardDTO
+
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustBeInFuture("expires_at"),
+		}
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
+		}
+
+		if actorID != job.CreatedBy {
+			return model.ErrInsufficientRights
+		}
+
+		if job.Status != model.JobStatusExpired && job.Status != model.JobStatusClosed {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: "job is not closed or expired",
+			}
+		}
+
+		if e := queries.JobReopen(ctx, pgdao.JobReopenParams{
+			ExpiresAt: ptrToNullTime(dto.ExpiresAt),
+			ID:        job.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to JobReopen with id='%s': %w", job.ID, e)
+		}
+
+		o, err := queries.JobGet(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", job.ID, err)
+		}
+
+		result = jobCardFromDB(o)
+		return nil
+	})
+}
+
+// SuspendExpired implements service.Job interface
+func (s *JobSvc) SuspendExpired(ctx context.Context) (int, error) {
+	var result int
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		jj, err := queries.JobsSuspendExpired(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to JobsSuspendExpired: %w", err)
+		}
+
+		for _, j := range jj {
+			if e := notifyPerson(ctx, queries, j.CreatedBy, model.NotificationJobExpired,
+				"Your job has expired and does not accept new applications: "+j.Title, path.Join("/", "jobs", j.ID)); e != nil {
+				return e
+			}
+		}
+
+		result = len(jj)
+		return nil
+	})
+}
+
+// Publish implements service.Job interface
+func (s *JobSvc) Publish(ctx context.Context, id, actorID string, dto *model.PublishJobDTO) (*model.JobCardDTO, error) {
+	var result *model.JobCardDTO
+
+	publishAt := time.Now()
+	if dto.PublishAt != nil && dto.PublishAt.After(publishAt) {
+		publishAt = *dto.PublishAt
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
+		}
+
+		if actorID != job.CreatedBy {
+			return model.ErrEntityNotFound
+		}
+
+		if job.PublishedAt.Valid {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: "job is already published",
+			}
+		}
+
+		if job.ExpiresAt.Valid && !job.ExpiresAt.Time.After(publishAt) {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: "job expires before publication",
+			}
+		}
+
+		if e := queries.JobPublish(ctx, pgdao.JobPublishParams{
+			PublishAt: publishAt.UTC(),
+			ID:        job.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to JobPublish with id='%s': %w", job.ID, e)
+		}
+
+		o, err := queries.JobGet(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", job.ID, err)
+		}
+
+		result = jobCardFromDB(o)
+		return nil
+	})
+}
+
+// PublishScheduled implements service.Job interface
+func (s *JobSvc) PublishScheduled(ctx context.Context) (int, error) {
+	var result int
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		jj, err := queries.JobsPublishScheduled(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to JobsPublishScheduled: %w", err)
+		}
+
+		for _, j := range jj {
+			if e := notifyPerson(ctx, queries, j.CreatedBy, model.NotificationJobPublished,
+				"Your job has been published: "+j.Title, path.Join("/", "jobs", j.ID)); e != nil {
+				return e
+			}
+		}
+
+		result = len(jj)
+		return nil
+	})
+}
+
 // Patch implements service.Job interface
 func (s *JobSvc) Patch(ctx context.Context, id, actorID string, dto *model.UpdateJobDTO) (*model.JobDTO, error) {
 	var result *model.JobDTO
@@ -296,6 +639,20 @@ func (s *JobSvc) Patch(ctx context.Context, id, actorID string, dto *model.Updat
 		}
 	}
 
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustBeInFuture("expires_at"),
+		}
+	}
+
+	if dto.ExpiresAt != nil && dto.ClearExpiresAt {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "expires_at and clear_expires_at are mutually exclusive",
+		}
+	}
+
 	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
 		job, err := queries.JobGet(ctx, id)
 
@@ -331,9 +688,15 @@ func (s *JobSvc) Patch(ctx context.Context, id, actorID string, dto *model.Updat
 			Description: strings.TrimSpace(dto.Description),
 			Budget:      dto.Budget.String(),
 			Duration:    dto.Duration,
+
+			ClearExpiresAt: dto.ClearExpiresAt,
+		}
+
+		if dto.ExpiresAt != nil {
+			params.ExpiresAtChange, params.ExpiresAt = true, dto.ExpiresAt.UTC()
 		}
 
-		_, err = queries.JobPatch(ctx, *params)
+		patchedJob, err := queries.JobPatch(ctx, *params)
 
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.ErrEntityNotFound
@@ -343,6 +706,16 @@ func (s *JobSvc) Patch(ctx context.Context, id, actorID string, dto *model.Updat
 			return fmt.Errorf("unable to JobPatch with id='%s': %w", job.ID, err)
 		}
 
+		if jobTermsChanged(job, patchedJob) {
+			if e := addJobRevision(ctx, queries, patchedJob); e != nil {
+				return e
+			}
+		}
+
+		if e := notifyApplicantsJobChanged(ctx, queries, job, patchedJob); e != nil {
+			return e
+		}
+
 		updatedJob, err := queries.JobGet(ctx, job.ID)
 		if err != nil {
 			return fmt.Errorf("unable to JobGet with id='%s': %w", job.ID, err)
@@ -360,8 +733,132 @@ func (s *JobSvc) Patch(ctx context.Context, id, actorID string, dto *model.Updat
 			ApplicationsCount:       uint(updatedJob.ApplicationCount),
 			CustomerDisplayName:     updatedJob.CustomerDisplayName,
 			CustomerEthereumAddress: updatedJob.CustomerEthereumAddress,
+			ExpiresAt:               nullTimeToPtr(updatedJob.ExpiresAt),
+		}
+
+		return nil
+	})
+}
+
+// ListRevisions implements service.Job interface
+func (s *JobSvc) ListRevisions(ctx context.Context, id, actorID string) ([]*model.JobRevisionDTO, error) {
+	result := make([]*model.JobRevisionDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
+		}
+
+		if e := checkJobVisibleFor(ctx, queries, job, actorID); e != nil {
+			return e
+		}
+
+		rr, err := queries.JobRevisionsListByJob(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to JobRevisionsListByJob: %w", err)
+		}
+
+		for _, r := range rr {
+			result = append(result, &model.JobRevisionDTO{
+				ID:                   r.ID,
+				JobID:                r.JobID,
+				Version:              r.Version,
+				Title:                r.Title,
+				Description:          r.Description,
+				Budget:               nullStringToDecimal(r.Budget),
+				Duration:             r.Duration.Int32,
+				ExpiresAt:            nullTimeToPtr(r.ExpiresAt),
+				CreatedBy:            r.CreatedBy,
+				CreatedByDisplayName: r.CreatedByDisplayName,
+				CreatedAt:            r.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+}
+
+// addJobRevision saves the current terms of the job as its next revision
+func addJobRevision(ctx context.Context, queries *pgdao.Queries, job pgdao.Job) error {
+	if _, err := queries.JobRevisionAdd(ctx, pgdao.JobRevisionAddParams{
+		ID:          pgdao.NewID(),
+		JobID:       job.ID,
+		Title:       job.Title,
+		Description: job.Description,
+		Budget:      job.Budget,
+		Duration:    job.Duration,
+		ExpiresAt:   job.ExpiresAt,
+		CreatedBy:   job.CreatedBy,
+	}); err != nil {
+		return fmt.Errorf("unable to JobRevisionAdd for job id='%s': %w", job.ID, err)
+	}
+
+	return nil
+}
+
+// jobTermsChanged returns true if any of the terms kept in revisions differs
+func jobTermsChanged(before pgdao.JobGetRow, after pgdao.Job) bool {
+	return before.Title != after.Title ||
+		before.Description != after.Description ||
+		!nullStringToDecimal(before.Budget).Equal(nullStringToDecimal(after.Budget)) ||
+		before.Duration.Int32 != after.Duration.Int32 ||
+		before.ExpiresAt.Valid != after.ExpiresAt.Valid ||
+		!before.ExpiresAt.Time.Equal(after.ExpiresAt.Time)
+}
+
+// notifyApplicantsJobChanged posts a system message into every application chat of the job
+// if its budget or description was changed, so applicants learn that the terms are not the same as they applied for
+func notifyApplicantsJobChanged(ctx context.Context, queries *pgdao.Queries, before pgdao.JobGetRow, after pgdao.Job) error {
+	if before.ApplicationCount == 0 {
+		return nil
+	}
+
+	var (
+		changes = make([]string, 0, 2)
+		payload = &model.SystemMessagePayload{
+			Event: model.SystemEventJobChanged,
+			JobID: after.ID,
 		}
+	)
+
+	oldBudget, newBudget := nullStringToDecimal(before.Budget), nullStringToDecimal(after.Budget)
+	if !oldBudget.Equal(newBudget) {
+		changes = append(changes, "budget has been changed from "+oldBudget.String()+" to "+newBudget.String())
+		payload.Changes = append(payload.Changes, "budget")
+	}
 
+	if before.Description != after.Description {
+		changes = append(changes, "description has been changed")
+		payload.Changes = append(payload.Changes, "description")
+	}
+
+	if len(changes) == 0 {
 		return nil
+	}
+
+	text := "The job terms have been changed: " + strings.Join(changes, ", ")
+
+	aa, err := queries.ApplicationsGetByJob(ctx, pgdao.ApplicationsGetByJobParams{
+		JobID: after.ID,
 	})
+	if err != nil {
+		return fmt.Errorf("unable to ApplicationsGetByJob: %w", err)
+	}
+
+	for _, a := range aa {
+		if a.WithdrawnAt.Valid {
+			continue
+		}
+
+		if e := addApplicationSystemMessage(ctx, queries, a.ID, after.CreatedBy, a.ApplicantID, text, payload); e != nil {
+			return e
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,340 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+// validateJobQuestions checks screening questions of the job
+func validateJobQuestions(qq []*model.JobQuestionParamsDTO) error {
+	for i, q := range qq {
+		field := fmt.Sprintf("questions[%d]", i)
+
+		if q == nil {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorRequired(field),
+			}
+		}
+
+		if strings.TrimSpace(q.Text) == "" {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorRequired(field + ".text"),
+			}
+		}
+
+		switch q.Kind {
+		case model.QuestionText:
+			if len(q.Options) > 0 {
+				return &model.BackendError{
+					Cause:   model.ErrValidationFailed,
+					Message: fmt.Sprintf("%s.options are allowed for choice questions only", field),
+				}
+			}
+
+		case model.QuestionSingleChoice, model.QuestionMultiChoice:
+			if len(q.Options) < 2 {
+				return &model.BackendError{
+					Cause:   model.ErrValidationFailed,
+					Message: fmt.Sprintf("%s.options must contain at least 2 options", field),
+				}
+			}
+
+			seen := make(map[string]bool, len(q.Options))
+			for _, o := range q.Options {
+				o = strings.TrimSpace(o)
+				if o == "" || seen[o] {
+					return &model.BackendError{
+						Cause:   model.ErrValidationFailed,
+						Message: model.ValidationErrorInvalidFormat(field + ".options"),
+					}
+				}
+				seen[o] = true
+			}
+
+		default:
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorInvalidFormat(field + ".kind"),
+			}
+		}
+	}
+
+	return nil
+}
+
+// setJobQuestions replaces all screening questions of the job
+func setJobQuestions(ctx context.Context, queries *pgdao.Queries, jobID string, qq []*model.JobQuestionParamsDTO) ([]*model.JobQuestionDTO, error) {
+	if err := queries.JobQuestionsDeleteByJob(ctx, jobID); err != nil {
+		return nil, fmt.Errorf("unable to JobQuestionsDeleteByJob with id='%s': %w", jobID, err)
+	}
+
+	result := make([]*model.JobQuestionDTO, 0, len(qq))
+
+	for i, q := range qq {
+		options := make([]string, 0, len(q.Options))
+		for _, o := range q.Options {
+			options = append(options, strings.TrimSpace(o))
+		}
+
+		question, err := queries.JobQuestionAdd(ctx, pgdao.JobQuestionAddParams{
+			ID:       pgdao.NewID(),
+			JobID:    jobID,
+			Position: int32(i + 1),
+			Kind:     q.Kind,
+			Text:     strings.TrimSpace(q.Text),
+			Options:  options,
+			Required: q.Required,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to JobQuestionAdd for job %s: %w", jobID, err)
+		}
+
+		result = append(result, jobQuestionFromDB(question))
+	}
+
+	return result, nil
+}
+
+func jobQuestionFromDB(q pgdao.JobQuestion) *model.JobQuestionDTO {
+	return &model.JobQuestionDTO{
+		ID:       q.ID,
+		Position: q.Position,
+		Kind:     q.Kind,
+		Text:     q.Text,
+		Options:  q.Options,
+		Required: q.Required,
+	}
+}
+
+// listJobQuestions returns screening questions of the job in order of their positions
+func listJobQuestions(ctx context.Context, queries *pgdao.Queries, jobID string) ([]*model.JobQuestionDTO, error) {
+	qq, err := queries.JobQuestionsListByJob(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to JobQuestionsListByJob with id='%s': %w", jobID, err)
+	}
+
+	result := make([]*model.JobQuestionDTO, 0, len(qq))
+	for _, q := range qq {
+		result = append(result, jobQuestionFromDB(q))
+	}
+
+	return result, nil
+}
+
+// addApplicationAnswers validates answers of the applicant against screening questions of the job and saves them
+func addApplicationAnswers(ctx context.Context, queries *pgdao.Queries, jobID, applicationID string, answers []*model.AnswerParamsDTO) error {
+	qq, err := queries.JobQuestionsListByJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("unable to JobQuestionsListByJob with id='%s': %w", jobID, err)
+	}
+
+	known := make(map[string]bool, len(qq))
+	for _, q := range qq {
+		known[q.ID] = true
+	}
+
+	byQuestion := make(map[string]*model.AnswerParamsDTO, len(answers))
+	for i, a := range answers {
+		if a == nil || a.QuestionID == "" {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorRequired(fmt.Sprintf("answers[%d].question_id", i)),
+			}
+		}
+
+		if !known[a.QuestionID] {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: fmt.Sprintf("question %s does not belong to the job", a.QuestionID),
+			}
+		}
+
+		if _, ok := byQuestion[a.QuestionID]; ok {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: fmt.Sprintf("question %s is answered more than once", a.QuestionID),
+			}
+		}
+
+		byQuestion[a.QuestionID] = a
+	}
+
+	for _, q := range qq {
+		params := pgdao.ApplicationAnswerAddParams{
+			ApplicationID: applicationID,
+			QuestionID:    q.ID,
+			Options:       []string{},
+		}
+
+		if a, ok := byQuestion[q.ID]; ok {
+			params.Text = strings.TrimSpace(a.Text)
+			for _, o := range a.Options {
+				params.Options = append(params.Options, strings.TrimSpace(o))
+			}
+		}
+
+		if e := validateAnswer(q, params.Text, params.Options); e != nil {
+			return e
+		}
+
+		if params.Text == "" && len(params.Options) == 0 {
+			continue
+		}
+
+		if e := queries.ApplicationAnswerAdd(ctx, params); e != nil {
+			return fmt.Errorf("unable to ApplicationAnswerAdd for application %s: %w", applicationID, e)
+		}
+	}
+
+	return nil
+}
+
+// validateAnswer checks the answer matches the kind of the question
+func validateAnswer(q pgdao.JobQuestion, text string, options []string) error {
+	field := fmt.Sprintf("answer to question %d", q.Position)
+
+	if text == "" && len(options) == 0 {
+		if q.Required {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorRequired(field),
+			}
+		}
+		return nil
+	}
+
+	if q.Kind == model.QuestionText {
+		if len(options) > 0 {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorInvalidFormat(field),
+			}
+		}
+		return nil
+	}
+
+	if text != "" || (q.Kind == model.QuestionSingleChoice && len(options) != 1) {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat(field),
+		}
+	}
+
+	allowed := make(map[string]bool, len(q.Options))
+	for _, o := range q.Options {
+		allowed[o] = true
+	}
+
+	for _, o := range options {
+		if !allowed[o] {
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: fmt.Sprintf("%s contains unknown option %q", field, o),
+			}
+		}
+		delete(allowed, o) // every option may be chosen once only
+	}
+
+	return nil
+}
+
+// SetQuestions implements service.Job interface
+func (s *JobSvc) SetQuestions(ctx context.Context, id, actorID string, qq []*model.JobQuestionParamsDTO) ([]*model.JobQuestionDTO, error) {
+	var result []*model.JobQuestionDTO
+
+	if err := validateJobQuestions(qq); err != nil {
+		return nil, err
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", id, err)
+		}
+
+		if actorID != job.CreatedBy {
+			return model.ErrInsufficientRights
+		}
+
+		count, err := queries.ApplicationsCountByJob(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("unable to ApplicationsCountByJob with id='%s': %w", job.ID, err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("%w: questions can not be changed after the job has got applications", model.ErrInappropriateAction)
+		}
+
+		result, err = setJobQuestions(ctx, queries, job.ID, qq)
+		return err
+	})
+}
+
+// groupAnswers groups answers by applications and questions
+func groupAnswers(aa []pgdao.ApplicationAnswersListByJobRow) map[string]map[string]pgdao.ApplicationAnswersListByJobRow {
+	result := make(map[string]map[string]pgdao.ApplicationAnswersListByJobRow)
+	for _, a := range aa {
+		if result[a.ApplicationID] == nil {
+			result[a.ApplicationID] = make(map[string]pgdao.ApplicationAnswersListByJobRow)
+		}
+		result[a.ApplicationID][a.QuestionID] = a
+	}
+
+	return result
+}
+
+// alignAnswers returns answers of the application for every question of the job in order of questions
+// Unanswered questions get empty answers, so applications can be compared side by side.
+func alignAnswers(questions []*model.JobQuestionDTO, byQuestion map[string]pgdao.ApplicationAnswersListByJobRow) []*model.AnswerDTO {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	result := make([]*model.AnswerDTO, 0, len(questions))
+	for _, q := range questions {
+		a := byQuestion[q.ID]
+		result = append(result, &model.AnswerDTO{
+			QuestionID:   q.ID,
+			QuestionText: q.Text,
+			Kind:         q.Kind,
+			Text:         a.Text,
+			Options:      a.Options,
+		})
+	}
+
+	return result
+}
+
+// listApplicationAnswers returns answers of the application in order of questions
+func listApplicationAnswers(ctx context.Context, queries *pgdao.Queries, applicationID string) ([]*model.AnswerDTO, error) {
+	aa, err := queries.ApplicationAnswersListByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to ApplicationAnswersListByApplication with id='%s': %w", applicationID, err)
+	}
+
+	result := make([]*model.AnswerDTO, 0, len(aa))
+	for _, a := range aa {
+		result = append(result, &model.AnswerDTO{
+			QuestionID:   a.QuestionID,
+			QuestionText: a.QuestionText,
+			Kind:         a.QuestionKind,
+			Text:         a.Text,
+			Options:      a.Options,
+		})
+	}
+
+	return result, nil
+}
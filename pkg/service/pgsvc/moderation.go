@@ -0,0 +1,611 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// ModerationSvc is a service for abuse reports and blocking
+	ModerationSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewModeration creates service
+func NewModeration(db *sql.DB) *ModerationSvc {
+	return &ModerationSvc{db: db}
+}
+
+// AddReport implements service.Moderation interface
+func (s *ModerationSvc) AddReport(ctx context.Context, actorID string, dto *model.CreateReportDTO) (*model.ReportDTO, error) {
+	var result *model.ReportDTO
+
+	targetID := strings.TrimSpace(dto.TargetID)
+	if targetID == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("target_id"),
+		}
+	}
+
+	switch dto.Reason {
+	case model.ReportReasonSpam, model.ReportReasonFraud, model.ReportReasonAbuse, model.ReportReasonOther:
+	case "":
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("reason"),
+		}
+	default:
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("reason"),
+		}
+	}
+
+	comment := strings.TrimSpace(dto.Comment)
+	if dto.Reason == model.ReportReasonOther && comment == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("comment"),
+		}
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		switch dto.TargetKind {
+		case model.TargetJob:
+			job, err := queries.JobGet(ctx, targetID)
+
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrEntityNotFound
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to JobGet with id='%s': %w", targetID, err)
+			}
+
+			if e := checkJobVisibleFor(ctx, queries, job, actorID); e != nil {
+				return e
+			}
+
+			if job.CreatedBy == actorID {
+				return model.ErrInappropriateAction
+			}
+
+		case model.TargetPerson:
+			person, err := queries.PersonGet(ctx, targetID)
+
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrEntityNotFound
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to PersonGet with id='%s': %w", targetID, err)
+			}
+
+			if person.ID == actorID {
+				return model.ErrInappropriateAction
+			}
+
+		case model.TargetMessage:
+			message, err := queries.MessageGet(ctx, targetID)
+
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrEntityNotFound
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to MessageGet with id='%s': %w", targetID, err)
+			}
+
+			// only participants of the chat know about the message
+			_, err = queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+				ChatID:   message.ChatID,
+				PersonID: actorID,
+			})
+
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrEntityNotFound
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to ChatParticipantGet: %w", err)
+			}
+
+			if message.CreatedBy == actorID {
+				return model.ErrInappropriateAction
+			}
+
+		default:
+			return &model.BackendError{
+				Cause:   model.ErrValidationFailed,
+				Message: model.ValidationErrorInvalidFormat("target_kind"),
+			}
+		}
+
+		report, err := queries.ReportAdd(ctx, pgdao.ReportAddParams{
+			ID:         pgdao.NewID(),
+			TargetKind: dto.TargetKind,
+			TargetID:   targetID,
+			Reason:     dto.Reason,
+			Comment:    comment,
+			CreatedBy:  actorID,
+		})
+
+		if pqe, ok := err.(*pq.Error); ok { //nolint: errorlint
+			if pqe.Code == "23505" {
+				return &model.BackendError{
+					Cause:   model.ErrDuplication,
+					Message: "it is already reported",
+				}
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ReportAdd: %w", err)
+		}
+
+		result, err = reportByID(ctx, queries, report.ID)
+		return err
+	})
+}
+
+// GetReport implements service.Moderation interface
+func (s *ModerationSvc) GetReport(ctx context.Context, id, actorID string) (*model.ReportDTO, error) {
+	var result *model.ReportDTO
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		var err error
+		result, err = reportByID(ctx, queries, id)
+		return err
+	})
+}
+
+// ListReports implements service.Moderation interface
+func (s *ModerationSvc) ListReports(ctx context.Context, actorID, status string) ([]*model.ReportDTO, error) {
+	switch status {
+	case "", model.ReportOpen, model.ReportResolved, model.ReportDismissed:
+	default:
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorInvalidFormat("status"),
+		}
+	}
+
+	result := make([]*model.ReportDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		rr, err := queries.ReportsList(ctx, status)
+		if err != nil {
+			return fmt.Errorf("unable to ReportsList: %w", err)
+		}
+
+		for _, r := range rr {
+			result = append(result, reportFromDB(pgdao.ReportGetRow(r)))
+		}
+
+		return nil
+	})
+}
+
+// ResolveReport implements service.Moderation interface
+func (s *ModerationSvc) ResolveReport(ctx context.Context, id, actorID string, dto *model.ResolveReportDTO) (*model.ReportDTO, error) {
+	var result *model.ReportDTO
+
+	resolution := strings.TrimSpace(dto.Resolution)
+	if resolution == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("resolution"),
+		}
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		r, err := openReportFor(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		if e := queries.ReportResolve(ctx, pgdao.ReportResolveParams{
+			Status:     model.ReportResolved,
+			ResolvedBy: actorID,
+			Resolution: resolution,
+			ID:         r.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to ReportResolve with id=%s: %w", r.ID, e)
+		}
+
+		if dto.Block {
+			kind, targetID := r.TargetKind, r.TargetID
+
+			// the author of the reported message is blocked
+			if kind == model.TargetMessage {
+				message, err := queries.MessageGet(ctx, targetID)
+				if err != nil {
+					return fmt.Errorf("unable to MessageGet with id='%s': %w", targetID, err)
+				}
+				kind, targetID = model.TargetPerson, message.CreatedBy
+			}
+
+			// the target might be blocked already on another report, it is fine
+			e := setBlocked(ctx, queries, actorID, kind, targetID, resolution, true)
+			if e != nil && !errors.Is(e, model.ErrInappropriateAction) && !errors.Is(e, model.ErrEntityNotFound) {
+				return e
+			}
+
+			// other complaints about the same target are resolved as well
+			if _, e := queries.ReportsResolveByTarget(ctx, pgdao.ReportsResolveByTargetParams{
+				ResolvedBy: actorID,
+				Resolution: resolution,
+				TargetKind: r.TargetKind,
+				TargetID:   r.TargetID,
+			}); e != nil {
+				return fmt.Errorf("unable to ReportsResolveByTarget: %w", e)
+			}
+		}
+
+		result, err = reportByID(ctx, queries, r.ID)
+		return err
+	})
+}
+
+// DismissReport implements service.Moderation interface
+func (s *ModerationSvc) DismissReport(ctx context.Context, id, actorID, resolution string) (*model.ReportDTO, error) {
+	var result *model.ReportDTO
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		r, err := openReportFor(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		if e := queries.ReportResolve(ctx, pgdao.ReportResolveParams{
+			Status:     model.ReportDismissed,
+			ResolvedBy: actorID,
+			Resolution: strings.TrimSpace(resolution),
+			ID:         r.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to ReportResolve with id=%s: %w", r.ID, e)
+		}
+
+		result, err = reportByID(ctx, queries, r.ID)
+		return err
+	})
+}
+
+// BlockPerson implements service.Moderation interface
+func (s *ModerationSvc) BlockPerson(ctx context.Context, id, actorID, reason string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		return setBlocked(ctx, queries, actorID, model.TargetPerson, id, reason, true)
+	})
+}
+
+// UnblockPerson implements service.Moderation interface
+func (s *ModerationSvc) UnblockPerson(ctx context.Context, id, actorID, reason string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		return setBlocked(ctx, queries, actorID, model.TargetPerson, id, reason, false)
+	})
+}
+
+// ListMessageRevisions implements service.Moderation interface
+func (s *ModerationSvc) ListMessageRevisions(ctx context.Context, id, actorID string) ([]*model.MessageRevisionDTO, error) {
+	result := make([]*model.MessageRevisionDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		message, err := queries.MessageGet(ctx, id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to MessageGet with id='%s': %w", id, err)
+		}
+
+		rr, err := queries.MessageRevisionsListByMessage(ctx, message.ID)
+		if err != nil {
+			return fmt.Errorf("unable to MessageRevisionsListByMessage with id='%s': %w", message.ID, err)
+		}
+
+		for _, r := range rr {
+			result = append(result, &model.MessageRevisionDTO{
+				ID:        r.ID,
+				MessageID: r.MessageID,
+				Version:   r.Version,
+				Text:      r.Text,
+				CreatedAt: r.CreatedAt,
+			})
+		}
+
+		// private conversations are revealed to the admin
+		return addAudit(ctx, queries, actorID, model.AuditViewMessageHistory, model.TargetMessage, message.ID, "")
+	})
+}
+
+// JoinContractChat implements service.Moderation interface
+func (s *ModerationSvc) JoinContractChat(ctx context.Context, id, actorID, reason string) (*model.Chat, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("reason"),
+		}
+	}
+
+	var result *model.Chat
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		contract, err := queries.ContractGet(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ContractGet with id='%s': %w", id, err)
+		}
+
+		chat, err := contractChat(ctx, queries, contract)
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.ChatParticipantGet(ctx, pgdao.ChatParticipantGetParams{
+			ChatID:   chat.ID,
+			PersonID: actorID,
+		})
+		if err == nil {
+			return fmt.Errorf("%w: already a participant of the chat", model.ErrInappropriateAction)
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unable to ChatParticipantGet: %w", err)
+		}
+
+		if _, e := queries.ChatParticipantAdd(ctx, pgdao.ChatParticipantAddParams{
+			ChatID:   chat.ID,
+			PersonID: actorID,
+		}); e != nil {
+			return fmt.Errorf("unable to ChatParticipantAdd: %w", e)
+		}
+
+		admin, err := queries.PersonGet(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id='%s': %w", actorID, err)
+		}
+
+		name := admin.DisplayName
+		if name == "" {
+			name = admin.Login
+		}
+
+		if e := addSystemMessage(ctx, queries, chat.ID, actorID, name+" has joined the chat", &model.SystemMessagePayload{
+			Event:      model.SystemEventParticipantJoined,
+			ContractID: contract.ID,
+			PersonID:   actorID,
+		}); e != nil {
+			return e
+		}
+
+		page, err := messagesPage(ctx, queries, chat.ID, &model.MessagePageParamsDTO{})
+		if err != nil {
+			return err
+		}
+
+		result = chatFromDB(chat)
+		result.Messages = page.Messages
+		result.HasOlder = page.HasOlder
+
+		return addAudit(ctx, queries, actorID, model.AuditJoinChat, model.TargetContract, contract.ID, reason)
+	})
+}
+
+// ListAudit implements service.Moderation interface
+func (s *ModerationSvc) ListAudit(ctx context.Context, actorID, targetKind, targetID string) ([]*model.AuditRecordDTO, error) {
+	result := make([]*model.AuditRecordDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		if e := requireAdmin(ctx, queries, actorID); e != nil {
+			return e
+		}
+
+		aa, err := queries.AuditList(ctx, pgdao.AuditListParams{
+			TargetKind: targetKind,
+			TargetID:   targetID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to AuditList: %w", err)
+		}
+
+		for _, a := range aa {
+			result = append(result, &model.AuditRecordDTO{
+				ID:               a.ID,
+				ActorID:          a.ActorID,
+				ActorDisplayName: a.ActorDisplayName,
+				Action:           a.Action,
+				TargetKind:       a.TargetKind,
+				TargetID:         a.TargetID,
+				Reason:           a.Reason,
+				CreatedAt:        a.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+}
+
+// requireAdmin returns model.ErrInsufficientRights if the actor is not an admin
+func requireAdmin(ctx context.Context, queries *pgdao.Queries, actorID string) error {
+	person, err := queries.PersonGet(ctx, actorID)
+	if err != nil {
+		return model.ErrInsufficientRights
+	}
+
+	if !person.IsAdmin {
+		return model.ErrInsufficientRights
+	}
+
+	return nil
+}
+
+// setBlocked blocks or unblocks the job or the person and keeps the reason in the audit log
+// Blocked job is not available even for admins, so it is reported as not found on the second blocking
+func setBlocked(ctx context.Context, queries *pgdao.Queries, actorID, kind, id, reason string, block bool) error {
+	if e := requireAdmin(ctx, queries, actorID); e != nil {
+		return e
+	}
+
+	var (
+		blockedAt sql.NullTime
+		err       error
+	)
+
+	switch kind {
+	case model.TargetJob:
+		var job pgdao.Job
+		job, err = queries.JobFind(ctx, id)
+		blockedAt = job.BlockedAt
+
+		if err == nil && block && blockedAt.Valid {
+			err = sql.ErrNoRows
+		}
+
+	case model.TargetPerson:
+		var person pgdao.Person
+		person, err = queries.PersonGet(ctx, id)
+		blockedAt = person.BlockedAt
+
+	default:
+		return fmt.Errorf("unable to block %s: unsupported kind", kind)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to get %s with id='%s': %w", kind, id, err)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("reason"),
+		}
+	}
+
+	if kind == model.TargetPerson && id == actorID {
+		return model.ErrInappropriateAction
+	}
+
+	if blockedAt.Valid == block {
+		if block {
+			return fmt.Errorf("%w: %s is already blocked", model.ErrInappropriateAction, kind)
+		}
+		return fmt.Errorf("%w: %s is not blocked", model.ErrInappropriateAction, kind)
+	}
+
+	switch {
+	case kind == model.TargetJob && block:
+		err = queries.JobBlock(ctx, id)
+	case kind == model.TargetJob:
+		err = queries.JobUnblock(ctx, id)
+	case block:
+		err = queries.PersonBlock(ctx, id)
+	default:
+		err = queries.PersonUnblock(ctx, id)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to change blocking of %s with id='%s': %w", kind, id, err)
+	}
+
+	action := model.AuditUnblock
+	if block {
+		action = model.AuditBlock
+	}
+
+	return addAudit(ctx, queries, actorID, action, kind, id, reason)
+}
+
+// addAudit records the privileged action
+func addAudit(ctx context.Context, queries *pgdao.Queries, actorID, action, kind, id, reason string) error {
+	if _, err := queries.AuditAdd(ctx, pgdao.AuditAddParams{
+		ID:         pgdao.NewID(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetKind: kind,
+		TargetID:   id,
+		Reason:     reason,
+	}); err != nil {
+		return fmt.Errorf("unable to AuditAdd: %w", err)
+	}
+
+	return nil
+}
+
+// openReportFor returns the report only if the actor is an admin and the report is not reviewed yet
+func openReportFor(ctx context.Context, queries *pgdao.Queries, id, actorID string) (*model.ReportDTO, error) {
+	if e := requireAdmin(ctx, queries, actorID); e != nil {
+		return nil, e
+	}
+
+	r, err := reportByID(ctx, queries, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Status != model.ReportOpen {
+		return nil, fmt.Errorf("%w: report is already %s", model.ErrInappropriateAction, r.Status)
+	}
+
+	return r, nil
+}
+
+func reportByID(ctx context.Context, queries *pgdao.Queries, id string) (*model.ReportDTO, error) {
+	r, err := queries.ReportGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to ReportGet with id=%s: %w", id, err)
+	}
+
+	return reportFromDB(r), nil
+}
+
+func reportFromDB(r pgdao.ReportGetRow) *model.ReportDTO {
+	return &model.ReportDTO{
+		ID:                  r.ID,
+		TargetKind:          r.TargetKind,
+		TargetID:            r.TargetID,
+		Reason:              r.Reason,
+		Comment:             r.Comment,
+		CreatedBy:           r.CreatedBy,
+		ReporterDisplayName: r.ReporterDisplayName,
+		CreatedAt:           r.CreatedAt,
+		Status:              r.Status,
+		ResolvedBy:          r.ResolvedBy.String,
+		ResolvedAt:          nullTimeToPtr(r.ResolvedAt),
+		Resolution:          r.Resolution,
+	}
+}
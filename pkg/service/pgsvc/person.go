@@ -36,10 +36,6 @@ func (s *PersonSvc) Add(ctx context.Context, person *model.Person) (*model.Perso
 			PasswordHash: CreateHashFromPassword(person.Password),
 			DisplayName:  person.DisplayName,
 			Email:        person.Email,
-			AccessToken: sql.NullString{
-				String: pgdao.NewID(),
-				Valid:  true,
-			},
 		}
 
 		if input.Realm == "" {
@@ -66,7 +62,14 @@ func (s *PersonSvc) Add(ctx context.Context, person *model.Person) (*model.Perso
 			return fmt.Errorf("unable to PersonAdd: %w", err)
 		}
 
+		// the person is logged in right after sign up
+		_, token, err := startSession(ctx, queries, o.ID, "", "")
+		if err != nil {
+			return err
+		}
+
 		result = personDBtoModel(o)
+		result.AccessToken = token
 
 		return nil
 	})
@@ -101,32 +104,12 @@ func personDBtoModel(o pgdao.Person) *model.Person {
 		CreatedAt:       o.CreatedAt,
 		Email:           o.Email,
 		Resources:       string(o.Resources),
-		AccessToken:     o.AccessToken.String,
 		IsAdmin:         o.IsAdmin,
 		EthereumAddress: o.EthereumAddress,
+		BlockedAt:       nullTimeToPtr(o.BlockedAt),
 	}
 }
 
-// GetByAccessToken implements service.Person
-func (s *PersonSvc) GetByAccessToken(ctx context.Context, accessToken string) (*model.Person, error) {
-	var result *model.Person
-	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
-		o, err := queries.PersonGetByAccessToken(ctx, accessToken)
-
-		if errors.Is(err, sql.ErrNoRows) {
-			return model.ErrEntityNotFound
-		}
-
-		if err != nil {
-			return fmt.Errorf("unable to PersonGet: %w", err)
-		}
-
-		result = personDBtoModel(o)
-
-		return nil
-	})
-}
-
 // List implements service.Person
 func (s *PersonSvc) List(ctx context.Context) ([]*model.Person, error) {
 	result := make([]*model.Person, 0)
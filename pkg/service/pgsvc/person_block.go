@@ -0,0 +1,92 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// PersonBlockSvc is a service for persons blocked by other persons
+	PersonBlockSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewPersonBlock creates service
+func NewPersonBlock(db *sql.DB) *PersonBlockSvc {
+	return &PersonBlockSvc{db: db}
+}
+
+// Block implements service.PersonBlock interface
+func (s *PersonBlockSvc) Block(ctx context.Context, personID, actorID string) error {
+	if personID == actorID {
+		return fmt.Errorf("%w: unable to block yourself", model.ErrInappropriateAction)
+	}
+
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		person, err := queries.PersonGet(ctx, personID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id='%s': %w", personID, err)
+		}
+
+		if e := queries.PersonBlockAdd(ctx, pgdao.PersonBlockAddParams{
+			PersonID:  actorID,
+			BlockedID: person.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to PersonBlockAdd with blocked_id='%s': %w", person.ID, e)
+		}
+
+		return nil
+	})
+}
+
+// Unblock implements service.PersonBlock interface
+func (s *PersonBlockSvc) Unblock(ctx context.Context, personID, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		n, err := queries.PersonBlockDelete(ctx, pgdao.PersonBlockDeleteParams{
+			PersonID:  actorID,
+			BlockedID: personID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to PersonBlockDelete with blocked_id='%s': %w", personID, err)
+		}
+
+		if n == 0 {
+			return model.ErrEntityNotFound
+		}
+
+		return nil
+	})
+}
+
+// List implements service.PersonBlock interface
+func (s *PersonBlockSvc) List(ctx context.Context, actorID string) ([]*model.BlockedPersonDTO, error) {
+	result := make([]*model.BlockedPersonDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.PersonBlocksListByPerson(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonBlocksListByPerson: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, &model.BlockedPersonDTO{
+				ID:              o.ID,
+				DisplayName:     o.DisplayName,
+				EthereumAddress: o.EthereumAddress,
+				BlockedAt:       o.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+}
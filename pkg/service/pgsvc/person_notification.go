@@ -0,0 +1,90 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// PersonNotificationSvc is a service for in-app notifications addressed to persons
+	PersonNotificationSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewPersonNotification creates service
+func NewPersonNotification(db *sql.DB) *PersonNotificationSvc {
+	return &PersonNotificationSvc{db: db}
+}
+
+func personNotificationFromDB(n pgdao.PersonNotification) *model.PersonNotification {
+	return &model.PersonNotification{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		Text:      n.Text,
+		Link:      n.Link,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    nullTimeToPtr(n.ReadAt),
+	}
+}
+
+// notifyPerson stores a new notification for the person within the current transaction
+func notifyPerson(ctx context.Context, queries *pgdao.Queries, personID, kind, text, link string) error {
+	_, err := queries.PersonNotificationAdd(ctx, pgdao.PersonNotificationAddParams{
+		ID:       pgdao.NewID(),
+		PersonID: personID,
+		Kind:     kind,
+		Text:     text,
+		Link:     link,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to PersonNotificationAdd for person %s: %w", personID, err)
+	}
+
+	return nil
+}
+
+// ListByPerson implements service.PersonNotification
+func (s *PersonNotificationSvc) ListByPerson(ctx context.Context, personID string) ([]*model.PersonNotification, error) {
+	result := make([]*model.PersonNotification, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		nn, err := queries.PersonNotificationsListByPerson(ctx, personID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonNotificationsListByPerson: %w", err)
+		}
+
+		for _, n := range nn {
+			result = append(result, personNotificationFromDB(n))
+		}
+
+		return nil
+	})
+}
+
+// MarkRead implements service.PersonNotification
+func (s *PersonNotificationSvc) MarkRead(ctx context.Context, id, personID string) (*model.PersonNotification, error) {
+	var result *model.PersonNotification
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		n, err := queries.PersonNotificationMarkRead(ctx, pgdao.PersonNotificationMarkReadParams{
+			ID:       id,
+			PersonID: personID,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to PersonNotificationMarkRead with id=%s: %w", id, err)
+		}
+
+		result = personNotificationFromDB(n)
+
+		return nil
+	})
+}
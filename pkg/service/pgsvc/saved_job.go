@@ -0,0 +1,87 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// SavedJobSvc is a service for jobs bookmarked by persons
+	SavedJobSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewSavedJob creates service
+func NewSavedJob(db *sql.DB) *SavedJobSvc {
+	return &SavedJobSvc{db: db}
+}
+
+// Save implements service.SavedJob interface
+func (s *SavedJobSvc) Save(ctx context.Context, jobID, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		job, err := queries.JobGet(ctx, jobID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to JobGet with id='%s': %w", jobID, err)
+		}
+
+		if e := checkJobVisibleFor(ctx, queries, job, actorID); e != nil {
+			return e
+		}
+
+		if e := queries.SavedJobAdd(ctx, pgdao.SavedJobAddParams{
+			PersonID: actorID,
+			JobID:    job.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to SavedJobAdd with job_id='%s': %w", job.ID, e)
+		}
+
+		return nil
+	})
+}
+
+// Unsave implements service.SavedJob interface
+func (s *SavedJobSvc) Unsave(ctx context.Context, jobID, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		n, err := queries.SavedJobDelete(ctx, pgdao.SavedJobDeleteParams{
+			PersonID: actorID,
+			JobID:    jobID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to SavedJobDelete with job_id='%s': %w", jobID, err)
+		}
+
+		if n == 0 {
+			return model.ErrEntityNotFound
+		}
+
+		return nil
+	})
+}
+
+// List implements service.SavedJob interface
+func (s *SavedJobSvc) List(ctx context.Context, actorID string) ([]*model.JobCardDTO, error) {
+	result := make([]*model.JobCardDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.SavedJobsListByPerson(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to SavedJobsListByPerson: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, jobCardFromDB(pgdao.JobGetRow(o)))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,233 @@
+package pgsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+type (
+	// SavedSearchSvc is a service for search criteria saved by persons
+	SavedSearchSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewSavedSearch creates service
+func NewSavedSearch(db *sql.DB) *SavedSearchSvc {
+	return &SavedSearchSvc{db: db}
+}
+
+func validateSavedSearch(dto *model.SavedSearchParamsDTO) error {
+	if strings.TrimSpace(dto.Title) == "" {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorRequired("title"),
+		}
+	}
+
+	if dto.BudgetMin.IsNegative() {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("budget_min"),
+		}
+	}
+
+	if dto.BudgetMax.IsNegative() {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: model.ValidationErrorMustNotBeNegative("budget_max"),
+		}
+	}
+
+	if dto.BudgetMax.IsPositive() && dto.BudgetMax.LessThan(dto.BudgetMin) {
+		return &model.BackendError{
+			Cause:   model.ErrValidationFailed,
+			Message: "budget_max is less than budget_min",
+		}
+	}
+
+	return nil
+}
+
+// decimalToNullString converts zero to null
+func decimalToNullString(d decimal.Decimal) sql.NullString {
+	return sql.NullString{
+		String: d.String(),
+		Valid:  !d.IsZero(),
+	}
+}
+
+func savedSearchFromDB(o pgdao.SavedSearch) *model.SavedSearchDTO {
+	return &model.SavedSearchDTO{
+		ID:        o.ID,
+		Title:     o.Title,
+		Keyword:   o.Keyword,
+		BudgetMin: nullStringToDecimal(o.BudgetMin),
+		BudgetMax: nullStringToDecimal(o.BudgetMax),
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// getOwnSavedSearch returns the saved search if the actor is its owner
+func getOwnSavedSearch(ctx context.Context, queries *pgdao.Queries, id, actorID string) (pgdao.SavedSearch, error) {
+	o, err := queries.SavedSearchGet(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return o, model.ErrEntityNotFound
+	}
+
+	if err != nil {
+		return o, fmt.Errorf("unable to SavedSearchGet with id='%s': %w", id, err)
+	}
+
+	if o.PersonID != actorID {
+		return o, model.ErrInsufficientRights
+	}
+
+	return o, nil
+}
+
+// Add implements service.SavedSearch interface
+func (s *SavedSearchSvc) Add(ctx context.Context, actorID string, dto *model.SavedSearchParamsDTO) (*model.SavedSearchDTO, error) {
+	var result *model.SavedSearchDTO
+
+	if e := validateSavedSearch(dto); e != nil {
+		return nil, e
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		o, err := queries.SavedSearchAdd(ctx, pgdao.SavedSearchAddParams{
+			ID:        pgdao.NewID(),
+			PersonID:  actorID,
+			Title:     strings.TrimSpace(dto.Title),
+			Keyword:   strings.TrimSpace(dto.Keyword),
+			BudgetMin: decimalToNullString(dto.BudgetMin),
+			BudgetMax: decimalToNullString(dto.BudgetMax),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to SavedSearchAdd: %w", err)
+		}
+
+		result = savedSearchFromDB(o)
+		return nil
+	})
+}
+
+// Get implements service.SavedSearch interface
+func (s *SavedSearchSvc) Get(ctx context.Context, id, actorID string) (*model.SavedSearchDTO, error) {
+	var result *model.SavedSearchDTO
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		o, err := getOwnSavedSearch(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		result = savedSearchFromDB(o)
+		return nil
+	})
+}
+
+// List implements service.SavedSearch interface
+func (s *SavedSearchSvc) List(ctx context.Context, actorID string) ([]*model.SavedSearchDTO, error) {
+	result := make([]*model.SavedSearchDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.SavedSearchesListByPerson(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to SavedSearchesListByPerson: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, savedSearchFromDB(o))
+		}
+
+		return nil
+	})
+}
+
+// Update implements service.SavedSearch interface
+func (s *SavedSearchSvc) Update(ctx context.Context, id, actorID string, dto *model.SavedSearchParamsDTO) (*model.SavedSearchDTO, error) {
+	var result *model.SavedSearchDTO
+
+	if e := validateSavedSearch(dto); e != nil {
+		return nil, e
+	}
+
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		o, err := getOwnSavedSearch(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		o, err = queries.SavedSearchUpdate(ctx, pgdao.SavedSearchUpdateParams{
+			Title:     strings.TrimSpace(dto.Title),
+			Keyword:   strings.TrimSpace(dto.Keyword),
+			BudgetMin: decimalToNullString(dto.BudgetMin),
+			BudgetMax: decimalToNullString(dto.BudgetMax),
+			ID:        o.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to SavedSearchUpdate with id='%s': %w", id, err)
+		}
+
+		result = savedSearchFromDB(o)
+		return nil
+	})
+}
+
+// Delete implements service.SavedSearch interface
+func (s *SavedSearchSvc) Delete(ctx context.Context, id, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		o, err := getOwnSavedSearch(ctx, queries, id, actorID)
+		if err != nil {
+			return err
+		}
+
+		return queries.SavedSearchDelete(ctx, o.ID)
+	})
+}
+
+// MatchNewJobs implements service.SavedSearch interface
+// A person gets a single alert about a job even if several searches of the person match it.
+func (s *SavedSearchSvc) MatchNewJobs(ctx context.Context) (int, error) {
+	var result int
+	return result, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		jj, err := queries.JobsClaimForAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to JobsClaimForAlerts: %w", err)
+		}
+
+		for _, j := range jj {
+			oo, err := queries.SavedSearchesMatchJob(ctx, j.ID)
+			if err != nil {
+				return fmt.Errorf("unable to SavedSearchesMatchJob with job_id='%s': %w", j.ID, err)
+			}
+
+			alerted := make(map[string]bool)
+			for _, o := range oo {
+				if alerted[o.PersonID] {
+					continue
+				}
+
+				if e := notifyPerson(ctx, queries, o.PersonID, model.NotificationSavedSearchMatch,
+					fmt.Sprintf("New job matches your saved search %q: %s", o.Title, j.Title),
+					path.Join("/", "jobs", j.ID)); e != nil {
+					return e
+				}
+
+				alerted[o.PersonID] = true
+				result++
+			}
+		}
+
+		return nil
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
@@ -56,26 +57,174 @@ func (s *SecuritySvc) FromEchoContext(c echo.Context) (*model.UserContext, error
 
 	token := strings.TrimSpace(auth)
 
-	// here is token used as is
-	personSvc := NewPerson(s.db)
-	p, err := personSvc.GetByAccessToken(c.Request().Context(), token)
-	if err != nil {
+	var (
+		p              *model.Person
+		sessionID      string
+		apiKey         *pgdao.ApiKey
+		impersonatedBy string
+		err            error
+	)
+
+	if strings.HasPrefix(token, model.APIKeyPrefix) {
+		p, apiKey, err = s.apiKey(c, token)
+	} else {
+		p, sessionID, err = s.session(c.Request().Context(), token)
+
+		if errors.Is(err, model.ErrEntityNotFound) {
+			p, impersonatedBy, err = s.impersonated(c, token)
+		}
+	}
+
+	if errors.Is(err, model.ErrInsufficientRights) {
+		clog.Ectx(c).Warn().Err(err).Msg("API key has no scope for the route")
+	} else if err != nil {
 		clog.Ectx(c).Warn().Err(err).Msg("Unable to authorize")
 		err = model.ErrUnauthorized
+	} else if p.BlockedAt != nil {
+		clog.Ectx(c).Warn().Str("id", p.ID).Msg("Blocked person is trying to authorize")
+		p, err = nil, model.ErrUnauthorized
 	}
 
 	newUctx := &model.UserContext{
-		Authenticated: err == nil,
-		Token:         token,
-		Subject:       p,
+		Authenticated:  err == nil,
+		Token:          token,
+		Subject:        p,
+		SessionID:      sessionID,
+		ImpersonatedBy: impersonatedBy,
+	}
+
+	if apiKey != nil {
+		newUctx.APIKeyID, newUctx.Scopes = apiKey.ID, apiKey.Scopes
 	}
 
 	c.Set(UserContextKey, newUctx)
 	return newUctx, err
 }
 
+// session returns the person and the session ID by the access token
+// The session is prolonged on every use.
+func (s *SecuritySvc) session(ctx context.Context, token string) (*model.Person, string, error) {
+	var (
+		person    *model.Person
+		sessionID string
+	)
+
+	return person, sessionID, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		o, err := queries.SessionGetByTokenHash(ctx, hashToken(token))
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to SessionGetByTokenHash: %w", err)
+		}
+
+		if e := queries.SessionTouch(ctx, pgdao.SessionTouchParams{
+			ExpiresAt: time.Now().Add(sessionTTL).UTC(),
+			ID:        o.ID,
+		}); e != nil {
+			return fmt.Errorf("unable to SessionTouch with id=%s: %w", o.ID, e)
+		}
+
+		p, err := queries.PersonGet(ctx, o.PersonID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id=%s: %w", o.PersonID, err)
+		}
+
+		person, sessionID = personDBtoModel(p), o.ID
+		return nil
+	})
+}
+
+// apiKey returns the person and the API key by the key itself
+// The key must be allowed from the IP address of the request and must have the scope of the route.
+func (s *SecuritySvc) apiKey(c echo.Context, key string) (*model.Person, *pgdao.ApiKey, error) {
+	var (
+		ctx    = c.Request().Context()
+		person *model.Person
+		apiKey *pgdao.ApiKey
+	)
+
+	return person, apiKey, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		o, err := queries.APIKeyGetByTokenHash(ctx, hashToken(key))
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to APIKeyGetByTokenHash: %w", err)
+		}
+
+		if !ipAllowed(o.IpAllowlist, c.RealIP()) {
+			return fmt.Errorf("API key %s is not allowed from %s: %w", o.ID, c.RealIP(), model.ErrUnauthorized)
+		}
+
+		if !apiKeyRouteAllowed(c.Request().Method, c.Request().URL.Path, o.Scopes) {
+			return fmt.Errorf("%w: API key has no scope for %s %s", model.ErrInsufficientRights, c.Request().Method, c.Request().URL.Path)
+		}
+
+		if e := queries.APIKeyTouch(ctx, o.ID); e != nil {
+			return fmt.Errorf("unable to APIKeyTouch with id=%s: %w", o.ID, e)
+		}
+
+		p, err := queries.PersonGet(ctx, o.PersonID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id=%s: %w", o.PersonID, err)
+		}
+
+		person, apiKey = personDBtoModel(p), &o
+		return nil
+	})
+}
+
+// impersonated returns the person by the impersonation token and the admin ID who impersonates the person
+// Every request with the impersonation token is recorded in the audit log
+func (s *SecuritySvc) impersonated(c echo.Context, token string) (*model.Person, string, error) {
+	var (
+		ctx     = c.Request().Context()
+		person  *model.Person
+		adminID string
+	)
+
+	return person, adminID, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		i, err := queries.ImpersonationGetByTokenHash(ctx, hashToken(token))
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrEntityNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to ImpersonationGetByTokenHash: %w", err)
+		}
+
+		admin, err := queries.PersonGet(ctx, i.AdminID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id=%s: %w", i.AdminID, err)
+		}
+
+		if !admin.IsAdmin || admin.BlockedAt.Valid {
+			return fmt.Errorf("impersonation %s belongs to %s who is not an admin anymore: %w", i.ID, i.AdminID, model.ErrUnauthorized)
+		}
+
+		o, err := queries.PersonGet(ctx, i.PersonID)
+		if err != nil {
+			return fmt.Errorf("unable to PersonGet with id=%s: %w", i.PersonID, err)
+		}
+
+		if e := addAudit(ctx, queries, i.AdminID, model.AuditImpersonatedRequest, model.TargetPerson, o.ID,
+			c.Request().Method+" "+c.Request().RequestURI); e != nil {
+			return e
+		}
+
+		person, adminID = personDBtoModel(o), i.AdminID
+		return nil
+	})
+}
+
 // FromLoginPassword implements service.Security
-func (s *SecuritySvc) FromLoginPassword(ctx context.Context, login, password string) (*model.UserContext, error) {
+func (s *SecuritySvc) FromLoginPassword(ctx context.Context, login, password, userAgent, ip string) (*model.UserContext, error) {
 	newUctx := new(model.UserContext)
 	return newUctx, doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
 		u, err := queries.PersonGetByLogin(ctx, pgdao.PersonGetByLoginParams{
@@ -97,21 +246,26 @@ func (s *SecuritySvc) FromLoginPassword(ctx context.Context, login, password str
 			return model.ErrUnableToLogin
 		}
 
-		token := pgdao.NewID()
-		if e := queries.PersonSetAccessToken(ctx, pgdao.PersonSetAccessTokenParams{
-			AccessToken: token,
-			ID:          u.ID,
-		}); e != nil {
+		if u.BlockedAt.Valid {
+			clog.Ctx(ctx).Warn().Str("login", login).Msg("Person is blocked")
+			return &model.BackendError{
+				Cause:   model.ErrUnableToLogin,
+				Message: "person is blocked",
+			}
+		}
+
+		session, token, err := startSession(ctx, queries, u.ID, userAgent, ip)
+		if err != nil {
 			clog.Ctx(ctx).Warn().
 				Str("login", login).
 				Str("id", u.ID).
-				Str("token", token).
-				Msg("Unable to set access token for user")
-			return e
+				Msg("Unable to start session for user")
+			return err
 		}
 
 		newUctx.Authenticated = true
 		newUctx.Token = token
+		newUctx.SessionID = session.ID
 		newUctx.Subject = &model.Person{
 			ID:              u.ID,
 			Login:           u.Login,
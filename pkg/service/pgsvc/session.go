@@ -0,0 +1,102 @@
+package pgsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"optrispace.com/work/pkg/db/pgdao"
+	"optrispace.com/work/pkg/model"
+)
+
+// sessionTTL is how long the session lives without use
+const sessionTTL = 30 * 24 * time.Hour
+
+type (
+	// SessionSvc is a service for logged in sessions of persons
+	SessionSvc struct {
+		db *sql.DB
+	}
+)
+
+// NewSession creates service
+func NewSession(db *sql.DB) *SessionSvc {
+	return &SessionSvc{db: db}
+}
+
+// hashToken returns the hash of the access token which is stored instead of the token
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// startSession creates a new session of the person and returns it with the access token
+// Expired sessions of the person are removed meanwhile.
+func startSession(ctx context.Context, queries *pgdao.Queries, personID, userAgent, ip string) (pgdao.Session, string, error) {
+	if e := queries.SessionsDeleteExpired(ctx, personID); e != nil {
+		return pgdao.Session{}, "", fmt.Errorf("unable to SessionsDeleteExpired with person_id='%s': %w", personID, e)
+	}
+
+	token := pgdao.NewID()
+
+	o, err := queries.SessionAdd(ctx, pgdao.SessionAddParams{
+		ID:        pgdao.NewID(),
+		PersonID:  personID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(sessionTTL).UTC(),
+		UserAgent: userAgent,
+		Ip:        ip,
+	})
+	if err != nil {
+		return pgdao.Session{}, "", fmt.Errorf("unable to SessionAdd with person_id='%s': %w", personID, err)
+	}
+
+	return o, token, nil
+}
+
+// List implements service.Session interface
+func (s *SessionSvc) List(ctx context.Context, actorID, currentID string) ([]*model.SessionDTO, error) {
+	result := make([]*model.SessionDTO, 0)
+	return result, doWithQueries(ctx, s.db, defaultRoTxOpts, func(queries *pgdao.Queries) error {
+		oo, err := queries.SessionsListByPerson(ctx, actorID)
+		if err != nil {
+			return fmt.Errorf("unable to SessionsListByPerson: %w", err)
+		}
+
+		for _, o := range oo {
+			result = append(result, &model.SessionDTO{
+				ID:         o.ID,
+				CreatedAt:  o.CreatedAt,
+				LastUsedAt: o.LastUsedAt,
+				ExpiresAt:  o.ExpiresAt,
+				UserAgent:  o.UserAgent,
+				IP:         o.Ip,
+				Current:    o.ID == currentID,
+			})
+		}
+
+		return nil
+	})
+}
+
+// Delete implements service.Session interface
+func (s *SessionSvc) Delete(ctx context.Context, id, actorID string) error {
+	return doWithQueries(ctx, s.db, defaultRwTxOpts, func(queries *pgdao.Queries) error {
+		n, err := queries.SessionDelete(ctx, pgdao.SessionDeleteParams{
+			ID:       id,
+			PersonID: actorID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to SessionDelete with id='%s': %w", id, err)
+		}
+
+		if n == 0 {
+			return model.ErrEntityNotFound
+		}
+
+		return nil
+	})
+}
@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 	"database/sql"
+	"io"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"optrispace.com/work/pkg/model"
 	"optrispace.com/work/pkg/service/ethsvc"
+	"optrispace.com/work/pkg/service/filesvc"
+	"optrispace.com/work/pkg/service/mailsvc"
 	"optrispace.com/work/pkg/service/pgsvc"
 )
 
@@ -30,7 +34,8 @@ type (
 		FromEchoContext(c echo.Context) (*model.UserContext, error)
 
 		// FromLoginPassword creates UserContext from login and password in default realm
-		FromLoginPassword(ctx context.Context, login, password string) (*model.UserContext, error)
+		// A new session is started for the client, other sessions of the person stay alive.
+		FromLoginPassword(ctx context.Context, login, password, userAgent, ip string) (*model.UserContext, error)
 	}
 
 	// Job handles job offers
@@ -39,31 +44,56 @@ type (
 		Add(ctx context.Context, customerID string, dto *model.CreateJobDTO) (*model.JobDTO, error)
 
 		// Get returns a specific job by ID
-		Get(ctx context.Context, id string) (*model.JobCardDTO, error)
+		// Private jobs are available only for the owner and invited persons, actorID may be empty for anonymous requests
+		Get(ctx context.Context, id, actorID string) (*model.JobCardDTO, error)
 
-		// List returns a list of jobs
-		List(ctx context.Context) ([]*model.JobDTO, error)
+		// List returns a list of open public jobs matching the filter from the last updated
+		List(ctx context.Context, filter *model.JobFilterDTO) ([]*model.JobDTO, error)
+
+		// ListByOwner returns all jobs of the owner in every state
+		ListByOwner(ctx context.Context, actorID string) ([]*model.JobCardDTO, error)
 
 		// Patch partially updates existing Job object
 		Patch(ctx context.Context, id, customerID string, patch *model.UpdateJobDTO) (*model.JobDTO, error)
 
-		// Block job
-		Block(ctx context.Context, id, actorID string) error
+		// ListRevisions returns the edit history of the job from the newest revision to the oldest one
+		ListRevisions(ctx context.Context, id, actorID string) ([]*model.JobRevisionDTO, error)
+
+		// SetQuestions replaces screening questions of the job
+		// Questions can be changed only until the job gets the first application.
+		SetQuestions(ctx context.Context, id, actorID string, qq []*model.JobQuestionParamsDTO) ([]*model.JobQuestionDTO, error)
+
+		// Block job, the reason is kept in the audit log
+		Block(ctx context.Context, id, actorID, reason string) error
+
+		// Unblock job, the reason is kept in the audit log
+		Unblock(ctx context.Context, id, actorID, reason string) error
 
 		// Suspend job
 		Suspend(ctx context.Context, id, actorID string) error
 
 		// Resume job
 		Resume(ctx context.Context, id, actorID string) error
+
+		// Reopen makes closed or expired job open again
+		Reopen(ctx context.Context, id, actorID string, dto *model.ReopenJobDTO) (*model.JobCardDTO, error)
+
+		// SuspendExpired suspends all jobs which are expired and notifies their owners
+		// It returns count of suspended jobs
+		SuspendExpired(ctx context.Context) (int, error)
+
+		// Publish publishes the draft immediately or schedules its publication
+		Publish(ctx context.Context, id, actorID string, dto *model.PublishJobDTO) (*model.JobCardDTO, error)
+
+		// PublishScheduled publishes all drafts which publication moment has come and notifies their owners
+		// It returns count of published jobs
+		PublishScheduled(ctx context.Context) (int, error)
 	}
 
 	// Person is a person who pay or earn
 	Person interface {
 		GenericCRUD[model.Person]
 
-		// GetByAccessToken returns person by access token supplied
-		GetByAccessToken(ctx context.Context, accessToken string) (*model.Person, error)
-
 		// Update password
 		UpdatePassword(ctx context.Context, subjectID, oldPassword, newPassword string) error
 
@@ -86,13 +116,48 @@ type (
 		GetForJob(ctx context.Context, jobID, actorID string) (*model.ApplicationDTO, error)
 
 		// ListByJob returns applications belong to specific job by ID
-		ListByJob(ctx context.Context, jobID, actorID string) ([]*model.ApplicationDTO, error)
+		// Only active applications in the status are returned if the status is not empty.
+		ListByJob(ctx context.Context, jobID, actorID, status string) ([]*model.ApplicationDTO, error)
 
 		// ListByApplicant returns list of applications by specified applicant
 		ListByApplicant(ctx context.Context, applicantID string) ([]*model.ApplicationDTO, error)
 
 		// GetChat returns chat associated with this application
 		GetChat(ctx context.Context, id, actorID string) (*model.Chat, error)
+
+		// Update changes comment and price of the application until a contract is created.
+		// The change is saved as a new revision and posted to the application chat.
+		Update(ctx context.Context, id, actorID string, dto *model.UpdateApplicationDTO) (*model.ApplicationDTO, error)
+
+		// Withdraw withdraws the application until a contract is created, the applicant is able to apply again
+		Withdraw(ctx context.Context, id, actorID string) (*model.ApplicationDTO, error)
+
+		// ListRevisions returns the edit history of the application from the newest revision
+		ListRevisions(ctx context.Context, id, actorID string) ([]*model.ApplicationRevisionDTO, error)
+
+		// SetStatus moves the application through the pipeline of the customer and notifies the applicant
+		SetStatus(ctx context.Context, id, actorID string, dto *model.ApplicationStatusDTO) (*model.ApplicationDTO, error)
+	}
+
+	// Invitation is an invitation of a person to apply for a job
+	Invitation interface {
+		// Add invites a person to the job
+		Add(ctx context.Context, customerID string, dto *model.CreateInvitationDTO) (*model.InvitationDTO, error)
+
+		// Get returns an invitation by ID for the job owner or the invited person
+		Get(ctx context.Context, id, actorID string) (*model.InvitationDTO, error)
+
+		// ListByJob returns invitations to the job for the job owner
+		ListByJob(ctx context.Context, jobID, actorID string) ([]*model.InvitationDTO, error)
+
+		// ListByPerson returns invitations addressed to the person
+		ListByPerson(ctx context.Context, personID string) ([]*model.InvitationDTO, error)
+
+		// Accept makes invitation accepted and creates an application for the job
+		Accept(ctx context.Context, id, actorID string, dto *model.AcceptInvitationDTO) (*model.InvitationDTO, error)
+
+		// Decline makes invitation declined
+		Decline(ctx context.Context, id, actorID string) (*model.InvitationDTO, error)
 	}
 
 	// Contract is an agreement between a Customer and a Performer (Contractor)
@@ -124,6 +189,10 @@ type (
 
 		// ListByPersonID returns list of entities by specific Person
 		ListByPersonID(ctx context.Context, personID string) ([]*model.ContractDTO, error)
+
+		// GetChat returns the chat of the contract parties
+		// It can return model.ErrNotFound
+		GetChat(ctx context.Context, id, actorID string) (*model.Chat, error)
 	}
 
 	// Notification service manipulates with notifications
@@ -132,6 +201,193 @@ type (
 		Push(ctx context.Context, data string) error
 	}
 
+	// PersonNotification service manipulates with in-app notifications addressed to persons
+	PersonNotification interface {
+		// ListByPerson returns notifications of the person from the newest to the oldest
+		ListByPerson(ctx context.Context, personID string) ([]*model.PersonNotification, error)
+
+		// MarkRead marks the notification as read by the person
+		MarkRead(ctx context.Context, id, personID string) (*model.PersonNotification, error)
+	}
+
+	// EmailDigest service sends persons digests of unread chat messages and contract status changes by email
+	EmailDigest interface {
+		// GetSettings returns the digest preference of the person
+		GetSettings(ctx context.Context, personID string) (*model.EmailDigestSettingsDTO, error)
+
+		// SetFrequency changes how often digests are sent to the person
+		SetFrequency(ctx context.Context, personID, frequency string) (*model.EmailDigestSettingsDTO, error)
+
+		// CheckUnsubscribe checks the token from the unsubscribe link without changing anything
+		CheckUnsubscribe(ctx context.Context, token string) error
+
+		// Unsubscribe stops digests for the person with the token from the unsubscribe link
+		Unsubscribe(ctx context.Context, token string) error
+
+		// SendDigests sends digests to persons whose digest period has passed and returns count of sent emails
+		SendDigests(ctx context.Context) (int, error)
+	}
+
+	// Attachment service manipulates with files attached to jobs, applications and chat messages
+	Attachment interface {
+		// Add saves uploaded file. It can be attached to a job, an application or a chat message on their creation
+		Add(ctx context.Context, actorID string, dto *model.CreateAttachmentDTO) (*model.AttachmentDTO, error)
+
+		// Get returns the attachment with its content, if the actor is able to see it
+		// Caller must close the content
+		Get(ctx context.Context, id, actorID string) (*model.AttachmentDTO, io.ReadCloser, error)
+
+		// GetThumbnail returns the attachment with the content of its preview, if the actor is able to see it
+		// It returns model.ErrEntityNotFound if the attachment has no thumbnail. Caller must close the content
+		GetThumbnail(ctx context.Context, id, actorID string) (*model.AttachmentDTO, io.ReadCloser, error)
+
+		// Delete removes the attachment uploaded by the actor
+		Delete(ctx context.Context, id, actorID string) error
+
+		// ListByJob returns attachments of the job
+		ListByJob(ctx context.Context, jobID, actorID string) ([]*model.AttachmentDTO, error)
+
+		// ListByApplication returns attachments of the application for the applicant or the job owner
+		ListByApplication(ctx context.Context, applicationID, actorID string) ([]*model.AttachmentDTO, error)
+	}
+
+	// Moderation service handles abuse reports and blocking of persons. It is available for admins only except reporting.
+	Moderation interface {
+		// AddReport reports a job, a person or a chat message
+		AddReport(ctx context.Context, actorID string, dto *model.CreateReportDTO) (*model.ReportDTO, error)
+
+		// GetReport returns the report
+		GetReport(ctx context.Context, id, actorID string) (*model.ReportDTO, error)
+
+		// ListReports returns reports in the status or in any status if it is empty. Open reports go first.
+		ListReports(ctx context.Context, actorID, status string) ([]*model.ReportDTO, error)
+
+		// ResolveReport resolves the report and blocks the reported entity if it is requested
+		ResolveReport(ctx context.Context, id, actorID string, dto *model.ResolveReportDTO) (*model.ReportDTO, error)
+
+		// DismissReport dismisses the report without any action
+		DismissReport(ctx context.Context, id, actorID, resolution string) (*model.ReportDTO, error)
+
+		// BlockPerson blocks the person, blocked person is unable to log in
+		BlockPerson(ctx context.Context, id, actorID, reason string) error
+
+		// UnblockPerson unblocks the person
+		UnblockPerson(ctx context.Context, id, actorID, reason string) error
+
+		// ListMessageRevisions returns previous versions of the message from the newest to the oldest
+		ListMessageRevisions(ctx context.Context, id, actorID string) ([]*model.MessageRevisionDTO, error)
+
+		// JoinContractChat adds the admin to the chat of the contract to settle a dispute
+		JoinContractChat(ctx context.Context, id, actorID, reason string) (*model.Chat, error)
+
+		// ListAudit returns audit records about the target, empty targetKind and targetID mean any
+		ListAudit(ctx context.Context, actorID, targetKind, targetID string) ([]*model.AuditRecordDTO, error)
+	}
+
+	// Admin service is for user management by admins. Every action changing or revealing person's data is audited.
+	Admin interface {
+		// ListPersons returns persons matching the query by login, display name, email, ID or ethereum address
+		ListPersons(ctx context.Context, actorID, query string, limit, offset int) ([]*model.Person, error)
+
+		// GetPerson returns the person with admin and blocking flags
+		GetPerson(ctx context.Context, id, actorID string) (*model.Person, error)
+
+		// SetAdmin grants or revokes admin privileges
+		SetAdmin(ctx context.Context, id, actorID string, isAdmin bool, reason string) (*model.Person, error)
+
+		// ResetAccessToken ends all sessions and impersonations of the person and revokes API keys, so the person has to log in again
+		ResetAccessToken(ctx context.Context, id, actorID, reason string) error
+
+		// Impersonate issues a temporary token to act as the person
+		Impersonate(ctx context.Context, id, actorID, reason string) (*model.UserContext, error)
+
+		// ListPersonJobs returns all jobs of the person
+		ListPersonJobs(ctx context.Context, id, actorID string) ([]*model.JobCardDTO, error)
+
+		// ListPersonApplications returns all applications of the person
+		ListPersonApplications(ctx context.Context, id, actorID string) ([]*model.ApplicationDTO, error)
+
+		// ListPersonContracts returns all contracts where the person is a customer or a performer
+		ListPersonContracts(ctx context.Context, id, actorID string) ([]*model.ContractDTO, error)
+	}
+
+	// Connects service for balances of connects which persons spend on applications
+	Connects interface {
+		// Balance returns the balance of the person, it returns nil if connects are not limited
+		Balance(ctx context.Context, personID string) (*model.ConnectsDTO, error)
+
+		// Adjust changes the balance of the person by an admin, the reason is kept in the audit log
+		Adjust(ctx context.Context, id, actorID string, dto *model.AdjustConnectsDTO) (*model.ConnectsDTO, error)
+	}
+
+	// SavedJob service for jobs bookmarked by persons
+	SavedJob interface {
+		// Save bookmarks the job for the person, saving of the already saved job has no effect
+		Save(ctx context.Context, jobID, actorID string) error
+
+		// Unsave removes the job from bookmarks of the person
+		Unsave(ctx context.Context, jobID, actorID string) error
+
+		// List returns saved jobs of the person from the last saved
+		List(ctx context.Context, actorID string) ([]*model.JobCardDTO, error)
+	}
+
+	// PersonBlock service for persons blocked by other persons.
+	// Blocked person is unable to post into shared chats, to apply to jobs and to get contracts of the blocking person.
+	PersonBlock interface {
+		// Block adds the person to the block list of the actor, blocking of the already blocked person has no effect
+		Block(ctx context.Context, personID, actorID string) error
+
+		// Unblock removes the person from the block list of the actor
+		Unblock(ctx context.Context, personID, actorID string) error
+
+		// List returns persons blocked by the actor from the last blocked
+		List(ctx context.Context, actorID string) ([]*model.BlockedPersonDTO, error)
+	}
+
+	// Session service for logged in sessions of persons, a person may have many sessions on different devices
+	Session interface {
+		// List returns not expired sessions of the actor from the recently used, currentID marks the session of the request
+		List(ctx context.Context, actorID, currentID string) ([]*model.SessionDTO, error)
+
+		// Delete ends the session of the actor, its token is not valid anymore
+		Delete(ctx context.Context, id, actorID string) error
+	}
+
+	// APIKey service for personal API keys of persons for integrations
+	APIKey interface {
+		// Add creates the key of the actor, the key itself is returned only here
+		Add(ctx context.Context, actorID string, dto *model.APIKeyParamsDTO) (*model.APIKeyDTO, error)
+
+		// List returns keys of the actor from the newest
+		List(ctx context.Context, actorID string) ([]*model.APIKeyDTO, error)
+
+		// Delete revokes the key of the actor
+		Delete(ctx context.Context, id, actorID string) error
+	}
+
+	// SavedSearch service for search criteria saved by persons to get alerts about new jobs
+	SavedSearch interface {
+		// Add saves new search criteria
+		Add(ctx context.Context, actorID string, dto *model.SavedSearchParamsDTO) (*model.SavedSearchDTO, error)
+
+		// Get returns the saved search of the person
+		Get(ctx context.Context, id, actorID string) (*model.SavedSearchDTO, error)
+
+		// List returns all saved searches of the person
+		List(ctx context.Context, actorID string) ([]*model.SavedSearchDTO, error)
+
+		// Update replaces criteria of the saved search
+		Update(ctx context.Context, id, actorID string, dto *model.SavedSearchParamsDTO) (*model.SavedSearchDTO, error)
+
+		// Delete deletes the saved search
+		Delete(ctx context.Context, id, actorID string) error
+
+		// MatchNewJobs matches published jobs, which have not been matched yet, against saved searches
+		// and sends alerts to owners of matched searches. It returns count of sent alerts.
+		MatchNewJobs(ctx context.Context) (int, error)
+	}
+
 	// Stats service for statistic information
 	Stats interface {
 		// Stats returns users registrations number grouped by days
@@ -140,14 +396,52 @@ type (
 
 	// Chat service for chat information, messaging etc.
 	Chat interface {
-		// AddMessage adds an message to the chat
-		AddMessage(ctx context.Context, chatID, participantID, text string) (*model.Message, error)
+		// AddMessage adds an message with optional attachments to the chat
+		AddMessage(ctx context.Context, chatID, participantID string, dto *model.CreateMessageDTO) (*model.Message, error)
+
+		// EditMessage replaces the text of own message, the previous text is kept for moderation
+		EditMessage(ctx context.Context, chatID, messageID, participantID, text string) (*model.Message, error)
 
-		// Get returns chat description with all messages in it
+		// DeleteMessage deletes own message, the text is kept for moderation
+		DeleteMessage(ctx context.Context, chatID, messageID, participantID string) error
+
+		// Get returns chat description with the most recent page of messages
 		Get(ctx context.Context, chatID, participantID string) (*model.Chat, error)
 
+		// ListMessages returns the page of messages of the chat
+		ListMessages(ctx context.Context, chatID, participantID string, params *model.MessagePageParamsDTO) (*model.MessagePageDTO, error)
+
+		// SearchMessages looks for messages in all chats of the participant, the newest messages go first
+		SearchMessages(ctx context.Context, participantID string, params *model.MessageSearchParamsDTO) ([]*model.MessageSearchHitDTO, error)
+
 		// ListByParticipant returns all chats by participant ID
 		ListByParticipant(ctx context.Context, participantID string) ([]*model.ChatDTO, error)
+
+		// MarkRead marks messages of the chat as read by the participant up to the message
+		// All messages are marked as read if messageID is empty.
+		MarkRead(ctx context.Context, chatID, participantID, messageID string) error
+
+		// UnreadCount returns a total count of unread messages in all chats of the participant
+		UnreadCount(ctx context.Context, participantID string) (int64, error)
+	}
+
+	// ChatEvents delivers real-time events to chat participants
+	// Events are passed through the database, so every replica gets them.
+	ChatEvents interface {
+		// Listen receives events from the database and dispatches them to subscribers until the context is done
+		Listen(ctx context.Context) error
+
+		// Subscribe returns events of all chats of the person, cancel stops the subscription
+		Subscribe(personID string) (events <-chan *model.ChatEvent, cancel func())
+
+		// Typing notifies other participants that the person is typing in the chat
+		Typing(ctx context.Context, chatID, personID string) error
+
+		// Ticket issues a short-lived single-use ticket authenticating the WebSocket handshake of the person
+		Ticket(ctx context.Context, personID string) (*model.ChatTicketDTO, error)
+
+		// UseTicket returns the person ID of the ticket, the ticket can not be used again
+		UseTicket(ctx context.Context, ticket string) (string, error)
 	}
 )
 
@@ -167,8 +461,15 @@ func NewPerson(db *sql.DB) Person {
 }
 
 // NewApplication creates application service
-func NewApplication(db *sql.DB) Application {
-	return pgsvc.NewApplication(db)
+// connects is a policy of connects spent on applications
+func NewApplication(db *sql.DB, connects *model.ConnectsPolicy) Application {
+	return pgsvc.NewApplication(db, connects)
+}
+
+// NewInvitation creates invitation service
+// connects is a policy of connects spent on accepted invitations
+func NewInvitation(db *sql.DB, connects *model.ConnectsPolicy) Invitation {
+	return pgsvc.NewInvitation(db, connects)
 }
 
 // NewContract creates contract service
@@ -181,12 +482,74 @@ func NewNotification(tgToken string, chatIDs ...int64) Notification {
 	return pgsvc.NewNotification(tgToken, chatIDs...)
 }
 
+// NewPersonNotification creates person notification service
+func NewPersonNotification(db *sql.DB) PersonNotification {
+	return pgsvc.NewPersonNotification(db)
+}
+
+// NewPersonBlock creates person block service
+func NewPersonBlock(db *sql.DB) PersonBlock {
+	return pgsvc.NewPersonBlock(db)
+}
+
+// NewSession creates session service
+func NewSession(db *sql.DB) Session {
+	return pgsvc.NewSession(db)
+}
+
+// NewAPIKey creates API key service
+func NewAPIKey(db *sql.DB) APIKey {
+	return pgsvc.NewAPIKey(db)
+}
+
+// NewEmailDigest creates email digest service, digests are not sent if the mailer is nil
+func NewEmailDigest(db *sql.DB, mailer mailsvc.Mailer, apiURL, webURL string) EmailDigest {
+	return pgsvc.NewEmailDigest(db, mailer, apiURL, webURL)
+}
+
+// NewAttachment creates attachment service
+func NewAttachment(db *sql.DB, storage filesvc.Storage, scanner filesvc.Scanner, maxSize int64, types []string) Attachment {
+	return pgsvc.NewAttachment(db, storage, scanner, maxSize, types)
+}
+
+// NewModeration creates moderation service
+func NewModeration(db *sql.DB) Moderation {
+	return pgsvc.NewModeration(db)
+}
+
+// NewAdmin creates admin service
+// impersonationTTL is a lifetime of impersonation tokens
+func NewAdmin(db *sql.DB, impersonationTTL time.Duration) Admin {
+	return pgsvc.NewAdmin(db, impersonationTTL)
+}
+
+// NewConnects creates connects service
+func NewConnects(db *sql.DB, policy *model.ConnectsPolicy) Connects {
+	return pgsvc.NewConnects(db, policy)
+}
+
+// NewSavedJob creates saved job service
+func NewSavedJob(db *sql.DB) SavedJob {
+	return pgsvc.NewSavedJob(db)
+}
+
+// NewSavedSearch creates saved search service
+func NewSavedSearch(db *sql.DB) SavedSearch {
+	return pgsvc.NewSavedSearch(db)
+}
+
 // NewStats create stats service
 func NewStats(db *sql.DB) Stats {
 	return pgsvc.NewStats(db)
 }
 
 // NewChat create chat service
-func NewChat(db *sql.DB) Chat {
-	return pgsvc.NewChat(db)
+func NewChat(db *sql.DB, editWindow time.Duration) Chat {
+	return pgsvc.NewChat(db, editWindow)
+}
+
+// NewChatEvents creates service of real-time chat events
+// dbURL is used for a dedicated connection listening to notifications
+func NewChatEvents(db *sql.DB, dbURL string) ChatEvents {
+	return pgsvc.NewChatEvents(db, dbURL)
 }
@@ -1,12 +1,14 @@
 package web
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
 
 	"github.com/labstack/echo/v4"
 	"optrispace.com/work/pkg/clog"
+	"optrispace.com/work/pkg/model"
 	"optrispace.com/work/pkg/service"
 )
 
@@ -22,8 +24,16 @@ var exceptions = [][2]string{
 	{anyMethod, "/info"},
 	{http.MethodGet, "/stats"},
 	{http.MethodGet, "/jobs"},
+	{http.MethodGet, "/jobs/feed.atom"},
+	{http.MethodGet, "/jobs/feed.rss"},
+	{http.MethodGet, "/jobs/feed.json"},
 	{http.MethodGet, "/jobs/*"},
+	{http.MethodGet, "/jobs/*/attachments"},
+	{http.MethodGet, "/jobs/*/revisions"},
+	{http.MethodGet, "/attachments/*"},
+	{http.MethodGet, "/chats/ws"}, // authenticated by the controller, the ticket may be in the query
 	{anyMethod, "/notifications"},
+	{anyMethod, "/email-digest/unsubscribe"}, // authenticated by the token from the email
 	{http.MethodGet, "/swagger/*"},
 }
 
@@ -34,7 +44,8 @@ func authSkip(c echo.Context) bool {
 			return true
 		}
 
-		match, err := path.Match(exc[1], c.Request().RequestURI)
+		// query is not a part of the pattern, so filters of public lists and feeds are allowed
+		match, err := path.Match(exc[1], c.Request().URL.Path)
 		if err != nil {
 			panic(fmt.Errorf("invalid pattern %s: %w", exc, err))
 		}
@@ -55,7 +66,9 @@ func Auth(securitySvc service.Security) echo.MiddlewareFunc {
 				goto further
 			}
 
-			if _, e := securitySvc.FromEchoContext(c); e != nil {
+			if _, e := securitySvc.FromEchoContext(c); errors.Is(e, model.ErrInsufficientRights) {
+				return e // API key without scope of the route
+			} else if e != nil {
 				clog.Ectx(c).Warn().Err(e).Msg("Unable to authenticate user against bearer")
 			} else {
 				goto further
@@ -90,6 +90,10 @@ func GetErrorHandler(oldHandler echo.HTTPErrorHandler) echo.HTTPErrorHandler { /
 			status = http.StatusUnprocessableEntity // 422
 			cause = model.ErrInsufficientFunds      // "insufficient funds"
 
+		case errors.Is(err, model.ErrNotEnoughConnects):
+			status = http.StatusUnprocessableEntity // 422
+			cause = model.ErrNotEnoughConnects      // "not enough connects"
+
 		case errors.Is(err, model.ErrUnableToLogin):
 			status = http.StatusUnprocessableEntity // 422
 			cause = model.ErrUnableToLogin          // "unable to login"